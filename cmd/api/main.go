@@ -10,16 +10,25 @@ import (
 	"go.uber.org/zap"
 
 	_ "github.com/lib/pq"
+	authAdapter "github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
+	authInfra "github.com/sgraham785/gocleanarch-example/internal/auth/infrastructure"
+	authUseCase "github.com/sgraham785/gocleanarch-example/internal/auth/usecase"
+	authorAdapter "github.com/sgraham785/gocleanarch-example/internal/author/adapter"
+	authorInfra "github.com/sgraham785/gocleanarch-example/internal/author/infrastructure"
+	authorUseCase "github.com/sgraham785/gocleanarch-example/internal/author/usecase"
 	bookAdapter "github.com/sgraham785/gocleanarch-example/internal/book/adapter"
 	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
 	bookUseCase "github.com/sgraham785/gocleanarch-example/internal/book/usecase"
 	borrowAdapter "github.com/sgraham785/gocleanarch-example/internal/borrow/adapter"
+	borrowInfra "github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
 	borrowUseCase "github.com/sgraham785/gocleanarch-example/internal/borrow/usecase"
 	userAdapter "github.com/sgraham785/gocleanarch-example/internal/user/adapter"
 	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
 	userUseCase "github.com/sgraham785/gocleanarch-example/internal/user/usecase"
 	"github.com/sgraham785/gocleanarch-example/pkg/config"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/mailer"
+	"github.com/sgraham785/gocleanarch-example/pkg/password"
 	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
@@ -44,15 +53,52 @@ func main() {
 	}
 
 	bookRepo := bookInfra.NewPgRepo(server)
-	bookUseCase := bookUseCase.New(server, bookRepo)
+	copyRepo := bookInfra.NewCopyPgRepo(server)
+	subjectRepo := bookInfra.NewSubjectPgRepo(server)
+	workRepo := bookInfra.NewWorkPgRepo(server)
+	seriesRepo := bookInfra.NewSeriesPgRepo(server)
+	authorRepo := authorInfra.NewPgRepo(server)
+	holdRepo := borrowInfra.NewHoldPgRepo(server)
+	bookUnit := bookInfra.NewPgUnitOfWork(server)
+	bookUseCase := bookUseCase.New(server, bookRepo, copyRepo, subjectRepo, workRepo, seriesRepo, holdRepo, bookUnit)
+
+	authorUseCase := authorUseCase.New(server, authorRepo, bookUseCase)
+
+	passwordService, err := password.New(cfg.PasswordConf)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	mail, err := mailer.New(cfg.MailConf)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
 	userRepo := userInfra.NewPgRepo(server)
-	userUseCase := userUseCase.New(server, userRepo)
-	borrowUseCase := borrowUseCase.New(server, userUseCase, bookUseCase)
+	userUseCase := userUseCase.New(server, userRepo, passwordService)
+	fineRepo := borrowInfra.NewFinePgRepo(server)
+	borrowUnit := borrowInfra.NewPgUnitOfWork(server)
+	fineUseCase := borrowUseCase.NewFineUseCase(server, fineRepo, borrowUnit)
+	borrowUseCase := borrowUseCase.New(server, borrowUnit, fineUseCase)
+
+	tokenRepo := authInfra.NewPgRepo(server)
+	authUseCase := authUseCase.New(server, tokenRepo, userRepo, passwordService, mail)
 
+	// every route but these needs a bearer access token
+	r.Chi.Use(authAdapter.Authenticate(authUseCase, "/auth/login", "/auth/refresh", "/auth/password/forgot", "/auth/password/reset", "/ping", "/metrics"))
+
+	authAdapter.HTTPRoutes(server, authUseCase)
 	bookAdapter.HTTPRoutes(server, bookUseCase)
+	authorAdapter.HTTPRoutes(server, authorUseCase)
 	userAdapter.HTTPRoutes(server, userUseCase)
 	borrowAdapter.HTTPRoutes(server, bookUseCase, userUseCase, borrowUseCase)
+	borrowAdapter.FineHTTPRoutes(server, userUseCase, fineUseCase)
+
+	// an interval of zero or less turns the sweeper off
+	if cfg.OverdueSweepInterval > 0 {
+		go sweepOverdueLoans(server.Log, borrowUseCase, cfg.OverdueSweepInterval)
+	} else {
+		server.Log.Zap.Warn("overdue sweeper off", zap.Duration("interval", cfg.OverdueSweepInterval))
+	}
 
 	r.Chi.Handle("/", r.Chi)
 	r.Chi.Handle("/metrics", promhttp.Handler())
@@ -67,7 +113,7 @@ func main() {
 		Handler:      r.Chi,
 	}
 	server.Log.Zap.Info("Started on -> ", zap.Int("port", cfg.APIPort))
-	err := srv.ListenAndServe()
+	err = srv.ListenAndServe()
 	if err != nil {
 		log.Fatal(err.Error())
 	}
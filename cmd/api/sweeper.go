@@ -0,0 +1,80 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	borrowUseCase "github.com/sgraham785/gocleanarch-example/internal/borrow/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+)
+
+var (
+	overdueLoansMarked = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "library",
+		Name:      "loans_marked_overdue_total",
+		Help:      "How many loans the sweeper flagged as overdue.",
+	})
+	overdueLoans = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "library",
+		Name:      "loans_overdue",
+		Help:      "How many loans are past their due date.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(overdueLoansMarked, overdueLoans)
+}
+
+// sweepOverdueLoans flags overdue loans and expires unclaimed holds every interval, forever
+func sweepOverdueLoans(log *logger.Logger, u borrowUseCase.BorrowUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sweep(log, u)
+		<-ticker.C
+	}
+}
+
+func sweep(log *logger.Logger, u borrowUseCase.BorrowUseCase) {
+	sweepLoans(log, u)
+	sweepHolds(log, u)
+}
+
+func sweepLoans(log *logger.Logger, u borrowUseCase.BorrowUseCase) {
+	marked, err := u.MarkOverdueLoans()
+	for _, l := range marked {
+		log.Zap.Info("loan overdue",
+			zap.String("loan_id", l.ID.String()),
+			zap.String("user_id", l.UserID.String()),
+			zap.String("book_id", l.BookID.String()),
+			zap.Time("due_at", l.DueAt),
+		)
+	}
+	overdueLoansMarked.Add(float64(len(marked)))
+	if err != nil {
+		log.Zap.Error("marking overdue loans", zap.Error(err))
+		return
+	}
+	all, err := u.ListOverdueLoans()
+	if err != nil {
+		log.Zap.Error("listing overdue loans", zap.Error(err))
+		return
+	}
+	overdueLoans.Set(float64(len(all)))
+}
+
+func sweepHolds(log *logger.Logger, u borrowUseCase.BorrowUseCase) {
+	expired, err := u.ExpireHolds()
+	for _, h := range expired {
+		log.Zap.Info("hold expired",
+			zap.String("hold_id", h.ID.String()),
+			zap.String("user_id", h.UserID.String()),
+			zap.String("book_id", h.BookID.String()),
+		)
+	}
+	if err != nil {
+		log.Zap.Error("expiring holds", zap.Error(err))
+	}
+}
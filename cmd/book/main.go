@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/lib/pq"
+	bookAdapter "github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
+	bookUseCase "github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	borrowInfra "github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/metric"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+const usage = `usage: book import [--dry-run] [--format csv|marc] <file>
+       book export [--format csv|jsonl|marc] [--output <file>]`
+
+// params are the arguments of a command, an export without file goes to stdout
+type params struct {
+	file   string
+	format string
+	dryRun bool
+}
+
+func handleParams(args []string) (string, params, error) {
+	var p params
+	if len(args) < 2 {
+		return "", p, errors.New(usage)
+	}
+	flags := flag.NewFlagSet(args[1], flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	switch args[1] {
+	case "import":
+		flags.BoolVar(&p.dryRun, "dry-run", false, "validate the file without writing")
+		flags.StringVar(&p.format, "format", "", "csv or marc, guessed from the file extension when not given")
+		if err := flags.Parse(args[2:]); err != nil || flags.NArg() != 1 {
+			return "", p, errors.New(usage)
+		}
+		p.file = flags.Arg(0)
+		if p.format == "" {
+			switch strings.ToLower(filepath.Ext(p.file)) {
+			case ".csv":
+				p.format = bookAdapter.FormatCSV
+			case ".mrc", ".marc":
+				p.format = bookAdapter.FormatMARC
+			}
+		}
+	case "export":
+		flags.StringVar(&p.format, "format", bookAdapter.FormatJSONL, "csv, jsonl or marc")
+		flags.StringVar(&p.file, "output", "", "file to write, stdout when not given")
+		if err := flags.Parse(args[2:]); err != nil || flags.NArg() != 0 {
+			return "", p, errors.New(usage)
+		}
+	default:
+		return "", p, errors.New(usage)
+	}
+	return args[1], p, nil
+}
+
+func main() {
+	cfg := config.Load()
+
+	logger := logger.New()
+	defer logger.Zap.Sync()
+
+	command, params, err := handleParams(os.Args)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	metricService, err := metric.NewPrometheusService(cfg)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	appMetric := metric.NewCLI(command)
+	appMetric.Started()
+
+	db := repository.NewPostgresConn(cfg.PostgresConf)
+	defer db.Pg.Close()
+
+	server := &server.Server{
+		Cfg: cfg,
+		Log: logger,
+		DB:  db,
+	}
+
+	bookRepo := bookInfra.NewPgRepo(server)
+	copyRepo := bookInfra.NewCopyPgRepo(server)
+	subjectRepo := bookInfra.NewSubjectPgRepo(server)
+	workRepo := bookInfra.NewWorkPgRepo(server)
+	seriesRepo := bookInfra.NewSeriesPgRepo(server)
+	holdRepo := borrowInfra.NewHoldPgRepo(server)
+	bookUnit := bookInfra.NewPgUnitOfWork(server)
+	service := bookUseCase.New(server, bookRepo, copyRepo, subjectRepo, workRepo, seriesRepo, holdRepo, bookUnit)
+
+	switch command {
+	case "import":
+		err = importBooks(service, params)
+	case "export":
+		err = exportBooks(service, params)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	appMetric.Finished()
+	err = metricService.SaveCLI(appMetric)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func importBooks(service bookUseCase.BookUseCase, params params) error {
+	f, err := os.Open(params.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	src, err := bookAdapter.NewRecordReader(params.format, f)
+	if err != nil {
+		return err
+	}
+	report, err := service.ImportBooks(src, params.dryRun)
+	for _, r := range report.Rows {
+		fmt.Printf("%d\t%s\t%s\t%s\t%s\n", r.Row, r.Status, r.ISBN, r.Title, r.Error)
+	}
+	if err != nil {
+		return err
+	}
+	if report.DryRun {
+		fmt.Print("dry run: ")
+	}
+	fmt.Printf("%d created, %d updated, %d skipped, %d invalid\n", report.Created, report.Updated, report.Skipped, report.Invalid)
+	return nil
+}
+
+func exportBooks(service bookUseCase.BookUseCase, params params) error {
+	out := os.Stdout
+	if params.file != "" {
+		f, err := os.Create(params.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	w := bufio.NewWriter(out)
+	bw, err := bookAdapter.NewBookWriter(params.format, w)
+	if err != nil {
+		return err
+	}
+	err = service.ExportBooks(bookEntity.Criteria{}, bw.Write)
+	if err != nil {
+		return err
+	}
+	err = bw.Close()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
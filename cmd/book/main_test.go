@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportArgs(t *testing.T) {
+	command, p, err := handleParams([]string{"book", "import", "--dry-run", "catalog.mrc"})
+	assert.Nil(t, err)
+	assert.Equal(t, "import", command)
+	assert.Equal(t, params{file: "catalog.mrc", format: "marc", dryRun: true}, p)
+
+	_, p, err = handleParams([]string{"book", "import", "--format", "csv", "catalog.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, params{file: "catalog.txt", format: "csv"}, p)
+}
+
+func TestExportArgs(t *testing.T) {
+	command, p, err := handleParams([]string{"book", "export"})
+	assert.Nil(t, err)
+	assert.Equal(t, "export", command)
+	assert.Equal(t, params{format: "jsonl"}, p)
+
+	_, p, err = handleParams([]string{"book", "export", "--format", "marc", "--output", "catalog.mrc"})
+	assert.Nil(t, err)
+	assert.Equal(t, params{file: "catalog.mrc", format: "marc"}, p)
+}
+
+func TestWithoutArgs(t *testing.T) {
+	_, _, err := handleParams([]string{"book"})
+	assert.Equal(t, usage, err.Error())
+
+	_, _, err = handleParams([]string{"book", "import"})
+	assert.Equal(t, usage, err.Error())
+
+	_, _, err = handleParams([]string{"book", "export", "catalog.csv"})
+	assert.Equal(t, usage, err.Error())
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
+	borrowInfra "github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/pkg/metric"
 	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
@@ -52,8 +54,14 @@ func main() {
 	}
 
 	bookRepo := bookInfra.NewPgRepo(server)
-	service := bookUseCase.New(server, bookRepo)
-	all, err := service.SearchBooks(query)
+	copyRepo := bookInfra.NewCopyPgRepo(server)
+	subjectRepo := bookInfra.NewSubjectPgRepo(server)
+	workRepo := bookInfra.NewWorkPgRepo(server)
+	seriesRepo := bookInfra.NewSeriesPgRepo(server)
+	holdRepo := borrowInfra.NewHoldPgRepo(server)
+	bookUnit := bookInfra.NewPgUnitOfWork(server)
+	service := bookUseCase.New(server, bookRepo, copyRepo, subjectRepo, workRepo, seriesRepo, holdRepo, bookUnit)
+	all, _, err := service.SearchBooks(query, bookEntity.Criteria{}, repository.ListOptions{})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	_ "github.com/lib/pq"
+	userAdapter "github.com/sgraham785/gocleanarch-example/internal/user/adapter"
+	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
+	userUseCase "github.com/sgraham785/gocleanarch-example/internal/user/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/metric"
+	"github.com/sgraham785/gocleanarch-example/pkg/password"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+const usage = "usage: user export [--format csv|jsonl] [--output <file>]"
+
+// params are the arguments of a command, an export without file goes to stdout
+type params struct {
+	file   string
+	format string
+}
+
+func handleParams(args []string) (string, params, error) {
+	var p params
+	if len(args) < 2 || args[1] != "export" {
+		return "", p, errors.New(usage)
+	}
+	flags := flag.NewFlagSet(args[1], flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	flags.StringVar(&p.format, "format", userAdapter.FormatJSONL, "csv or jsonl")
+	flags.StringVar(&p.file, "output", "", "file to write, stdout when not given")
+	if err := flags.Parse(args[2:]); err != nil || flags.NArg() != 0 {
+		return "", p, errors.New(usage)
+	}
+	return args[1], p, nil
+}
+
+func main() {
+	cfg := config.Load()
+
+	logger := logger.New()
+	defer logger.Zap.Sync()
+
+	command, params, err := handleParams(os.Args)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	metricService, err := metric.NewPrometheusService(cfg)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	appMetric := metric.NewCLI(command)
+	appMetric.Started()
+
+	db := repository.NewPostgresConn(cfg.PostgresConf)
+	defer db.Pg.Close()
+
+	server := &server.Server{
+		Cfg: cfg,
+		Log: logger,
+		DB:  db,
+	}
+
+	passwordService, err := password.New(cfg.PasswordConf)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	userRepo := userInfra.NewPgRepo(server)
+	service := userUseCase.New(server, userRepo, passwordService)
+
+	err = exportUsers(service, params)
+	if err != nil {
+		log.Fatal(err)
+	}
+	appMetric.Finished()
+	err = metricService.SaveCLI(appMetric)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func exportUsers(service userUseCase.UserUseCase, params params) error {
+	out := os.Stdout
+	if params.file != "" {
+		f, err := os.Create(params.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	w := bufio.NewWriter(out)
+	uw, err := userAdapter.NewUserWriter(params.format, w)
+	if err != nil {
+		return err
+	}
+	err = service.ExportUsers(uw.Write)
+	if err != nil {
+		return err
+	}
+	err = uw.Close()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportArgs(t *testing.T) {
+	command, p, err := handleParams([]string{"user", "export", "--format", "csv", "--output", "users.csv"})
+	assert.Nil(t, err)
+	assert.Equal(t, "export", command)
+	assert.Equal(t, params{file: "users.csv", format: "csv"}, p)
+}
+
+func TestWithoutArgs(t *testing.T) {
+	_, _, err := handleParams([]string{"user"})
+	assert.Equal(t, usage, err.Error())
+
+	_, _, err = handleParams([]string{"user", "import"})
+	assert.Equal(t, usage, err.Error())
+}
@@ -0,0 +1,237 @@
+package adapter
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/usecase"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+// TokensHTTP JSON data
+type TokensHTTP struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresIn        int64     `json:"expires_in"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+func newTokensHTTP(t *entity.Tokens) *TokensHTTP {
+	return &TokensHTTP{
+		AccessToken:      t.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(time.Until(t.AccessExpiresAt).Seconds()),
+		RefreshToken:     t.RefreshToken,
+		RefreshExpiresAt: t.RefreshExpiresAt,
+	}
+}
+
+func writeTokens(w http.ResponseWriter, t *entity.Tokens, err error, errorMessage string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	switch err {
+	case nil:
+	case entity.ErrInvalidCredentials, entity.ErrInvalidToken, entity.ErrTokenRevoked:
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+		return
+	}
+	if err := json.NewEncoder(w).Encode(newTokensHTTP(t)); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+// LoginHTTP handler
+func LoginHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error logging in"
+		var input struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		t, err := authUseCase.Login(input.Email, input.Password)
+		writeTokens(w, t, err, errorMessage)
+	})
+}
+
+// RefreshHTTP handler
+func RefreshHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error refreshing token"
+		var input struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		t, err := authUseCase.Refresh(input.RefreshToken)
+		writeTokens(w, t, err, errorMessage)
+	})
+}
+
+// LogoutHTTP handler, the body may name the refresh token to revoke with the access token
+func LogoutHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error logging out"
+		var input struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		err = authUseCase.Logout(bearerToken(r), input.RefreshToken)
+		switch err {
+		case nil:
+		case entity.ErrInvalidToken, entity.ErrTokenRevoked:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// ForgotPasswordHTTP handler, answers the same whether the email is known or not
+func ForgotPasswordHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error requesting a password reset"
+		var input struct {
+			Email string `json:"email"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil || input.Email == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		err = authUseCase.ForgotPassword(input.Email)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// ResetPasswordHTTP handler
+func ResetPasswordHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error resetting password"
+		var input struct {
+			Token    string `json:"token"`
+			Password string `json:"password"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		err = authUseCase.ResetPassword(input.Token, input.Password)
+		switch err {
+		case nil:
+		case entity.ErrInvalidToken, userEntity.ErrInvalidUserEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// RequestVerificationHTTP handler, mails a verification token to the user
+func RequestVerificationHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error requesting email verification"
+		err := authUseCase.RequestVerification(chi.URLParam(r, "userID"))
+		writeVerification(w, err, http.StatusAccepted, errorMessage)
+	})
+}
+
+// VerifyEmailHTTP handler
+func VerifyEmailHTTP(authUseCase usecase.AuthUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error verifying email"
+		var input struct {
+			Token string `json:"token"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		err = authUseCase.VerifyEmail(chi.URLParam(r, "userID"), input.Token)
+		writeVerification(w, err, http.StatusNoContent, errorMessage)
+	})
+}
+
+func writeVerification(w http.ResponseWriter, err error, status int, errorMessage string) {
+	switch err {
+	case nil:
+	case userEntity.ErrUserNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
+		return
+	case userEntity.ErrUserAlreadyVerified:
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(err.Error()))
+		return
+	case entity.ErrInvalidToken:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+		return
+	}
+	w.WriteHeader(status)
+}
+
+// HTTPRoutes make url handlers, forgot and reset are meant to be public
+func HTTPRoutes(s *server.Server, authUseCase usecase.AuthUseCase) {
+	s.Router.Chi.Route("/auth", func(r chi.Router) {
+		r.Post("/login", LoginHTTP(authUseCase))
+		r.Post("/refresh", RefreshHTTP(authUseCase))
+		r.Post("/logout", LogoutHTTP(authUseCase))
+		r.Post("/password/forgot", ForgotPasswordHTTP(authUseCase))
+		r.Post("/password/reset", ResetPasswordHTTP(authUseCase))
+	})
+	selfOrStaff := router.Authorize(Self, Staff)
+	s.Router.Chi.With(selfOrStaff).Post("/user/{userID}/verification", RequestVerificationHTTP(authUseCase))
+	s.Router.Chi.With(selfOrStaff).Post("/user/{userID}/verify", VerifyEmailHTTP(authUseCase))
+}
@@ -0,0 +1,236 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/mock"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockAuthUseCase(controller)
+	r := router.NewChiRouter()
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		m.EXPECT().Login("ozzy@metalgods.net", "wrong").Return(nil, entity.ErrInvalidCredentials)
+		res, err := http.Post(ts.URL+"/auth/login", "application/json", strings.NewReader(`{"email":"ozzy@metalgods.net","password":"wrong"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m.EXPECT().Login("ozzy@metalgods.net", "bateater666").Return(&entity.Tokens{
+			AccessToken:      "access",
+			AccessExpiresAt:  time.Now().Add(15 * time.Minute),
+			RefreshToken:     "refresh",
+			RefreshExpiresAt: time.Now().Add(24 * time.Hour),
+		}, nil)
+		res, err := http.Post(ts.URL+"/auth/login", "application/json", strings.NewReader(`{"email":"ozzy@metalgods.net","password":"bateater666"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d *adapter.TokensHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, "access", d.AccessToken)
+		assert.Equal(t, "refresh", d.RefreshToken)
+		assert.Equal(t, "Bearer", d.TokenType)
+		assert.InDelta(t, 900, d.ExpiresIn, 5)
+	})
+}
+
+func TestRefreshHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockAuthUseCase(controller)
+	r := router.NewChiRouter()
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	m.EXPECT().Refresh("used").Return(nil, entity.ErrTokenRevoked)
+	res, err := http.Post(ts.URL+"/auth/refresh", "application/json", strings.NewReader(`{"refresh_token":"used"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	m.EXPECT().Refresh("fresh").Return(&entity.Tokens{AccessToken: "access", RefreshToken: "next"}, nil)
+	res, err = http.Post(ts.URL+"/auth/refresh", "application/json", strings.NewReader(`{"refresh_token":"fresh"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestLogoutHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockAuthUseCase(controller)
+	r := router.NewChiRouter()
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+
+	m.EXPECT().Logout("access", "refresh").Return(nil)
+	req, _ := http.NewRequest("POST", "/auth/logout", strings.NewReader(`{"refresh_token":"refresh"}`))
+	req.Header.Set("Authorization", "Bearer access")
+	rr := httptest.NewRecorder()
+	r.Chi.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	m.EXPECT().Logout("", "").Return(entity.ErrInvalidToken)
+	req = httptest.NewRequest("POST", "/auth/logout", nil)
+	rr = httptest.NewRecorder()
+	r.Chi.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestAuthenticate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockAuthUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(adapter.Authenticate(m, "/ping"))
+	r.Chi.Get("/ping", func(w http.ResponseWriter, r *http.Request) {})
+	r.Chi.Get("/me", func(w http.ResponseWriter, r *http.Request) {
+		u, ok := adapter.UserFromContext(r.Context())
+		assert.True(t, ok)
+		w.Write([]byte(u.ID.String()))
+	})
+
+	t.Run("public path", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, httptest.NewRequest("GET", "/ping", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, httptest.NewRequest("GET", "/me", nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.Equal(t, "Bearer", rr.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		m.EXPECT().Authenticate("revoked").Return(nil, entity.ErrTokenRevoked)
+		req := httptest.NewRequest("GET", "/me", nil)
+		req.Header.Set("Authorization", "Bearer revoked")
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("user in context", func(t *testing.T) {
+		u := &userEntity.User{
+			ID: userEntity.NewID(),
+		}
+		m.EXPECT().Authenticate("valid").Return(u, nil)
+		req := httptest.NewRequest("GET", "/me", nil)
+		req.Header.Set("Authorization", "bearer valid")
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, u.ID.String(), rr.Body.String())
+	})
+}
+
+func TestPasswordResetHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockAuthUseCase(controller)
+	r := router.NewChiRouter()
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	t.Run("forgot", func(t *testing.T) {
+		m.EXPECT().ForgotPassword("ozzy@metalgods.net").Return(nil)
+		res, err := http.Post(ts.URL+"/auth/password/forgot", "application/json", strings.NewReader(`{"email":"ozzy@metalgods.net"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, res.StatusCode)
+	})
+
+	t.Run("reset invalid token", func(t *testing.T) {
+		m.EXPECT().ResetPassword("nope", "newpassword").Return(entity.ErrInvalidToken)
+		res, err := http.Post(ts.URL+"/auth/password/reset", "application/json", strings.NewReader(`{"token":"nope","password":"newpassword"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		m.EXPECT().ResetPassword("secret", "newpassword").Return(nil)
+		res, err := http.Post(ts.URL+"/auth/password/reset", "application/json", strings.NewReader(`{"token":"secret","password":"newpassword"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	})
+}
+
+func TestVerifyEmailHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockAuthUseCase(controller)
+	member := userEntity.NewID()
+	r := router.NewChiRouter()
+	r.Chi.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p := router.Principal{ID: member.String(), Role: string(userEntity.RoleMember)}
+			next.ServeHTTP(w, r.WithContext(router.WithPrincipal(r.Context(), p)))
+		})
+	})
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+
+	t.Run("request", func(t *testing.T) {
+		m.EXPECT().RequestVerification(member.String()).Return(nil)
+		req := httptest.NewRequest("POST", "/user/"+member.String()+"/verification", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		m.EXPECT().VerifyEmail(member.String(), "secret").Return(userEntity.ErrUserAlreadyVerified)
+		req := httptest.NewRequest("POST", "/user/"+member.String()+"/verify", strings.NewReader(`{"token":"secret"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		m.EXPECT().VerifyEmail(member.String(), "secret").Return(nil)
+		req := httptest.NewRequest("POST", "/user/"+member.String()+"/verify", strings.NewReader(`{"token":"secret"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("someone else", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/user/"+userEntity.NewID().String()+"/verify", strings.NewReader(`{"token":"secret"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
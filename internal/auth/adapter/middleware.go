@@ -0,0 +1,79 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/usecase"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+)
+
+type contextKey struct{}
+
+// userContextKey is where Authenticate puts the user of the request
+var userContextKey = contextKey{}
+
+// WithUser returns a copy of ctx carrying the authenticated user
+func WithUser(ctx context.Context, u *userEntity.User) context.Context {
+	return context.WithValue(ctx, userContextKey, u)
+}
+
+// UserFromContext returns the authenticated user of the request, if any
+func UserFromContext(ctx context.Context) (*userEntity.User, bool) {
+	u, ok := ctx.Value(userContextKey).(*userEntity.User)
+	return u, ok && u != nil
+}
+
+// Authenticate is a middleware that rejects requests without a valid bearer
+// access token and puts the user it was issued to in the request context,
+// both as is and as the router.Principal authorization policies look at.
+// Requests to the public paths go through untouched.
+func Authenticate(authUseCase usecase.AuthUseCase, public ...string) func(next http.Handler) http.Handler {
+	open := map[string]bool{}
+	for _, p := range public {
+		open[p] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if open[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			t := bearerToken(r)
+			if t == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("Authentication required"))
+				return
+			}
+			u, err := authUseCase.Authenticate(t)
+			switch err {
+			case nil:
+			case entity.ErrInvalidToken, entity.ErrTokenRevoked:
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(err.Error()))
+				return
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte("Error authenticating"))
+				return
+			}
+			ctx := WithUser(r.Context(), u)
+			ctx = router.WithPrincipal(ctx, router.Principal{ID: u.ID.String(), Role: string(u.Role)})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// bearerToken reads the token of an "Authorization: Bearer <token>" header
+func bearerToken(r *http.Request) string {
+	h := r.Header.Get("Authorization")
+	if len(h) < 7 || !strings.EqualFold(h[:7], "bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(h[7:])
+}
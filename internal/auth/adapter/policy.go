@@ -0,0 +1,16 @@
+package adapter
+
+import (
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+)
+
+var (
+	// Staff lets librarians through, and admins who can do anything a librarian does
+	Staff = router.AnyRole(string(userEntity.RoleLibrarian), string(userEntity.RoleAdmin))
+	// Admin lets admins through
+	Admin = router.AnyRole(string(userEntity.RoleAdmin))
+)
+
+// Self lets through the user named by the userID URL parameter
+var Self = router.Self("userID")
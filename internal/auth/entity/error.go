@@ -0,0 +1,24 @@
+package entity
+
+import "errors"
+
+// ErrInvalidCredentials wrong email or password
+var ErrInvalidCredentials = errors.New("Invalid credentials")
+
+// ErrInvalidToken cannot authenticate
+var ErrInvalidToken = errors.New("Invalid token")
+
+// ErrTokenRevoked cannot authenticate
+var ErrTokenRevoked = errors.New("Token revoked")
+
+// ErrRefreshTokenNotFound not found
+var ErrRefreshTokenNotFound = errors.New("Refresh token not found")
+
+// ErrInvalidRefreshToken invalid refresh token entity
+var ErrInvalidRefreshToken = errors.New("Invalid refresh token entity")
+
+// ErrOneTimeTokenNotFound not found
+var ErrOneTimeTokenNotFound = errors.New("One time token not found")
+
+// ErrInvalidOneTimeToken invalid one time token entity
+var ErrInvalidOneTimeToken = errors.New("Invalid one time token entity")
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/rs/xid"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+// Purpose is what a one time token was issued for
+type Purpose string
+
+const (
+	// PurposePasswordReset lets the user choose a new password
+	PurposePasswordReset Purpose = "password_reset"
+	// PurposeEmailVerification proves the user owns its email
+	PurposeEmailVerification Purpose = "email_verification"
+)
+
+// OneTimeToken is a single use token mailed to an user, only the hash of its secret is kept
+type OneTimeToken struct {
+	ID        ID
+	UserID    userEntity.ID
+	Purpose   Purpose
+	Email     string
+	Hash      string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    time.Time
+}
+
+// NewOneTimeToken issues a token for purpose to the user at email, valid for ttl,
+// and returns it with the secret to mail
+func NewOneTimeToken(userID userEntity.ID, purpose Purpose, email string, ttl time.Duration) (*OneTimeToken, string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+	t := &OneTimeToken{
+		ID:        xid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		Email:     email,
+		Hash:      HashSecret(secret),
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	err = t.Validate()
+	if err != nil {
+		return nil, "", ErrInvalidOneTimeToken
+	}
+	return t, secret, nil
+}
+
+// HashSecret is how the secret of a one time token is looked up
+func HashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// IsValid tells if the token can still be used at t
+func (o *OneTimeToken) IsValid(t time.Time) bool {
+	return o.UsedAt.IsZero() && t.Before(o.ExpiresAt)
+}
+
+// Use spends the token, it cannot be used again
+func (o *OneTimeToken) Use(t time.Time) error {
+	if !o.IsValid(t) {
+		return ErrInvalidToken
+	}
+	o.UsedAt = t
+	return nil
+}
+
+// Validate validate one time token
+func (o *OneTimeToken) Validate() error {
+	if o.UserID.IsNil() || o.Email == "" || o.Hash == "" || !o.ExpiresAt.After(o.CreatedAt) {
+		return ErrInvalidOneTimeToken
+	}
+	if o.Purpose != PurposePasswordReset && o.Purpose != PurposeEmailVerification {
+		return ErrInvalidOneTimeToken
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOneTimeToken(t *testing.T) {
+	uID := userEntity.NewID()
+	o, secret, err := entity.NewOneTimeToken(uID, entity.PurposePasswordReset, "ozzy@metalgods.net", time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, uID, o.UserID)
+	assert.NotEmpty(t, secret)
+	assert.NotEqual(t, secret, o.Hash)
+	assert.Equal(t, entity.HashSecret(secret), o.Hash)
+	assert.True(t, o.IsValid(time.Now()))
+	assert.False(t, o.IsValid(o.ExpiresAt))
+
+	_, other, _ := entity.NewOneTimeToken(uID, entity.PurposePasswordReset, "ozzy@metalgods.net", time.Hour)
+	assert.NotEqual(t, secret, other)
+
+	_, _, err = entity.NewOneTimeToken(uID, "unlock", "ozzy@metalgods.net", time.Hour)
+	assert.Equal(t, entity.ErrInvalidOneTimeToken, err)
+	_, _, err = entity.NewOneTimeToken(uID, entity.PurposeEmailVerification, "", time.Hour)
+	assert.Equal(t, entity.ErrInvalidOneTimeToken, err)
+	_, _, err = entity.NewOneTimeToken(uID, entity.PurposeEmailVerification, "ozzy@metalgods.net", 0)
+	assert.Equal(t, entity.ErrInvalidOneTimeToken, err)
+}
+
+func TestOneTimeToken_Use(t *testing.T) {
+	o, _, _ := entity.NewOneTimeToken(userEntity.NewID(), entity.PurposeEmailVerification, "ozzy@metalgods.net", time.Hour)
+	err := o.Use(time.Now())
+	assert.Nil(t, err)
+	assert.False(t, o.IsValid(time.Now()))
+	err = o.Use(time.Now())
+	assert.Equal(t, entity.ErrInvalidToken, err)
+
+	expired, _, _ := entity.NewOneTimeToken(userEntity.NewID(), entity.PurposeEmailVerification, "ozzy@metalgods.net", time.Hour)
+	err = expired.Use(expired.ExpiresAt)
+	assert.Equal(t, entity.ErrInvalidToken, err)
+}
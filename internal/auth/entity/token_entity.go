@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+// ID is id for refresh token
+type ID = xid.ID
+
+// NewID create a new refresh token entity ID
+func NewID() ID {
+	return xid.New()
+}
+
+func IDFromString(id string) (xid.ID, error) {
+	i, err := xid.FromString(id)
+	return i, err
+}
+
+// RefreshToken is a long lived token an user trades for new access tokens
+type RefreshToken struct {
+	ID        ID
+	UserID    userEntity.ID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// NewRefreshToken issues a refresh token to an user, valid for ttl
+func NewRefreshToken(userID userEntity.ID, ttl time.Duration) (*RefreshToken, error) {
+	now := time.Now()
+	t := &RefreshToken{
+		ID:        xid.New(),
+		UserID:    userID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	err := t.Validate()
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	return t, nil
+}
+
+// IsValid tells if the token can still be used at t
+func (r *RefreshToken) IsValid(t time.Time) bool {
+	return r.RevokedAt.IsZero() && t.Before(r.ExpiresAt)
+}
+
+// Revoke stops the token from being used again
+func (r *RefreshToken) Revoke() error {
+	if !r.RevokedAt.IsZero() {
+		return ErrTokenRevoked
+	}
+	r.RevokedAt = time.Now()
+	return nil
+}
+
+// Validate validate refresh token
+func (r *RefreshToken) Validate() error {
+	if r.UserID.IsNil() || !r.ExpiresAt.After(r.CreatedAt) {
+		return ErrInvalidRefreshToken
+	}
+	return nil
+}
+
+// Tokens is what an user gets back from a login or a refresh
+type Tokens struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
@@ -0,0 +1,34 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	uID := userEntity.NewID()
+	r, err := entity.NewRefreshToken(uID, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, uID, r.UserID)
+	assert.Equal(t, time.Hour, r.ExpiresAt.Sub(r.CreatedAt))
+	assert.True(t, r.IsValid(time.Now()))
+	assert.False(t, r.IsValid(r.ExpiresAt))
+
+	_, err = entity.NewRefreshToken(userEntity.ID{}, time.Hour)
+	assert.Equal(t, entity.ErrInvalidRefreshToken, err)
+	_, err = entity.NewRefreshToken(uID, 0)
+	assert.Equal(t, entity.ErrInvalidRefreshToken, err)
+}
+
+func TestRefreshToken_Revoke(t *testing.T) {
+	r, _ := entity.NewRefreshToken(userEntity.NewID(), time.Hour)
+	err := r.Revoke()
+	assert.Nil(t, err)
+	assert.False(t, r.IsValid(time.Now()))
+	err = r.Revoke()
+	assert.Equal(t, entity.ErrTokenRevoked, err)
+}
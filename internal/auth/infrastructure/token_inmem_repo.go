@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+type tokenInMemRepo struct {
+	mtx     sync.RWMutex
+	m       map[entity.ID]*entity.RefreshToken
+	revoked map[string]time.Time
+	oneTime map[string]*entity.OneTimeToken
+}
+
+// NewInMemRepo create token in memory repository
+func NewInMemRepo() TokenRepo {
+	return &tokenInMemRepo{
+		m:       map[entity.ID]*entity.RefreshToken{},
+		revoked: map[string]time.Time{},
+		oneTime: map[string]*entity.OneTimeToken{},
+	}
+}
+
+// GetRefresh get a refresh token
+func (r *tokenInMemRepo) GetRefresh(id entity.ID) (*entity.RefreshToken, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrRefreshTokenNotFound
+	}
+	t := *r.m[id]
+	return &t, nil
+}
+
+// IsRevoked tells if an access token was revoked before it expired
+func (r *tokenInMemRepo) IsRevoked(tokenID string) (bool, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	expiresAt, ok := r.revoked[tokenID]
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+// CreateRefresh store a refresh token
+func (r *tokenInMemRepo) CreateRefresh(e *entity.RefreshToken) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.ID] = e
+	return nil
+}
+
+// UpdateRefresh update a refresh token, unless someone revoked it first
+func (r *tokenInMemRepo) UpdateRefresh(e *entity.RefreshToken) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	t := r.m[e.ID]
+	if t == nil {
+		return entity.ErrRefreshTokenNotFound
+	}
+	if !t.RevokedAt.IsZero() {
+		return entity.ErrTokenRevoked
+	}
+	saved := *e
+	r.m[e.ID] = &saved
+	return nil
+}
+
+// RevokeUserRefresh revokes every refresh token of a user still open
+func (r *tokenInMemRepo) RevokeUserRefresh(userID userEntity.ID, at time.Time) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for id, t := range r.m {
+		if t.UserID == userID && t.RevokedAt.IsZero() {
+			revoked := *t
+			revoked.RevokedAt = at
+			r.m[id] = &revoked
+		}
+	}
+	return nil
+}
+
+// Revoke adds an access token to the revocation list until it expires
+func (r *tokenInMemRepo) Revoke(tokenID string, expiresAt time.Time) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.revoked[tokenID] = expiresAt
+	return nil
+}
+
+// GetOneTime finds a one time token by the hash of its secret
+func (r *tokenInMemRepo) GetOneTime(hash string) (*entity.OneTimeToken, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.oneTime[hash] == nil {
+		return nil, entity.ErrOneTimeTokenNotFound
+	}
+	o := *r.oneTime[hash]
+	return &o, nil
+}
+
+// CreateOneTime store a one time token
+func (r *tokenInMemRepo) CreateOneTime(e *entity.OneTimeToken) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	o := *e
+	r.oneTime[e.Hash] = &o
+	return nil
+}
+
+// UseOneTime marks a one time token used, unless someone used it first
+func (r *tokenInMemRepo) UseOneTime(e *entity.OneTimeToken) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	o := r.oneTime[e.Hash]
+	if o == nil || !o.UsedAt.IsZero() {
+		return entity.ErrInvalidToken
+	}
+	o.UsedAt = e.UsedAt
+	return nil
+}
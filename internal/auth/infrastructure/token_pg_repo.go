@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+// tokenPgRepo pg database repo
+type tokenPgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewPgRepo create new token postgres repo
+func NewPgRepo(s *server.Server) TokenRepo {
+	return &tokenPgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// GetRefresh get a refresh token
+func (r *tokenPgRepo) GetRefresh(id entity.ID) (*entity.RefreshToken, error) {
+	query := `select id, user_id, created_at, expires_at, revoked_at from refresh_token where id = $1`
+	var t entity.RefreshToken
+	var revokedAt sql.NullTime
+	err := r.db.QueryRowx(query, id).Scan(&t.ID, &t.UserID, &t.CreatedAt, &t.ExpiresAt, &revokedAt)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.RevokedAt = revokedAt.Time
+	return &t, nil
+}
+
+// IsRevoked tells if an access token was revoked before it expired
+func (r *tokenPgRepo) IsRevoked(tokenID string) (bool, error) {
+	var n int
+	err := r.db.QueryRowx(`select count(*) from revoked_token where id = $1 and expires_at > $2`, tokenID, time.Now()).Scan(&n)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// CreateRefresh store a refresh token
+func (r *tokenPgRepo) CreateRefresh(e *entity.RefreshToken) error {
+	query := `insert into refresh_token (id, user_id, created_at, expires_at) values($1,$2,$3,$4)`
+	_, err := r.db.Exec(query, e.ID, e.UserID, e.CreatedAt, e.ExpiresAt)
+	return err
+}
+
+// UpdateRefresh update a refresh token, unless someone revoked it first
+func (r *tokenPgRepo) UpdateRefresh(e *entity.RefreshToken) error {
+	revokedAt := sql.NullTime{Time: e.RevokedAt, Valid: !e.RevokedAt.IsZero()}
+	res, err := r.db.Exec(`update refresh_token set revoked_at = $1 where id = $2 and revoked_at is null`, revokedAt, e.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		_, err = r.GetRefresh(e.ID)
+		if err != nil {
+			return err
+		}
+		return entity.ErrTokenRevoked
+	}
+	return nil
+}
+
+// RevokeUserRefresh revokes every refresh token of a user still open
+func (r *tokenPgRepo) RevokeUserRefresh(userID userEntity.ID, at time.Time) error {
+	_, err := r.db.Exec(`update refresh_token set revoked_at = $1 where user_id = $2 and revoked_at is null`, at, userID)
+	return err
+}
+
+// Revoke adds an access token to the revocation list until it expires,
+// expired entries are dropped on the way
+func (r *tokenPgRepo) Revoke(tokenID string, expiresAt time.Time) error {
+	_, err := r.db.Exec(`delete from revoked_token where expires_at <= $1`, time.Now())
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(`insert into revoked_token (id, expires_at) values($1,$2) on conflict (id) do nothing`, tokenID, expiresAt)
+	return err
+}
+
+// GetOneTime finds a one time token by the hash of its secret
+func (r *tokenPgRepo) GetOneTime(hash string) (*entity.OneTimeToken, error) {
+	query := `select id, user_id, purpose, email, hash, created_at, expires_at, used_at from one_time_token where hash = $1`
+	var t entity.OneTimeToken
+	var usedAt sql.NullTime
+	err := r.db.QueryRowx(query, hash).Scan(&t.ID, &t.UserID, &t.Purpose, &t.Email, &t.Hash, &t.CreatedAt, &t.ExpiresAt, &usedAt)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrOneTimeTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.UsedAt = usedAt.Time
+	return &t, nil
+}
+
+// CreateOneTime store a one time token
+func (r *tokenPgRepo) CreateOneTime(e *entity.OneTimeToken) error {
+	query := `insert into one_time_token (id, user_id, purpose, email, hash, created_at, expires_at) values($1,$2,$3,$4,$5,$6,$7)`
+	_, err := r.db.Exec(query, e.ID, e.UserID, e.Purpose, e.Email, e.Hash, e.CreatedAt, e.ExpiresAt)
+	return err
+}
+
+// UseOneTime marks a one time token used, unless someone used it first
+func (r *tokenPgRepo) UseOneTime(e *entity.OneTimeToken) error {
+	res, err := r.db.Exec(`update one_time_token set used_at = $1 where id = $2 and used_at is null`, e.UsedAt, e.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrInvalidToken
+	}
+	return nil
+}
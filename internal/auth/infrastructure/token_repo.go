@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+//go:generate mockgen -destination=../mock/token_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/auth/infrastructure Reader,Writer,TokenRepo
+
+// Reader interface
+type Reader interface {
+	GetRefresh(id entity.ID) (*entity.RefreshToken, error)
+	IsRevoked(tokenID string) (bool, error)
+	GetOneTime(hash string) (*entity.OneTimeToken, error)
+}
+
+// Writer token writer
+type Writer interface {
+	CreateRefresh(e *entity.RefreshToken) error
+	UpdateRefresh(e *entity.RefreshToken) error
+	RevokeUserRefresh(userID userEntity.ID, at time.Time) error
+	Revoke(tokenID string, expiresAt time.Time) error
+	CreateOneTime(e *entity.OneTimeToken) error
+	UseOneTime(e *entity.OneTimeToken) error
+}
+
+// TokenRepo keeps refresh tokens, the list of revoked access tokens and one time tokens
+type TokenRepo interface {
+	Reader
+	Writer
+}
@@ -0,0 +1,341 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/auth/infrastructure (interfaces: Reader,Writer,TokenRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// GetOneTime mocks base method.
+func (m *MockReader) GetOneTime(arg0 string) (*entity.OneTimeToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneTime", arg0)
+	ret0, _ := ret[0].(*entity.OneTimeToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneTime indicates an expected call of GetOneTime.
+func (mr *MockReaderMockRecorder) GetOneTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneTime", reflect.TypeOf((*MockReader)(nil).GetOneTime), arg0)
+}
+
+// GetRefresh mocks base method.
+func (m *MockReader) GetRefresh(arg0 xid.ID) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefresh", arg0)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefresh indicates an expected call of GetRefresh.
+func (mr *MockReaderMockRecorder) GetRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefresh", reflect.TypeOf((*MockReader)(nil).GetRefresh), arg0)
+}
+
+// IsRevoked mocks base method.
+func (m *MockReader) IsRevoked(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockReaderMockRecorder) IsRevoked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockReader)(nil).IsRevoked), arg0)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// CreateOneTime mocks base method.
+func (m *MockWriter) CreateOneTime(arg0 *entity.OneTimeToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOneTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOneTime indicates an expected call of CreateOneTime.
+func (mr *MockWriterMockRecorder) CreateOneTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOneTime", reflect.TypeOf((*MockWriter)(nil).CreateOneTime), arg0)
+}
+
+// CreateRefresh mocks base method.
+func (m *MockWriter) CreateRefresh(arg0 *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefresh", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefresh indicates an expected call of CreateRefresh.
+func (mr *MockWriterMockRecorder) CreateRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefresh", reflect.TypeOf((*MockWriter)(nil).CreateRefresh), arg0)
+}
+
+// Revoke mocks base method.
+func (m *MockWriter) Revoke(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockWriterMockRecorder) Revoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockWriter)(nil).Revoke), arg0, arg1)
+}
+
+// RevokeUserRefresh mocks base method.
+func (m *MockWriter) RevokeUserRefresh(arg0 xid.ID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefresh", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefresh indicates an expected call of RevokeUserRefresh.
+func (mr *MockWriterMockRecorder) RevokeUserRefresh(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefresh", reflect.TypeOf((*MockWriter)(nil).RevokeUserRefresh), arg0, arg1)
+}
+
+// UpdateRefresh mocks base method.
+func (m *MockWriter) UpdateRefresh(arg0 *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefresh", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRefresh indicates an expected call of UpdateRefresh.
+func (mr *MockWriterMockRecorder) UpdateRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefresh", reflect.TypeOf((*MockWriter)(nil).UpdateRefresh), arg0)
+}
+
+// UseOneTime mocks base method.
+func (m *MockWriter) UseOneTime(arg0 *entity.OneTimeToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseOneTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseOneTime indicates an expected call of UseOneTime.
+func (mr *MockWriterMockRecorder) UseOneTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseOneTime", reflect.TypeOf((*MockWriter)(nil).UseOneTime), arg0)
+}
+
+// MockTokenRepo is a mock of TokenRepo interface.
+type MockTokenRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRepoMockRecorder
+}
+
+// MockTokenRepoMockRecorder is the mock recorder for MockTokenRepo.
+type MockTokenRepoMockRecorder struct {
+	mock *MockTokenRepo
+}
+
+// NewMockTokenRepo creates a new mock instance.
+func NewMockTokenRepo(ctrl *gomock.Controller) *MockTokenRepo {
+	mock := &MockTokenRepo{ctrl: ctrl}
+	mock.recorder = &MockTokenRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRepo) EXPECT() *MockTokenRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateOneTime mocks base method.
+func (m *MockTokenRepo) CreateOneTime(arg0 *entity.OneTimeToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOneTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOneTime indicates an expected call of CreateOneTime.
+func (mr *MockTokenRepoMockRecorder) CreateOneTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOneTime", reflect.TypeOf((*MockTokenRepo)(nil).CreateOneTime), arg0)
+}
+
+// CreateRefresh mocks base method.
+func (m *MockTokenRepo) CreateRefresh(arg0 *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefresh", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefresh indicates an expected call of CreateRefresh.
+func (mr *MockTokenRepoMockRecorder) CreateRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefresh", reflect.TypeOf((*MockTokenRepo)(nil).CreateRefresh), arg0)
+}
+
+// GetOneTime mocks base method.
+func (m *MockTokenRepo) GetOneTime(arg0 string) (*entity.OneTimeToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOneTime", arg0)
+	ret0, _ := ret[0].(*entity.OneTimeToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOneTime indicates an expected call of GetOneTime.
+func (mr *MockTokenRepoMockRecorder) GetOneTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOneTime", reflect.TypeOf((*MockTokenRepo)(nil).GetOneTime), arg0)
+}
+
+// GetRefresh mocks base method.
+func (m *MockTokenRepo) GetRefresh(arg0 xid.ID) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefresh", arg0)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefresh indicates an expected call of GetRefresh.
+func (mr *MockTokenRepoMockRecorder) GetRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefresh", reflect.TypeOf((*MockTokenRepo)(nil).GetRefresh), arg0)
+}
+
+// IsRevoked mocks base method.
+func (m *MockTokenRepo) IsRevoked(arg0 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockTokenRepoMockRecorder) IsRevoked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockTokenRepo)(nil).IsRevoked), arg0)
+}
+
+// Revoke mocks base method.
+func (m *MockTokenRepo) Revoke(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockTokenRepoMockRecorder) Revoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenRepo)(nil).Revoke), arg0, arg1)
+}
+
+// RevokeUserRefresh mocks base method.
+func (m *MockTokenRepo) RevokeUserRefresh(arg0 xid.ID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserRefresh", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserRefresh indicates an expected call of RevokeUserRefresh.
+func (mr *MockTokenRepoMockRecorder) RevokeUserRefresh(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRefresh", reflect.TypeOf((*MockTokenRepo)(nil).RevokeUserRefresh), arg0, arg1)
+}
+
+// UpdateRefresh mocks base method.
+func (m *MockTokenRepo) UpdateRefresh(arg0 *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefresh", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRefresh indicates an expected call of UpdateRefresh.
+func (mr *MockTokenRepoMockRecorder) UpdateRefresh(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefresh", reflect.TypeOf((*MockTokenRepo)(nil).UpdateRefresh), arg0)
+}
+
+// UseOneTime mocks base method.
+func (m *MockTokenRepo) UseOneTime(arg0 *entity.OneTimeToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseOneTime", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseOneTime indicates an expected call of UseOneTime.
+func (mr *MockTokenRepoMockRecorder) UseOneTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseOneTime", reflect.TypeOf((*MockTokenRepo)(nil).UseOneTime), arg0)
+}
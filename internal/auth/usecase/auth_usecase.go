@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/infrastructure"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/mailer"
+	"github.com/sgraham785/gocleanarch-example/pkg/password"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"github.com/sgraham785/gocleanarch-example/pkg/token"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -destination=../mock/auth_usecase_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/auth/usecase AuthUseCase
+
+const (
+	accessKind  = "access"
+	refreshKind = "refresh"
+)
+
+// AuthUseCase is the interface that provides the methods.
+type AuthUseCase interface {
+	Login(email, password string) (*entity.Tokens, error)
+	Refresh(refreshToken string) (*entity.Tokens, error)
+	Logout(accessToken, refreshToken string) error
+	Authenticate(accessToken string) (*userEntity.User, error)
+	ForgotPassword(email string) error
+	ResetPassword(token, password string) error
+	RequestVerification(userID string) error
+	VerifyEmail(userID, token string) error
+}
+
+type authUseCase struct {
+	repo       infrastructure.TokenRepo
+	userRepo   userInfra.UserRepo
+	password   password.Service
+	mailer     mailer.Mailer
+	signer     *token.Signer
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	resetTTL   time.Duration
+	verifyTTL  time.Duration
+	log        *logger.Logger
+}
+
+// New create new auth use case
+func New(s *server.Server, r infrastructure.TokenRepo, u userInfra.UserRepo, p password.Service, m mailer.Mailer) AuthUseCase {
+	return &authUseCase{
+		repo:       r,
+		userRepo:   u,
+		password:   p,
+		mailer:     m,
+		signer:     token.NewSigner([]byte(s.Cfg.AuthSigningKey)),
+		accessTTL:  s.Cfg.AccessTokenTTL,
+		refreshTTL: s.Cfg.RefreshTokenTTL,
+		resetTTL:   s.Cfg.PasswordResetTTL,
+		verifyTTL:  s.Cfg.EmailVerificationTTL,
+		log:        s.Log,
+	}
+}
+
+// Login checks the credentials of an user and issues a new pair of tokens
+func (s *authUseCase) Login(email, password string) (*entity.Tokens, error) {
+	u, err := s.userRepo.GetByEmail(email)
+	if err == userEntity.ErrUserNotFound {
+		return nil, entity.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = s.password.Compare(u.Password, password)
+	if err != nil {
+		s.log.Zap.Info("login failed", zap.String("user_id", u.ID.String()))
+		return nil, entity.ErrInvalidCredentials
+	}
+	s.rehash(u, password)
+	return s.issue(u.ID)
+}
+
+// rehash stores a new hash of the password when the stored one was made with other
+// parameters, a failure only costs another try on the next login
+func (s *authUseCase) rehash(u *userEntity.User, password string) {
+	if !s.password.NeedsRehash(u.Password) {
+		return
+	}
+	hash, err := s.password.Generate(password)
+	if err == nil {
+		u.Password = hash
+		u.UpdatedAt = time.Now()
+		err = s.userRepo.Update(u)
+	}
+	if err != nil {
+		s.log.Zap.Error("rehashing password", zap.String("user_id", u.ID.String()), zap.Error(err))
+	}
+}
+
+// Refresh trades a refresh token for a new pair, the old one cannot be used again
+func (s *authUseCase) Refresh(refreshToken string) (*entity.Tokens, error) {
+	r, err := s.refreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	err = r.Revoke()
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.UpdateRefresh(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.issue(r.UserID)
+}
+
+// Logout revokes the access token and, when given, the refresh token of the session
+func (s *authUseCase) Logout(accessToken, refreshToken string) error {
+	c, err := s.parse(accessToken, accessKind)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Revoke(c.ID, c.Expiry())
+	if err != nil {
+		return err
+	}
+	if refreshToken == "" {
+		return nil
+	}
+	r, err := s.refreshToken(refreshToken)
+	if err == entity.ErrTokenRevoked {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if r.UserID.String() != c.Subject {
+		return entity.ErrInvalidToken
+	}
+	err = r.Revoke()
+	if err != nil {
+		return err
+	}
+	err = s.repo.UpdateRefresh(r)
+	if err == entity.ErrTokenRevoked {
+		return nil
+	}
+	return err
+}
+
+// Authenticate finds the user an access token was issued to
+func (s *authUseCase) Authenticate(accessToken string) (*userEntity.User, error) {
+	c, err := s.parse(accessToken, accessKind)
+	if err != nil {
+		return nil, err
+	}
+	revoked, err := s.repo.IsRevoked(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, entity.ErrTokenRevoked
+	}
+	uID, err := userEntity.IDFromString(c.Subject)
+	if err != nil {
+		return nil, entity.ErrInvalidToken
+	}
+	u, err := s.userRepo.Get(uID)
+	if err == userEntity.ErrUserNotFound {
+		return nil, entity.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// ForgotPassword mails a password reset token to the user, unknown emails are
+// ignored so the answer does not tell who has an account
+func (s *authUseCase) ForgotPassword(email string) error {
+	u, err := s.userRepo.GetByEmail(email)
+	if err == userEntity.ErrUserNotFound {
+		s.log.Zap.Info("password reset for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	secret, err := s.issueOneTime(u, entity.PurposePasswordReset, s.resetTTL)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(mailer.Message{
+		To:      u.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Someone asked to reset the password of your library account.\n\n"+
+			"Send this token with your new password to POST /auth/password/reset within %s:\n\n%s\n\n"+
+			"If it was not you, ignore this email.\n", s.resetTTL, secret),
+	})
+}
+
+// ResetPassword spends a password reset token to set a new password, signing out every session
+func (s *authUseCase) ResetPassword(token, password string) error {
+	if password == "" {
+		return userEntity.ErrInvalidUserEntity
+	}
+	u, err := s.spendOneTime(token, entity.PurposePasswordReset)
+	if err != nil {
+		return err
+	}
+	hash, err := s.password.Generate(password)
+	if err != nil {
+		return err
+	}
+	// the sessions opened with the old password end with it
+	now := time.Now()
+	err = s.repo.RevokeUserRefresh(u.ID, now)
+	if err != nil {
+		return err
+	}
+	u.Password = hash
+	u.UpdatedAt = now
+	return s.userRepo.Update(u)
+}
+
+// RequestVerification mails an email verification token to the user
+func (s *authUseCase) RequestVerification(userID string) error {
+	u, err := s.user(userID)
+	if err != nil {
+		return err
+	}
+	if u.IsVerified() {
+		return userEntity.ErrUserAlreadyVerified
+	}
+	secret, err := s.issueOneTime(u, entity.PurposeEmailVerification, s.verifyTTL)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(mailer.Message{
+		To:      u.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Welcome to the library.\n\n"+
+			"Send this token to POST /user/%s/verify within %s to start borrowing:\n\n%s\n", u.ID, s.verifyTTL, secret),
+	})
+}
+
+// VerifyEmail spends an email verification token issued to the user
+func (s *authUseCase) VerifyEmail(userID, token string) error {
+	u, err := s.user(userID)
+	if err != nil {
+		return err
+	}
+	if u.IsVerified() {
+		return userEntity.ErrUserAlreadyVerified
+	}
+	owner, err := s.spendOneTime(token, entity.PurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	if owner.ID != u.ID {
+		return entity.ErrInvalidToken
+	}
+	err = u.Verify()
+	if err != nil {
+		return err
+	}
+	return s.userRepo.Update(u)
+}
+
+// user finds an user by its string id
+func (s *authUseCase) user(id string) (*userEntity.User, error) {
+	uID, err := userEntity.IDFromString(id)
+	if err != nil {
+		return nil, userEntity.ErrUserNotFound
+	}
+	return s.userRepo.Get(uID)
+}
+
+// issueOneTime stores a new one time token for the user and returns its secret
+func (s *authUseCase) issueOneTime(u *userEntity.User, purpose entity.Purpose, ttl time.Duration) (string, error) {
+	o, secret, err := entity.NewOneTimeToken(u.ID, purpose, u.Email, ttl)
+	if err != nil {
+		return "", err
+	}
+	err = s.repo.CreateOneTime(o)
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// spendOneTime uses up a one time token and returns the user it was issued to,
+// tokens mailed to an address the user no longer has are refused
+func (s *authUseCase) spendOneTime(secret string, purpose entity.Purpose) (*userEntity.User, error) {
+	o, err := s.repo.GetOneTime(entity.HashSecret(secret))
+	if err == entity.ErrOneTimeTokenNotFound {
+		return nil, entity.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.Purpose != purpose {
+		return nil, entity.ErrInvalidToken
+	}
+	u, err := s.userRepo.Get(o.UserID)
+	if err == userEntity.ErrUserNotFound {
+		return nil, entity.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(u.Email, o.Email) {
+		return nil, entity.ErrInvalidToken
+	}
+	err = o.Use(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.UseOneTime(o)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// issue signs a new access token and stores a new refresh token for the user
+func (s *authUseCase) issue(userID userEntity.ID) (*entity.Tokens, error) {
+	now := time.Now()
+	access := token.Claims{
+		ID:        entity.NewID().String(),
+		Subject:   userID.String(),
+		Kind:      accessKind,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.accessTTL).Unix(),
+	}
+	accessToken, err := s.signer.Sign(access)
+	if err != nil {
+		return nil, err
+	}
+	r, err := entity.NewRefreshToken(userID, s.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.signer.Sign(token.Claims{
+		ID:        r.ID.String(),
+		Subject:   userID.String(),
+		Kind:      refreshKind,
+		IssuedAt:  r.CreatedAt.Unix(),
+		ExpiresAt: r.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = s.repo.CreateRefresh(r)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Tokens{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  access.Expiry(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: r.ExpiresAt,
+	}, nil
+}
+
+// refreshToken finds the stored refresh token behind a signed one
+func (s *authUseCase) refreshToken(refreshToken string) (*entity.RefreshToken, error) {
+	c, err := s.parse(refreshToken, refreshKind)
+	if err != nil {
+		return nil, err
+	}
+	id, err := entity.IDFromString(c.ID)
+	if err != nil {
+		return nil, entity.ErrInvalidToken
+	}
+	r, err := s.repo.GetRefresh(id)
+	if err == entity.ErrRefreshTokenNotFound {
+		return nil, entity.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !r.RevokedAt.IsZero() {
+		return nil, entity.ErrTokenRevoked
+	}
+	if !r.IsValid(time.Now()) {
+		return nil, entity.ErrInvalidToken
+	}
+	return r, nil
+}
+
+// parse verifies a signed token of the given kind
+func (s *authUseCase) parse(t, kind string) (*token.Claims, error) {
+	c, err := s.signer.Parse(t, time.Now())
+	if err != nil || c.Kind != kind {
+		return nil, entity.ErrInvalidToken
+	}
+	return c, nil
+}
@@ -0,0 +1,284 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/auth/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/internal/auth/usecase"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/mailer"
+	"github.com/sgraham785/gocleanarch-example/pkg/password"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"github.com/sgraham785/gocleanarch-example/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const signingKey = "iron-man"
+
+func newServer() *server.Server {
+	return &server.Server{
+		Log: logger.New(),
+		Cfg: &config.Specification{
+			AuthConf: config.AuthConf{
+				AuthSigningKey:       signingKey,
+				AccessTokenTTL:       15 * time.Minute,
+				RefreshTokenTTL:      24 * time.Hour,
+				PasswordResetTTL:     time.Hour,
+				EmailVerificationTTL: 48 * time.Hour,
+			},
+		},
+	}
+}
+
+func newFixtureUser(users userInfra.UserRepo, p password.Service) *userEntity.User {
+	hash, _ := p.Generate("bateater666")
+	u, _ := userEntity.New("ozzy@metalgods.net", hash, "Ozzy", "Osbourne")
+	_, _ = users.Create(u)
+	return u
+}
+
+func newFixture() (usecase.AuthUseCase, *userEntity.User) {
+	uc, u, _ := newMailFixture()
+	return uc, u
+}
+
+func newMailFixture() (usecase.AuthUseCase, *userEntity.User, *mailer.InMem) {
+	p := password.NewBcryptService(bcrypt.MinCost)
+	users := userInfra.NewInMemRepo()
+	u := newFixtureUser(users, p)
+	m := mailer.NewInMem()
+	return usecase.New(newServer(), infrastructure.NewInMemRepo(), users, p, m), u, m
+}
+
+// mailedToken is the secret in the last email sent, the only line without spaces
+func mailedToken(m *mailer.InMem) string {
+	sent := m.Sent()
+	if len(sent) == 0 {
+		return ""
+	}
+	for _, line := range strings.Split(sent[len(sent)-1].Body, "\n") {
+		if line != "" && !strings.Contains(line, " ") {
+			return line
+		}
+	}
+	return ""
+}
+
+func Test_authUseCase_Login(t *testing.T) {
+	uc, u := newFixture()
+	t.Run("unknown email", func(t *testing.T) {
+		_, err := uc.Login("dio@metalgods.net", "bateater666")
+		assert.Equal(t, entity.ErrInvalidCredentials, err)
+	})
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := uc.Login(u.Email, "wrong")
+		assert.Equal(t, entity.ErrInvalidCredentials, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		tokens, err := uc.Login("OZZY@metalgods.net", "bateater666")
+		assert.Nil(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), tokens.AccessExpiresAt, time.Minute)
+
+		authenticated, err := uc.Authenticate(tokens.AccessToken)
+		assert.Nil(t, err)
+		assert.Equal(t, u.ID, authenticated.ID)
+	})
+}
+
+func Test_authUseCase_Login_Rehash(t *testing.T) {
+	users := userInfra.NewInMemRepo()
+	u := newFixtureUser(users, password.NewBcryptService(bcrypt.MinCost))
+	t.Run("bcrypt cost changed", func(t *testing.T) {
+		p := password.NewBcryptService(bcrypt.MinCost + 1)
+		uc := usecase.New(newServer(), infrastructure.NewInMemRepo(), users, p, mailer.NewInMem())
+		_, err := uc.Login(u.Email, "bateater666")
+		assert.Nil(t, err)
+		saved, _ := users.Get(u.ID)
+		cost, _ := bcrypt.Cost([]byte(saved.Password))
+		assert.Equal(t, bcrypt.MinCost+1, cost)
+	})
+	t.Run("bcrypt to argon2id", func(t *testing.T) {
+		p := password.NewArgon2idService(password.Argon2idParams{Time: 1, Memory: 1024, Threads: 1})
+		uc := usecase.New(newServer(), infrastructure.NewInMemRepo(), users, p, mailer.NewInMem())
+		_, err := uc.Login(u.Email, "bateater666")
+		assert.Nil(t, err)
+		saved, _ := users.Get(u.ID)
+		assert.True(t, strings.HasPrefix(saved.Password, "$argon2id$"))
+		assert.False(t, p.NeedsRehash(saved.Password))
+
+		_, err = uc.Login(u.Email, "bateater666")
+		assert.Nil(t, err)
+		_, err = uc.Login(u.Email, "wrong")
+		assert.Equal(t, entity.ErrInvalidCredentials, err)
+	})
+}
+
+func Test_authUseCase_Authenticate(t *testing.T) {
+	uc, u := newFixture()
+	tokens, _ := uc.Login(u.Email, "bateater666")
+
+	t.Run("garbage", func(t *testing.T) {
+		_, err := uc.Authenticate("not-a-token")
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+	t.Run("refresh token is not an access token", func(t *testing.T) {
+		_, err := uc.Authenticate(tokens.RefreshToken)
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+	t.Run("signed with another key", func(t *testing.T) {
+		forged, _ := token.NewSigner([]byte("black-sabbath")).Sign(token.Claims{
+			ID:        entity.NewID().String(),
+			Subject:   u.ID.String(),
+			Kind:      "access",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		})
+		_, err := uc.Authenticate(forged)
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+	t.Run("expired", func(t *testing.T) {
+		expired, _ := token.NewSigner([]byte(signingKey)).Sign(token.Claims{
+			ID:        entity.NewID().String(),
+			Subject:   u.ID.String(),
+			Kind:      "access",
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		})
+		_, err := uc.Authenticate(expired)
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+}
+
+func Test_authUseCase_Refresh(t *testing.T) {
+	uc, u := newFixture()
+	tokens, _ := uc.Login(u.Email, "bateater666")
+
+	_, err := uc.Refresh(tokens.AccessToken)
+	assert.Equal(t, entity.ErrInvalidToken, err)
+
+	refreshed, err := uc.Refresh(tokens.RefreshToken)
+	assert.Nil(t, err)
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+	authenticated, err := uc.Authenticate(refreshed.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, u.ID, authenticated.ID)
+
+	_, err = uc.Refresh(tokens.RefreshToken)
+	assert.Equal(t, entity.ErrTokenRevoked, err)
+
+	t.Run("rotated once", func(t *testing.T) {
+		repo := infrastructure.NewInMemRepo()
+		r, _ := entity.NewRefreshToken(u.ID, time.Hour)
+		_ = repo.CreateRefresh(r)
+		first, _ := repo.GetRefresh(r.ID)
+		second, _ := repo.GetRefresh(r.ID)
+		_ = first.Revoke()
+		_ = second.Revoke()
+		assert.Nil(t, repo.UpdateRefresh(first))
+		assert.Equal(t, entity.ErrTokenRevoked, repo.UpdateRefresh(second))
+	})
+}
+
+func Test_authUseCase_Logout(t *testing.T) {
+	uc, u := newFixture()
+	tokens, _ := uc.Login(u.Email, "bateater666")
+	other, _ := uc.Login(u.Email, "bateater666")
+
+	err := uc.Logout(tokens.AccessToken, tokens.RefreshToken)
+	assert.Nil(t, err)
+	_, err = uc.Authenticate(tokens.AccessToken)
+	assert.Equal(t, entity.ErrTokenRevoked, err)
+	_, err = uc.Refresh(tokens.RefreshToken)
+	assert.Equal(t, entity.ErrTokenRevoked, err)
+
+	_, err = uc.Authenticate(other.AccessToken)
+	assert.Nil(t, err)
+	err = uc.Logout(other.AccessToken, "")
+	assert.Nil(t, err)
+	_, err = uc.Authenticate(other.AccessToken)
+	assert.Equal(t, entity.ErrTokenRevoked, err)
+	_, err = uc.Refresh(other.RefreshToken)
+	assert.Nil(t, err)
+}
+
+func Test_authUseCase_ResetPassword(t *testing.T) {
+	uc, u, m := newMailFixture()
+	t.Run("unknown email", func(t *testing.T) {
+		err := uc.ForgotPassword("dio@metalgods.net")
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(m.Sent()))
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		err := uc.ResetPassword("nope", "newpassword")
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		session, err := uc.Login(u.Email, "bateater666")
+		assert.Nil(t, err)
+		err = uc.ForgotPassword("OZZY@metalgods.net")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(m.Sent()))
+		assert.Equal(t, u.Email, m.Sent()[0].To)
+		secret := mailedToken(m)
+		assert.NotEmpty(t, secret)
+
+		err = uc.ResetPassword(secret, "")
+		assert.Equal(t, userEntity.ErrInvalidUserEntity, err)
+		err = uc.ResetPassword(secret, "newpassword")
+		assert.Nil(t, err)
+		_, err = uc.Login(u.Email, "bateater666")
+		assert.Equal(t, entity.ErrInvalidCredentials, err)
+		_, err = uc.Login(u.Email, "newpassword")
+		assert.Nil(t, err)
+		_, err = uc.Refresh(session.RefreshToken)
+		assert.Equal(t, entity.ErrTokenRevoked, err)
+
+		err = uc.ResetPassword(secret, "another")
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+	t.Run("verification token", func(t *testing.T) {
+		_ = uc.RequestVerification(u.ID.String())
+		err := uc.ResetPassword(mailedToken(m), "another")
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+}
+
+func Test_authUseCase_VerifyEmail(t *testing.T) {
+	uc, u, m := newMailFixture()
+	t.Run("user not found", func(t *testing.T) {
+		err := uc.RequestVerification(userEntity.NewID().String())
+		assert.Equal(t, userEntity.ErrUserNotFound, err)
+	})
+	t.Run("invalid token", func(t *testing.T) {
+		err := uc.VerifyEmail(u.ID.String(), "nope")
+		assert.Equal(t, entity.ErrInvalidToken, err)
+	})
+	t.Run("token of someone else", func(t *testing.T) {
+		err := uc.RequestVerification(u.ID.String())
+		assert.Nil(t, err)
+		err = uc.VerifyEmail(userEntity.NewID().String(), mailedToken(m))
+		assert.Equal(t, userEntity.ErrUserNotFound, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		err := uc.RequestVerification(u.ID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, u.Email, m.Sent()[len(m.Sent())-1].To)
+		err = uc.VerifyEmail(u.ID.String(), mailedToken(m))
+		assert.Nil(t, err)
+		authenticated, _ := uc.Login(u.Email, "bateater666")
+		verified, _ := uc.Authenticate(authenticated.AccessToken)
+		assert.True(t, verified.IsVerified())
+
+		err = uc.RequestVerification(u.ID.String())
+		assert.Equal(t, userEntity.ErrUserAlreadyVerified, err)
+		err = uc.VerifyEmail(u.ID.String(), mailedToken(m))
+		assert.Equal(t, userEntity.ErrUserAlreadyVerified, err)
+	})
+}
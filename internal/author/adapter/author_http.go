@@ -0,0 +1,304 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	auth "github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/author/usecase"
+	bookAdapter "github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+// AuthorHTTP JSON data
+type AuthorHTTP struct {
+	ID    entity.ID `json:"id"`
+	Name  string    `json:"name"`
+	Dates string    `json:"dates,omitempty"`
+}
+
+func newAuthorHTTP(a *entity.Author) *AuthorHTTP {
+	return &AuthorHTTP{
+		ID:    a.ID,
+		Name:  a.Name,
+		Dates: a.Dates,
+	}
+}
+
+func writeAuthors(w http.ResponseWriter, data []*entity.Author, errorMessage string) {
+	toJ := []*AuthorHTTP{}
+	for _, d := range data {
+		toJ = append(toJ, newAuthorHTTP(d))
+	}
+	if err := json.NewEncoder(w).Encode(toJ); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+// ListAuthorsHTTP handler, one page at a time
+func ListAuthorsHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing authors"
+		opts := router.ListOptionsFromContext(r.Context())
+		data, page, err := u.ListAuthors(opts)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		router.SetPage(w, r, opts, page)
+		if len(data) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeAuthors(w, data, errorMessage)
+	})
+}
+
+// CreateAuthorHTTP handler
+func CreateAuthorHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding author"
+		var input struct {
+			Name  string `json:"name"`
+			Dates string `json:"dates"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		id, err := u.CreateAuthor(input.Name, input.Dates)
+		if err == entity.ErrInvalidAuthorEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.GetAuthor(id.String())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newAuthorHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// GetAuthorHTTP handler
+func GetAuthorHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading author"
+		data, err := u.GetAuthor(chi.URLParam(r, "authorID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrAuthorNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newAuthorHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// UpdateAuthorHTTP handler, PUT takes the name and the dates
+func UpdateAuthorHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating author"
+		var input struct {
+			Name  string `json:"name"`
+			Dates string `json:"dates"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.GetAuthor(chi.URLParam(r, "authorID"))
+		if err == nil {
+			data.Name, data.Dates = input.Name, input.Dates
+			err = u.UpdateAuthor(data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrAuthorNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidAuthorEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newAuthorHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// DeleteAuthorHTTP handler
+func DeleteAuthorHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing author"
+		err := u.DeleteAuthor(chi.URLParam(r, "authorID"))
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusNoContent)
+		case entity.ErrAuthorNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+		case entity.ErrAuthorCannotBeDeleted:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListAuthorBooksHTTP handler, the books of an author one page at a time
+func ListAuthorBooksHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading books"
+		opts := router.ListOptionsFromContext(r.Context())
+		data, page, err := u.ListAuthorBooks(chi.URLParam(r, "authorID"), opts)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrAuthorNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		router.SetPage(w, r, opts, page)
+		toJ := []*bookAdapter.BookHTTP{}
+		for _, d := range data {
+			toJ = append(toJ, bookAdapter.NewBookHTTP(d))
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListBookAuthorsHTTP handler, in the order they are credited
+func ListBookAuthorsHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading authors"
+		data, err := u.ListBookAuthors(chi.URLParam(r, "bookID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case bookEntity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeAuthors(w, data, errorMessage)
+	})
+}
+
+// SetBookAuthorsHTTP handler, takes the ids of the authors of a book in the order they are credited
+func SetBookAuthorsHTTP(u usecase.AuthorUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error crediting authors"
+		var input struct {
+			Authors []string `json:"authors"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.SetBookAuthors(chi.URLParam(r, "bookID"), input.Authors)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case bookEntity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrAuthorNotFound, bookEntity.ErrInvalidBookEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeAuthors(w, data, errorMessage)
+	})
+}
+
+// HTTPRoutes defines http routes for authors, only staff change them
+func HTTPRoutes(s *server.Server, u usecase.AuthorUseCase) {
+	staff := router.Authorize(auth.Staff)
+	s.Router.Chi.Route("/author", func(r chi.Router) {
+		r.With(router.Paginate).Get("/", ListAuthorsHTTP(u))
+		r.With(staff).Post("/", CreateAuthorHTTP(u)) // POST /author
+
+		r.Route("/{authorID}", func(r chi.Router) {
+			r.Get("/", GetAuthorHTTP(u)) // GET /author/123
+			r.With(staff).Put("/", UpdateAuthorHTTP(u))
+			r.With(staff).Delete("/", DeleteAuthorHTTP(u))
+			r.With(router.Paginate).Get("/books", ListAuthorBooksHTTP(u)) // GET /author/123/books
+		})
+	})
+	s.Router.Chi.Get("/book/{bookID}/authors", ListBookAuthorsHTTP(u))
+	s.Router.Chi.With(staff).Put("/book/{bookID}/authors", SetBookAuthorsHTTP(u))
+}
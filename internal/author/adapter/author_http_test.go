@@ -0,0 +1,144 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/author/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/author/mock"
+	bookAdapter "github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	bookMock "github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+func newRouter(t *testing.T, role string) (*router.HTTPRouter, *mock.MockAuthorUseCase) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+	m := mock.NewMockAuthorUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: role}))
+	s := &server.Server{
+		Router: r,
+	}
+	bookAdapter.HTTPRoutes(s, bookMock.NewMockBookUseCase(controller))
+	adapter.HTTPRoutes(s, m)
+	return r, m
+}
+
+func TestCreateAuthorHTTP(t *testing.T) {
+	r, m := newRouter(t, "librarian")
+	a := &entity.Author{ID: entity.NewID(), Name: "Ozzy Osbourne", Dates: "1948-"}
+	m.EXPECT().CreateAuthor("Ozzy Osbourne", "1948-").Return(a.ID, nil)
+	m.EXPECT().GetAuthor(a.ID.String()).Return(a, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/author", strings.NewReader(`{"name":"Ozzy Osbourne","dates":"1948-"}`))
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusCreated, res.Code)
+	var got adapter.AuthorHTTP
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&got))
+	assert.Equal(t, adapter.AuthorHTTP{ID: a.ID, Name: "Ozzy Osbourne", Dates: "1948-"}, got)
+
+	m.EXPECT().CreateAuthor("", "").Return(entity.ID{}, entity.ErrInvalidAuthorEntity)
+	req = httptest.NewRequest(http.MethodPost, "/author", strings.NewReader(`{}`))
+	res = httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestCreateAuthorHTTP_Forbidden(t *testing.T) {
+	r, _ := newRouter(t, "member")
+	req := httptest.NewRequest(http.MethodPost, "/author", strings.NewReader(`{"name":"Ozzy Osbourne"}`))
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusForbidden, res.Code)
+}
+
+func TestAuthorHTTP_NotFound(t *testing.T) {
+	r, m := newRouter(t, "librarian")
+	m.EXPECT().GetAuthor("123").Return(nil, entity.ErrAuthorNotFound).Times(2)
+	m.EXPECT().DeleteAuthor("123").Return(entity.ErrAuthorNotFound)
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/author/123", strings.NewReader(`{"name":"Ozzy"}`))
+		res := httptest.NewRecorder()
+		r.Chi.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusNotFound, res.Code, method)
+	}
+}
+
+func TestDeleteAuthorHTTP_OnlyAuthor(t *testing.T) {
+	r, m := newRouter(t, "librarian")
+	m.EXPECT().DeleteAuthor("123").Return(entity.ErrAuthorCannotBeDeleted)
+	req := httptest.NewRequest(http.MethodDelete, "/author/123", nil)
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusConflict, res.Code)
+}
+
+func TestListAuthorBooksHTTP(t *testing.T) {
+	r, m := newRouter(t, "member")
+	b := &bookEntity.Book{ID: bookEntity.NewID(), Title: "I Am Ozzy", Author: "Ozzy Osbourne", Pages: 294}
+	m.EXPECT().
+		ListAuthorBooks("123", repository.ListOptions{Limit: 1, Direction: repository.Asc}).
+		Return([]*bookEntity.Book{b}, &repository.Page{Total: 2, Next: "abc"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/author/123/books?limit=1", nil)
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "2", res.Header().Get("X-Total-Count"))
+	var books []bookAdapter.BookHTTP
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&books))
+	assert.Len(t, books, 1)
+	assert.Equal(t, "I Am Ozzy", books[0].Title)
+}
+
+func TestSetBookAuthorsHTTP(t *testing.T) {
+	r, m := newRouter(t, "librarian")
+	ozzy := &entity.Author{ID: entity.NewID(), Name: "Ozzy Osbourne"}
+	chris := &entity.Author{ID: entity.NewID(), Name: "Chris Ayres"}
+	m.EXPECT().
+		SetBookAuthors("b1", []string{ozzy.ID.String(), chris.ID.String()}).
+		Return([]*entity.Author{ozzy, chris}, nil)
+	m.EXPECT().ListBookAuthors("b1").Return([]*entity.Author{ozzy, chris}, nil)
+	m.EXPECT().SetBookAuthors("b1", []string{"nobody"}).Return(nil, entity.ErrAuthorNotFound)
+
+	req := httptest.NewRequest(http.MethodPut, "/book/b1/authors",
+		strings.NewReader(`{"authors":["`+ozzy.ID.String()+`","`+chris.ID.String()+`"]}`))
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/book/b1/authors", nil)
+	res = httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	var authors []adapter.AuthorHTTP
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&authors))
+	assert.Equal(t, []adapter.AuthorHTTP{{ID: ozzy.ID, Name: "Ozzy Osbourne"}, {ID: chris.ID, Name: "Chris Ayres"}}, authors)
+
+	req = httptest.NewRequest(http.MethodPut, "/book/b1/authors", strings.NewReader(`{"authors":["nobody"]}`))
+	res = httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+// signedIn plays the authentication middleware, every request is made by p
+func signedIn(p router.Principal) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(router.WithPrincipal(r.Context(), p)))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// ID is id for author
+type ID = xid.ID
+
+// NewID create a new author entity ID
+func NewID() ID {
+	return xid.New()
+}
+
+// IDFromString reads an author ID
+func IDFromString(id string) (xid.ID, error) {
+	i, err := xid.FromString(id)
+	return i, err
+}
+
+// Author entity. Authors of the same name are told apart by their Dates,
+// the years they lived as catalogues write them: 1948- or 1812-1870.
+type Author struct {
+	ID        ID
+	Name      string
+	Dates     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// New creates a new author
+func New(name string, dates string) (*Author, error) {
+	a := &Author{
+		ID:        xid.New(),
+		Name:      strings.TrimSpace(name),
+		Dates:     strings.TrimSpace(dates),
+		CreatedAt: time.Now(),
+	}
+	err := a.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Validate validate author
+func (a *Author) Validate() error {
+	if strings.TrimSpace(a.Name) == "" {
+		return ErrInvalidAuthorEntity
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	a, err := entity.New(" Neil Gaiman ", "1960-")
+	assert.Nil(t, err)
+	assert.Equal(t, "Neil Gaiman", a.Name)
+	assert.Equal(t, "1960-", a.Dates)
+	assert.False(t, a.ID.IsNil())
+
+	_, err = entity.New("  ", "")
+	assert.Equal(t, entity.ErrInvalidAuthorEntity, err)
+}
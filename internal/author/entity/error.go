@@ -0,0 +1,12 @@
+package entity
+
+import "errors"
+
+// ErrAuthorNotFound not found
+var ErrAuthorNotFound = errors.New("Author not found")
+
+// ErrInvalidAuthorEntity invalid author entity
+var ErrInvalidAuthorEntity = errors.New("Invalid author entity")
+
+// ErrAuthorCannotBeDeleted the only author of a book
+var ErrAuthorCannotBeDeleted = errors.New("Author cannot be deleted")
@@ -0,0 +1,202 @@
+package infrastructure
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+type authorInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[entity.ID]*entity.Author
+	// books holds the authors of every book, in the order they are credited
+	books map[bookEntity.ID][]entity.ID
+}
+
+// NewInMemRepo create author in memory repository
+func NewInMemRepo() AuthorRepo {
+	return &authorInMemRepo{
+		m:     map[entity.ID]*entity.Author{},
+		books: map[bookEntity.ID][]entity.ID{},
+	}
+}
+
+// Create an author
+func (r *authorInMemRepo) Create(e *entity.Author) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Get an author
+func (r *authorInMemRepo) Get(id entity.ID) (*entity.Author, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrAuthorNotFound
+	}
+	a := *r.m[id]
+	return &a, nil
+}
+
+// Update an author
+func (r *authorInMemRepo) Update(e *entity.Author) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[e.ID] == nil {
+		return entity.ErrAuthorNotFound
+	}
+	a := *e
+	r.m[e.ID] = &a
+	return nil
+}
+
+// List authors
+func (r *authorInMemRepo) List(opts repository.ListOptions) ([]*entity.Author, *repository.Page, error) {
+	if _, ok := authorSorts[opts.Sort]; !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var authors []*entity.Author
+	var rows []repository.Row
+	for _, a := range r.m {
+		authors = append(authors, a)
+		rows = append(rows, repository.Row{ID: a.ID.String(), Key: authorSortKey(a, opts.Sort)})
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.Author
+	for _, i := range idx {
+		a := *authors[i]
+		d = append(d, &a)
+	}
+	return d, page, nil
+}
+
+// ListByBook lists the authors of a book
+func (r *authorInMemRepo) ListByBook(bookID bookEntity.ID) ([]*entity.Author, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var d []*entity.Author
+	for _, id := range r.books[bookID] {
+		a := *r.m[id]
+		d = append(d, &a)
+	}
+	return d, nil
+}
+
+// ListBooks lists the books of an author
+func (r *authorInMemRepo) ListBooks(id entity.ID, opts repository.ListOptions) ([]bookEntity.ID, *repository.Page, error) {
+	if opts.Sort != "" {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var books []bookEntity.ID
+	var rows []repository.Row
+	for bookID, authors := range r.books {
+		for _, a := range authors {
+			if a == id {
+				books = append(books, bookID)
+				rows = append(rows, repository.Row{ID: bookID.String()})
+			}
+		}
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []bookEntity.ID
+	for _, i := range idx {
+		d = append(d, books[i])
+	}
+	return d, page, nil
+}
+
+// SetBookAuthors replaces the authors of a book
+func (r *authorInMemRepo) SetBookAuthors(bookID bookEntity.ID, authors []entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, a := range authors {
+		if r.m[a] == nil {
+			return entity.ErrAuthorNotFound
+		}
+	}
+	if len(authors) == 0 {
+		delete(r.books, bookID)
+		return nil
+	}
+	r.books[bookID] = append([]entity.ID(nil), authors...)
+	return nil
+}
+
+// CreditBook replaces the authors of a book with those going by the names
+func (r *authorInMemRepo) CreditBook(bookID bookEntity.ID, names []string) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	var authors []entity.ID
+	seen := map[entity.ID]bool{}
+	for _, name := range names {
+		a := r.named(name)
+		if a == nil {
+			var err error
+			a, err = entity.New(name, "")
+			if err != nil {
+				return err
+			}
+			r.m[a.ID] = a
+		}
+		if !seen[a.ID] {
+			seen[a.ID] = true
+			authors = append(authors, a.ID)
+		}
+	}
+	if len(authors) == 0 {
+		delete(r.books, bookID)
+		return nil
+	}
+	r.books[bookID] = authors
+	return nil
+}
+
+// named finds the oldest author going by a name, whatever its case
+func (r *authorInMemRepo) named(name string) *entity.Author {
+	var found *entity.Author
+	for _, a := range r.m {
+		if !strings.EqualFold(a.Name, strings.TrimSpace(name)) {
+			continue
+		}
+		if found == nil || a.CreatedAt.Before(found.CreatedAt) ||
+			(a.CreatedAt.Equal(found.CreatedAt) && a.ID.String() < found.ID.String()) {
+			found = a
+		}
+	}
+	return found
+}
+
+// Delete an author, it is no longer credited on its books
+func (r *authorInMemRepo) Delete(id entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[id] == nil {
+		return entity.ErrAuthorNotFound
+	}
+	delete(r.m, id)
+	for bookID, authors := range r.books {
+		var kept []entity.ID
+		for _, a := range authors {
+			if a != id {
+				kept = append(kept, a)
+			}
+		}
+		r.books[bookID] = kept
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+type authorPgRepo struct {
+	db       repository.Querier
+	transact func(fn func(tx *sqlx.Tx) error) error
+	log      *logger.Logger
+}
+
+// NewPgRepo create new author repository
+func NewPgRepo(s *server.Server) AuthorRepo {
+	return &authorPgRepo{
+		db:       s.DB.Pg,
+		transact: s.DB.Transact,
+		log:      s.Log,
+	}
+}
+
+// NewTxPgRepo create an author postgres repo writing through tx
+func NewTxPgRepo(tx *sqlx.Tx, l *logger.Logger) AuthorRepo {
+	return &authorPgRepo{
+		db:       tx,
+		transact: repository.Within(tx),
+		log:      l,
+	}
+}
+
+// authorColumns are selected in the order authorRow scans them
+const authorColumns = `id, name, dates, created_at, updated_at`
+
+type authorRow struct {
+	ID        entity.ID `db:"id"`
+	Name      string    `db:"name"`
+	Dates     string    `db:"dates"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (a authorRow) author() *entity.Author {
+	return &entity.Author{ID: a.ID, Name: a.Name, Dates: a.Dates, CreatedAt: a.CreatedAt, UpdatedAt: a.UpdatedAt}
+}
+
+// Create an author
+func (r *authorPgRepo) Create(e *entity.Author) (entity.ID, error) {
+	_, err := r.db.Exec(`insert into author (id, name, dates, created_at, updated_at) values($1,$2,$3,$4,$4)`,
+		e.ID, e.Name, e.Dates, e.CreatedAt)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get an author
+func (r *authorPgRepo) Get(id entity.ID) (*entity.Author, error) {
+	var rows []authorRow
+	err := r.db.Select(&rows, `select `+authorColumns+` from author where id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, entity.ErrAuthorNotFound
+	}
+	return rows[0].author(), nil
+}
+
+// Update an author
+func (r *authorPgRepo) Update(e *entity.Author) error {
+	e.UpdatedAt = time.Now()
+	res, err := r.db.Exec(`update author set name = $1, dates = $2, updated_at = $3 where id = $4`,
+		e.Name, e.Dates, e.UpdatedAt, e.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrAuthorNotFound
+	}
+	return nil
+}
+
+// List authors
+func (r *authorPgRepo) List(opts repository.ListOptions) ([]*entity.Author, *repository.Page, error) {
+	column, ok := authorSorts[opts.Sort]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from author`).Scan(&page.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses(column, nil, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []authorRow
+	err = r.db.Select(&rows, `select `+authorColumns+` from author where true`+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var authors []*entity.Author
+	for _, a := range rows {
+		authors = append(authors, a.author())
+	}
+	if repository.HasNext(len(authors), opts) {
+		authors = authors[:opts.Limit]
+		last := authors[len(authors)-1]
+		page.Next = repository.Cursor(last.ID.String(), authorSortKey(last, opts.Sort))
+	}
+	return authors, page, nil
+}
+
+// ListByBook lists the authors of a book
+func (r *authorPgRepo) ListByBook(bookID bookEntity.ID) ([]*entity.Author, error) {
+	var rows []authorRow
+	err := r.db.Select(&rows, `select a.id, a.name, a.dates, a.created_at, a.updated_at
+		from author a join book_author ba on ba.author_id = a.id
+		where ba.book_id = $1 order by ba.position`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	var authors []*entity.Author
+	for _, a := range rows {
+		authors = append(authors, a.author())
+	}
+	return authors, nil
+}
+
+// ListBooks lists the books of an author
+func (r *authorPgRepo) ListBooks(id entity.ID, opts repository.ListOptions) ([]bookEntity.ID, *repository.Page, error) {
+	if opts.Sort != "" {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from book_author where author_id = $1`, id).Scan(&page.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses("id", []interface{}{id}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var books []bookEntity.ID
+	err = r.db.Select(&books, `select id from (select book_id as id from book_author where author_id = $1) b where true`+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if repository.HasNext(len(books), opts) {
+		books = books[:opts.Limit]
+		page.Next = repository.Cursor(books[len(books)-1].String(), nil)
+	}
+	return books, page, nil
+}
+
+// SetBookAuthors replaces the authors of a book
+func (r *authorPgRepo) SetBookAuthors(bookID bookEntity.ID, authors []entity.ID) error {
+	return r.transact(func(tx *sqlx.Tx) error {
+		return setBookAuthors(tx, bookID, authors)
+	})
+}
+
+// setBookAuthors replaces the authors of a book within tx
+func setBookAuthors(tx *sqlx.Tx, bookID bookEntity.ID, authors []entity.ID) error {
+	ids := make([]string, len(authors))
+	for i, a := range authors {
+		ids[i] = a.String()
+	}
+	_, err := tx.Exec(`delete from book_author where book_id = $1`, bookID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`insert into book_author (book_id, author_id, position)
+		select $1, a.id, a.position from unnest($2::varchar[]) with ordinality as a(id, position)`,
+		bookID, pq.Array(ids))
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "foreign_key_violation" {
+		return entity.ErrAuthorNotFound
+	}
+	return err
+}
+
+// CreditBook replaces the authors of a book with those going by the names. A lock on
+// every name keeps two books credited at once from creating the same author twice
+func (r *authorPgRepo) CreditBook(bookID bookEntity.ID, names []string) error {
+	return r.transact(func(tx *sqlx.Tx) error {
+		var authors []entity.ID
+		seen := map[entity.ID]bool{}
+		for _, name := range names {
+			_, err := tx.Exec(`select pg_advisory_xact_lock(hashtext(lower($1)))`, name)
+			if err != nil {
+				return err
+			}
+			var ids []entity.ID
+			err = tx.Select(&ids, `select id from author where lower(name) = lower($1) order by created_at, id limit 1`, name)
+			if err != nil {
+				return err
+			}
+			var id entity.ID
+			if len(ids) > 0 {
+				id = ids[0]
+			} else {
+				a, err := entity.New(name, "")
+				if err != nil {
+					return err
+				}
+				_, err = tx.Exec(`insert into author (id, name, dates, created_at, updated_at) values($1,$2,$3,$4,$4)`,
+					a.ID, a.Name, a.Dates, a.CreatedAt)
+				if err != nil {
+					return err
+				}
+				id = a.ID
+			}
+			if !seen[id] {
+				seen[id] = true
+				authors = append(authors, id)
+			}
+		}
+		return setBookAuthors(tx, bookID, authors)
+	})
+}
+
+// Delete an author, book_author rows go with it
+func (r *authorPgRepo) Delete(id entity.ID) error {
+	res, err := r.db.Exec(`delete from author where id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrAuthorNotFound
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+//go:generate mockgen -destination=../mock/author_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/author/infrastructure Reader,Writer,AuthorRepo
+
+// Reader interface, lists come a page at a time. The authors of a book come
+// in the order they are credited, the books of an author by id
+type Reader interface {
+	Get(id entity.ID) (*entity.Author, error)
+	List(opts repository.ListOptions) ([]*entity.Author, *repository.Page, error)
+	ListByBook(bookID bookEntity.ID) ([]*entity.Author, error)
+	ListBooks(id entity.ID, opts repository.ListOptions) ([]bookEntity.ID, *repository.Page, error)
+}
+
+// Writer author writer. CreditBook sets the authors of a book by name, a name goes to its
+// oldest author and the names no author goes by yet become new authors
+type Writer interface {
+	Create(e *entity.Author) (entity.ID, error)
+	Update(e *entity.Author) error
+	Delete(id entity.ID) error
+	SetBookAuthors(bookID bookEntity.ID, authors []entity.ID) error
+	CreditBook(bookID bookEntity.ID, names []string) error
+}
+
+// AuthorRepo interface
+type AuthorRepo interface {
+	Reader
+	Writer
+}
+
+// authorSorts maps the fields authors are sorted on to their column
+var authorSorts = map[string]string{
+	"":           "id",
+	"name":       "lower(name)",
+	"created_at": "created_at",
+}
+
+// authorSortKey is the value of an author for the sort field
+func authorSortKey(a *entity.Author, field string) interface{} {
+	switch field {
+	case "name":
+		return strings.ToLower(a.Name)
+	case "created_at":
+		return a.CreatedAt
+	}
+	return nil
+}
@@ -0,0 +1,349 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/author/infrastructure (interfaces: Reader,Writer,AuthorRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+// MockReader is a mock of Reader interface.
+type MockReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderMockRecorder
+}
+
+// MockReaderMockRecorder is the mock recorder for MockReader.
+type MockReaderMockRecorder struct {
+	mock *MockReader
+}
+
+// NewMockReader creates a new mock instance.
+func NewMockReader(ctrl *gomock.Controller) *MockReader {
+	mock := &MockReader{ctrl: ctrl}
+	mock.recorder = &MockReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReader) EXPECT() *MockReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReader) Get(arg0 xid.ID) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockReader) List(arg0 repository.ListOptions) ([]*entity.Author, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockReaderMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), arg0)
+}
+
+// ListBooks mocks base method.
+func (m *MockReader) ListBooks(arg0 xid.ID, arg1 repository.ListOptions) ([]xid.ID, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooks", arg0, arg1)
+	ret0, _ := ret[0].([]xid.ID)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBooks indicates an expected call of ListBooks.
+func (mr *MockReaderMockRecorder) ListBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockReader)(nil).ListBooks), arg0, arg1)
+}
+
+// ListByBook mocks base method.
+func (m *MockReader) ListByBook(arg0 xid.ID) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockReaderMockRecorder) ListByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockReader)(nil).ListByBook), arg0)
+}
+
+// MockWriter is a mock of Writer interface.
+type MockWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWriterMockRecorder
+}
+
+// MockWriterMockRecorder is the mock recorder for MockWriter.
+type MockWriterMockRecorder struct {
+	mock *MockWriter
+}
+
+// NewMockWriter creates a new mock instance.
+func NewMockWriter(ctrl *gomock.Controller) *MockWriter {
+	mock := &MockWriter{ctrl: ctrl}
+	mock.recorder = &MockWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWriter) EXPECT() *MockWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWriter) Create(arg0 *entity.Author) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWriter)(nil).Create), arg0)
+}
+
+// CreditBook mocks base method.
+func (m *MockWriter) CreditBook(arg0 xid.ID, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditBook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditBook indicates an expected call of CreditBook.
+func (mr *MockWriterMockRecorder) CreditBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditBook", reflect.TypeOf((*MockWriter)(nil).CreditBook), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockWriter) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWriterMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWriter)(nil).Delete), arg0)
+}
+
+// SetBookAuthors mocks base method.
+func (m *MockWriter) SetBookAuthors(arg0 xid.ID, arg1 []xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookAuthors", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookAuthors indicates an expected call of SetBookAuthors.
+func (mr *MockWriterMockRecorder) SetBookAuthors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookAuthors", reflect.TypeOf((*MockWriter)(nil).SetBookAuthors), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockWriter) Update(arg0 *entity.Author) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWriterMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWriter)(nil).Update), arg0)
+}
+
+// MockAuthorRepo is a mock of AuthorRepo interface.
+type MockAuthorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorRepoMockRecorder
+}
+
+// MockAuthorRepoMockRecorder is the mock recorder for MockAuthorRepo.
+type MockAuthorRepoMockRecorder struct {
+	mock *MockAuthorRepo
+}
+
+// NewMockAuthorRepo creates a new mock instance.
+func NewMockAuthorRepo(ctrl *gomock.Controller) *MockAuthorRepo {
+	mock := &MockAuthorRepo{ctrl: ctrl}
+	mock.recorder = &MockAuthorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorRepo) EXPECT() *MockAuthorRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuthorRepo) Create(arg0 *entity.Author) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthorRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorRepo)(nil).Create), arg0)
+}
+
+// CreditBook mocks base method.
+func (m *MockAuthorRepo) CreditBook(arg0 xid.ID, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditBook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditBook indicates an expected call of CreditBook.
+func (mr *MockAuthorRepoMockRecorder) CreditBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditBook", reflect.TypeOf((*MockAuthorRepo)(nil).CreditBook), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAuthorRepo) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAuthorRepoMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAuthorRepo)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockAuthorRepo) Get(arg0 xid.ID) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAuthorRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAuthorRepo)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockAuthorRepo) List(arg0 repository.ListOptions) ([]*entity.Author, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAuthorRepoMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAuthorRepo)(nil).List), arg0)
+}
+
+// ListBooks mocks base method.
+func (m *MockAuthorRepo) ListBooks(arg0 xid.ID, arg1 repository.ListOptions) ([]xid.ID, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooks", arg0, arg1)
+	ret0, _ := ret[0].([]xid.ID)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBooks indicates an expected call of ListBooks.
+func (mr *MockAuthorRepoMockRecorder) ListBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockAuthorRepo)(nil).ListBooks), arg0, arg1)
+}
+
+// ListByBook mocks base method.
+func (m *MockAuthorRepo) ListByBook(arg0 xid.ID) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockAuthorRepoMockRecorder) ListByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockAuthorRepo)(nil).ListByBook), arg0)
+}
+
+// SetBookAuthors mocks base method.
+func (m *MockAuthorRepo) SetBookAuthors(arg0 xid.ID, arg1 []xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookAuthors", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookAuthors indicates an expected call of SetBookAuthors.
+func (mr *MockAuthorRepoMockRecorder) SetBookAuthors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookAuthors", reflect.TypeOf((*MockAuthorRepo)(nil).SetBookAuthors), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockAuthorRepo) Update(arg0 *entity.Author) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAuthorRepoMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorRepo)(nil).Update), arg0)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/author/usecase (interfaces: AuthorUseCase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	entity0 "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+// MockAuthorUseCase is a mock of AuthorUseCase interface.
+type MockAuthorUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorUseCaseMockRecorder
+}
+
+// MockAuthorUseCaseMockRecorder is the mock recorder for MockAuthorUseCase.
+type MockAuthorUseCaseMockRecorder struct {
+	mock *MockAuthorUseCase
+}
+
+// NewMockAuthorUseCase creates a new mock instance.
+func NewMockAuthorUseCase(ctrl *gomock.Controller) *MockAuthorUseCase {
+	mock := &MockAuthorUseCase{ctrl: ctrl}
+	mock.recorder = &MockAuthorUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorUseCase) EXPECT() *MockAuthorUseCaseMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthor mocks base method.
+func (m *MockAuthorUseCase) CreateAuthor(arg0, arg1 string) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthor", arg0, arg1)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuthor indicates an expected call of CreateAuthor.
+func (mr *MockAuthorUseCaseMockRecorder) CreateAuthor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthor", reflect.TypeOf((*MockAuthorUseCase)(nil).CreateAuthor), arg0, arg1)
+}
+
+// DeleteAuthor mocks base method.
+func (m *MockAuthorUseCase) DeleteAuthor(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthor", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuthor indicates an expected call of DeleteAuthor.
+func (mr *MockAuthorUseCaseMockRecorder) DeleteAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockAuthorUseCase)(nil).DeleteAuthor), arg0)
+}
+
+// GetAuthor mocks base method.
+func (m *MockAuthorUseCase) GetAuthor(arg0 string) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthor", arg0)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockAuthorUseCaseMockRecorder) GetAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockAuthorUseCase)(nil).GetAuthor), arg0)
+}
+
+// ListAuthorBooks mocks base method.
+func (m *MockAuthorUseCase) ListAuthorBooks(arg0 string, arg1 repository.ListOptions) ([]*entity0.Book, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthorBooks", arg0, arg1)
+	ret0, _ := ret[0].([]*entity0.Book)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuthorBooks indicates an expected call of ListAuthorBooks.
+func (mr *MockAuthorUseCaseMockRecorder) ListAuthorBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthorBooks", reflect.TypeOf((*MockAuthorUseCase)(nil).ListAuthorBooks), arg0, arg1)
+}
+
+// ListAuthors mocks base method.
+func (m *MockAuthorUseCase) ListAuthors(arg0 repository.ListOptions) ([]*entity.Author, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthors", arg0)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuthors indicates an expected call of ListAuthors.
+func (mr *MockAuthorUseCaseMockRecorder) ListAuthors(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthors", reflect.TypeOf((*MockAuthorUseCase)(nil).ListAuthors), arg0)
+}
+
+// ListBookAuthors mocks base method.
+func (m *MockAuthorUseCase) ListBookAuthors(arg0 string) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookAuthors", arg0)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookAuthors indicates an expected call of ListBookAuthors.
+func (mr *MockAuthorUseCaseMockRecorder) ListBookAuthors(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookAuthors", reflect.TypeOf((*MockAuthorUseCase)(nil).ListBookAuthors), arg0)
+}
+
+// SetBookAuthors mocks base method.
+func (m *MockAuthorUseCase) SetBookAuthors(arg0 string, arg1 []string) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookAuthors", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookAuthors indicates an expected call of SetBookAuthors.
+func (mr *MockAuthorUseCaseMockRecorder) SetBookAuthors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookAuthors", reflect.TypeOf((*MockAuthorUseCase)(nil).SetBookAuthors), arg0, arg1)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorUseCase) UpdateAuthor(arg0 *entity.Author) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorUseCaseMockRecorder) UpdateAuthor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorUseCase)(nil).UpdateAuthor), arg0)
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/author/infrastructure"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	bookUseCase "github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+//go:generate mockgen -destination=../mock/author_usecase_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/author/usecase AuthorUseCase
+
+// AuthorUseCase is the interface that provides the methods.
+type AuthorUseCase interface {
+	GetAuthor(id string) (*entity.Author, error)
+	ListAuthors(opts repository.ListOptions) ([]*entity.Author, *repository.Page, error)
+	CreateAuthor(name string, dates string) (entity.ID, error)
+	UpdateAuthor(e *entity.Author) error
+	DeleteAuthor(id string) error
+	ListAuthorBooks(id string, opts repository.ListOptions) ([]*bookEntity.Book, *repository.Page, error)
+	ListBookAuthors(bookID string) ([]*entity.Author, error)
+	SetBookAuthors(bookID string, authorIDs []string) ([]*entity.Author, error)
+}
+
+type authorUseCase struct {
+	repo  infrastructure.AuthorRepo
+	books bookUseCase.BookUseCase
+	log   *logger.Logger
+}
+
+// New create new author use case, books are read through the book use case
+func New(s *server.Server, r infrastructure.AuthorRepo, b bookUseCase.BookUseCase) AuthorUseCase {
+	return &authorUseCase{
+		repo:  r,
+		books: b,
+		log:   s.Log,
+	}
+}
+
+// GetAuthor get an author
+func (u *authorUseCase) GetAuthor(id string) (*entity.Author, error) {
+	aID, err := entity.IDFromString(id)
+	if err != nil {
+		return nil, entity.ErrAuthorNotFound
+	}
+	return u.repo.Get(aID)
+}
+
+// ListAuthors lists a page of authors
+func (u *authorUseCase) ListAuthors(opts repository.ListOptions) ([]*entity.Author, *repository.Page, error) {
+	return u.repo.List(opts)
+}
+
+// CreateAuthor create an author
+func (u *authorUseCase) CreateAuthor(name string, dates string) (entity.ID, error) {
+	a, err := entity.New(name, dates)
+	if err != nil {
+		return entity.ID{}, err
+	}
+	return u.repo.Create(a)
+}
+
+// UpdateAuthor Update an author, a new name is written on its books
+func (u *authorUseCase) UpdateAuthor(e *entity.Author) error {
+	err := e.Validate()
+	if err != nil {
+		return err
+	}
+	stored, err := u.repo.Get(e.ID)
+	if err != nil {
+		return err
+	}
+	e.UpdatedAt = time.Now()
+	err = u.repo.Update(e)
+	if err != nil || stored.Name == e.Name {
+		return err
+	}
+	books, err := u.bookIDs(e.ID)
+	if err != nil {
+		return err
+	}
+	return u.writeNames(books)
+}
+
+// DeleteAuthor Delete an author, its books stay in the catalog credited to their other authors.
+// The only author of a book cannot be deleted
+func (u *authorUseCase) DeleteAuthor(id string) error {
+	a, err := u.GetAuthor(id)
+	if err != nil {
+		return err
+	}
+	books, err := u.bookIDs(a.ID)
+	if err != nil {
+		return err
+	}
+	for _, bookID := range books {
+		authors, err := u.repo.ListByBook(bookID)
+		if err != nil {
+			return err
+		}
+		if len(authors) == 1 {
+			return entity.ErrAuthorCannotBeDeleted
+		}
+	}
+	err = u.repo.Delete(a.ID)
+	if err != nil {
+		return err
+	}
+	return u.writeNames(books)
+}
+
+// bookPageSize is how many books of an author are read at a time
+const bookPageSize = 500
+
+// bookIDs lists the ids of every book of an author
+func (u *authorUseCase) bookIDs(id entity.ID) ([]bookEntity.ID, error) {
+	var books []bookEntity.ID
+	err := repository.EachPage(bookPageSize, func(opts repository.ListOptions) (*repository.Page, error) {
+		ids, page, err := u.repo.ListBooks(id, opts)
+		books = append(books, ids...)
+		return page, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+// writeNames writes the names of their authors as the author of the books
+func (u *authorUseCase) writeNames(books []bookEntity.ID) error {
+	for _, bookID := range books {
+		authors, err := u.repo.ListByBook(bookID)
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(authors))
+		for i, a := range authors {
+			names[i] = a.Name
+		}
+		_, err = u.books.SetAuthorNames(bookID.String(), names)
+		if err != nil && err != bookEntity.ErrBookNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAuthorBooks lists a page of the books of an author, with their quantities
+func (u *authorUseCase) ListAuthorBooks(id string, opts repository.ListOptions) ([]*bookEntity.Book, *repository.Page, error) {
+	a, err := u.GetAuthor(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, page, err := u.repo.ListBooks(a.ID, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var books []*bookEntity.Book
+	for _, bookID := range ids {
+		b, err := u.books.GetBook(bookID.String())
+		if err == bookEntity.ErrBookNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		books = append(books, b)
+	}
+	return books, page, nil
+}
+
+// ListBookAuthors lists the authors of a book in the order they are credited
+func (u *authorUseCase) ListBookAuthors(bookID string) ([]*entity.Author, error) {
+	b, err := u.books.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.ListByBook(b.ID)
+}
+
+// SetBookAuthors credits a book to authors, in order, in place of its former authors,
+// and writes their names as its author
+func (u *authorUseCase) SetBookAuthors(bookID string, authorIDs []string) ([]*entity.Author, error) {
+	b, err := u.books.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []entity.ID
+	seen := map[entity.ID]bool{}
+	for _, id := range authorIDs {
+		a, err := u.GetAuthor(id)
+		if err != nil {
+			return nil, err
+		}
+		if seen[a.ID] {
+			continue
+		}
+		seen[a.ID] = true
+		ids = append(ids, a.ID)
+	}
+	if len(ids) == 0 {
+		return nil, bookEntity.ErrInvalidBookEntity
+	}
+	err = u.repo.SetBookAuthors(b.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+	err = u.writeNames([]bookEntity.ID{b.ID})
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.ListByBook(b.ID)
+}
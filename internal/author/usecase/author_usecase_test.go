@@ -0,0 +1,158 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/author/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/author/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/internal/author/usecase"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
+	bookUseCase "github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	borrowInfra "github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFixture(t *testing.T) (usecase.AuthorUseCase, bookUseCase.BookUseCase) {
+	logger := logger.New()
+	t.Cleanup(func() { logger.Zap.Sync() })
+	s := &server.Server{
+		Log: logger,
+	}
+	copies := bookInfra.NewCopyInMemRepo()
+	authors := infrastructure.NewInMemRepo()
+	r := bookInfra.NewInMemRepo(copies)
+	subjects := bookInfra.NewSubjectInMemRepo()
+	books := bookUseCase.New(s, r, copies, subjects, bookInfra.NewWorkInMemRepo(), bookInfra.NewSeriesInMemRepo(), borrowInfra.NewHoldInMemRepo(),
+		bookInfra.NewInMemUnitOfWork(&bookInfra.Repos{Books: r, Copies: copies, Subjects: subjects, Credits: authors}))
+	return usecase.New(s, authors, books), books
+}
+
+func Test_authorUseCase_CRUD(t *testing.T) {
+	m, _ := newFixture(t)
+	id, err := m.CreateAuthor("Ozzy Osbourne", "1948-")
+	assert.Nil(t, err)
+	_, err = m.CreateAuthor("", "")
+	assert.Equal(t, entity.ErrInvalidAuthorEntity, err)
+
+	a, err := m.GetAuthor(id.String())
+	assert.Nil(t, err)
+	assert.Equal(t, "Ozzy Osbourne", a.Name)
+	a.Name = "John Michael Osbourne"
+	assert.Nil(t, m.UpdateAuthor(a))
+	a, err = m.GetAuthor(id.String())
+	assert.Nil(t, err)
+	assert.Equal(t, "John Michael Osbourne", a.Name)
+	assert.False(t, a.UpdatedAt.IsZero())
+
+	authors, page, err := m.ListAuthors(repository.ListOptions{Sort: "name"})
+	assert.Nil(t, err)
+	assert.Len(t, authors, 1)
+	assert.Equal(t, 1, page.Total)
+
+	assert.Nil(t, m.DeleteAuthor(id.String()))
+	_, err = m.GetAuthor(id.String())
+	assert.Equal(t, entity.ErrAuthorNotFound, err)
+	assert.Equal(t, entity.ErrAuthorNotFound, m.DeleteAuthor("not an id"))
+}
+
+func Test_authorUseCase_Books(t *testing.T) {
+	m, books := newFixture(t)
+	ozzy, _ := m.CreateAuthor("Ozzy Osbourne", "1948-")
+	chris, _ := m.CreateAuthor("Chris Ayres", "")
+	memoir, _, _ := books.CreateBook("I Am Ozzy", "Ozzy Osbourne & Chris Ayres", 294, 1, "")
+	trust, _, _ := books.CreateBook("Trust Me, I'm Dr. Ozzy", "Ozzy Osbourne", 272, 2, "")
+
+	authors, err := m.SetBookAuthors(memoir.String(), []string{ozzy.String(), chris.String(), ozzy.String()})
+	assert.Nil(t, err)
+	assert.Len(t, authors, 2)
+	assert.Equal(t, "Ozzy Osbourne", authors[0].Name)
+	assert.Equal(t, "Chris Ayres", authors[1].Name)
+	_, err = m.SetBookAuthors(trust.String(), []string{ozzy.String()})
+	assert.Nil(t, err)
+
+	_, err = m.SetBookAuthors(trust.String(), []string{entity.NewID().String()})
+	assert.Equal(t, entity.ErrAuthorNotFound, err)
+	_, err = m.SetBookAuthors(bookEntity.NewID().String(), []string{ozzy.String()})
+	assert.Equal(t, bookEntity.ErrBookNotFound, err)
+
+	data, page, err := m.ListAuthorBooks(ozzy.String(), repository.ListOptions{Limit: 1})
+	assert.Nil(t, err)
+	assert.Len(t, data, 1)
+	assert.Equal(t, 2, page.Total)
+	assert.NotEqual(t, "", page.Next)
+	next, _, err := m.ListAuthorBooks(ozzy.String(), repository.ListOptions{Limit: 1, Cursor: page.Next})
+	assert.Nil(t, err)
+	assert.Len(t, next, 1)
+	assert.NotEqual(t, data[0].ID, next[0].ID)
+
+	data, _, err = m.ListAuthorBooks(chris.String(), repository.ListOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, data, 1)
+	assert.Equal(t, "I Am Ozzy", data[0].Title)
+	assert.Equal(t, 1, data[0].Quantity)
+
+	// the book stays, credited to its other author
+	assert.Nil(t, m.DeleteAuthor(chris.String()))
+	authors, err = m.ListBookAuthors(memoir.String())
+	assert.Nil(t, err)
+	assert.Len(t, authors, 1)
+
+	_, _, err = m.ListAuthorBooks(chris.String(), repository.ListOptions{})
+	assert.Equal(t, entity.ErrAuthorNotFound, err)
+}
+
+func Test_authorUseCase_Credits(t *testing.T) {
+	m, books := newFixture(t)
+	ozzy, _ := m.CreateAuthor("Ozzy Osbourne", "1948-")
+
+	t.Run("new book is credited to the authors it names", func(t *testing.T) {
+		memoir, _, err := books.CreateBook("I Am Ozzy", "ozzy osbourne & Chris Ayres", 294, 1, "")
+		assert.Nil(t, err)
+		authors, err := m.ListBookAuthors(memoir.String())
+		assert.Nil(t, err)
+		assert.Len(t, authors, 2)
+		assert.Equal(t, ozzy, authors[0].ID)
+		assert.Equal(t, "Chris Ayres", authors[1].Name)
+		data, _, err := m.ListAuthorBooks(ozzy.String(), repository.ListOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, data, 1)
+	})
+
+	t.Run("new author on update", func(t *testing.T) {
+		id, _, _ := books.CreateBook("Trust Me, I'm Dr. Ozzy", "Ozzy Osbourne", 272, 1, "")
+		b, _ := books.GetBook(id.String())
+		b.Author = "Ozzy Osbourne and Chris Ayres"
+		assert.Nil(t, books.UpdateBook(b))
+		authors, err := m.ListBookAuthors(id.String())
+		assert.Nil(t, err)
+		assert.Len(t, authors, 2)
+		all, _, _ := m.ListAuthors(repository.ListOptions{})
+		assert.Len(t, all, 2)
+	})
+
+	t.Run("credits are written on the book", func(t *testing.T) {
+		id, _, _ := books.CreateBook("Diary of a Madman", "Ozzy Osbourne", 320, 1, "")
+		lemmy, _ := m.CreateAuthor("Lemmy Kilmister", "1945-2015")
+		_, err := m.SetBookAuthors(id.String(), []string{lemmy.String(), ozzy.String()})
+		assert.Nil(t, err)
+		b, _ := books.GetBook(id.String())
+		assert.Equal(t, "Lemmy Kilmister; Ozzy Osbourne", b.Author)
+		_, err = m.SetBookAuthors(id.String(), nil)
+		assert.Equal(t, bookEntity.ErrInvalidBookEntity, err)
+
+		a, _ := m.GetAuthor(lemmy.String())
+		a.Name = "Ian Kilmister"
+		assert.Nil(t, m.UpdateAuthor(a))
+		b, _ = books.GetBook(id.String())
+		assert.Equal(t, "Ian Kilmister; Ozzy Osbourne", b.Author)
+
+		assert.Nil(t, m.DeleteAuthor(lemmy.String()))
+		b, _ = books.GetBook(id.String())
+		assert.Equal(t, "Ozzy Osbourne", b.Author)
+		assert.Equal(t, entity.ErrAuthorCannotBeDeleted, m.DeleteAuthor(ozzy.String()))
+	})
+}
@@ -0,0 +1,141 @@
+package adapter
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+// csvColumns are the columns of a catalog CSV file, in the order they are written.
+// Imports ignore the id and take quantity as the number of copies to shelve.
+var csvColumns = []string{"id", "isbn", "title", "author", "pages", "quantity", "language", "published_at", "tags"}
+
+// tagSeparator separates the tags of a book in a CSV cell
+const tagSeparator = ";"
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+// NewCSVReader reads a catalog CSV file whose header names its columns, in any order:
+// title, author and pages are required, isbn, quantity (1 when blank), language,
+// published_at (a date) and tags (separated by ;) are optional, others are ignored
+func NewCSVReader(r io.Reader) (entity.RecordReader, error) {
+	c := &csvReader{r: csv.NewReader(r), columns: map[string]int{}}
+	c.r.FieldsPerRecord = -1
+	header, err := c.r.Read()
+	if err != nil {
+		return nil, entity.ErrInvalidCatalog
+	}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		c.columns[h] = i
+	}
+	for _, required := range []string{"title", "author", "pages"} {
+		if _, ok := c.columns[required]; !ok {
+			return nil, entity.ErrInvalidCatalog
+		}
+	}
+	return c, nil
+}
+
+// Read the next row, rows are counted from the one after the header
+func (c *csvReader) Read() (*entity.ImportRecord, error) {
+	fields, err := c.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	c.row++
+	rec := &entity.ImportRecord{Row: c.row}
+	if perr, ok := err.(*csv.ParseError); ok {
+		rec.Err = perr.Err
+		return rec, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec.Book, rec.Err = c.book(fields)
+	return rec, nil
+}
+
+func (c *csvReader) book(fields []string) (*entity.Book, error) {
+	get := func(column string) string {
+		i, ok := c.columns[column]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+	b := &entity.Book{
+		ISBN:     get("isbn"),
+		Title:    get("title"),
+		Author:   get("author"),
+		Quantity: 1,
+		Language: strings.ToLower(get("language")),
+	}
+	var err error
+	if p := get("pages"); p != "" {
+		b.Pages, err = strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pages %q", p)
+		}
+	}
+	if q := get("quantity"); q != "" {
+		b.Quantity, err = strconv.Atoi(q)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid quantity %q", q)
+		}
+	}
+	if p := get("published_at"); p != "" {
+		b.PublishedAt, err = time.Parse(dateLayout, p)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid published_at %q", p)
+		}
+	}
+	if t := get("tags"); t != "" {
+		b.Tags = strings.Split(t, tagSeparator)
+	}
+	return b, nil
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSVWriter writes a catalog CSV file, header first
+func NewCSVWriter(w io.Writer) (BookWriter, error) {
+	c := &csvWriter{w: csv.NewWriter(w)}
+	return c, c.w.Write(csvColumns)
+}
+
+// Write a row
+func (c *csvWriter) Write(b *entity.Book) error {
+	published := ""
+	if !b.PublishedAt.IsZero() {
+		published = b.PublishedAt.Format(dateLayout)
+	}
+	return c.w.Write([]string{
+		b.ID.String(),
+		b.ISBN,
+		b.Title,
+		b.Author,
+		strconv.Itoa(b.Pages),
+		strconv.Itoa(b.Quantity),
+		b.Language,
+		published,
+		strings.Join(b.Tags, tagSeparator),
+	})
+}
+
+// Close flushes the rows still buffered
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,91 @@
+package adapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+)
+
+// FormatJSONL is a catalog file of a JSON book per line, only exports write it
+const FormatJSONL = "jsonl"
+
+// BookWriter writes a catalog file a book at a time, Close flushes it
+type BookWriter interface {
+	Write(b *entity.Book) error
+	Close() error
+}
+
+type jsonlWriter struct {
+	e *json.Encoder
+}
+
+// Write the JSON of a book on a line
+func (j *jsonlWriter) Write(b *entity.Book) error {
+	return j.e.Encode(NewBookHTTP(b))
+}
+
+// Close has nothing to flush, lines are written whole
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+// NewBookWriter writes a catalog file in format
+func NewBookWriter(format string, w io.Writer) (BookWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w)
+	case FormatJSONL:
+		return &jsonlWriter{e: json.NewEncoder(w)}, nil
+	case FormatMARC:
+		return NewMARCWriter(w), nil
+	}
+	return nil, entity.ErrUnknownFormat
+}
+
+// exportTypes are the Content-Type and file extension of the export formats
+var exportTypes = map[string][2]string{
+	FormatCSV:   {"text/csv; charset=utf-8", "csv"},
+	FormatJSONL: {"application/x-ndjson", "jsonl"},
+	FormatMARC:  {"application/marc", "mrc"},
+}
+
+// ExportBooksHTTP handler, streams the whole catalog as a file in format, csv,
+// jsonl (the default) or marc. It takes the filters of a list.
+func ExportBooksHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = FormatJSONL
+		}
+		t, ok := exportTypes[format]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(entity.ErrUnknownFormat.Error()))
+			return
+		}
+		c, err := criteria(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", t[0])
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="books.%s"`, t[1]))
+		bw, err := NewBookWriter(format, w)
+		if err == nil {
+			err = u.ExportBooks(c, bw.Write)
+		}
+		if err == nil {
+			err = bw.Close()
+		}
+		if err != nil {
+			// the status is sent already, the client gets a truncated file
+			log.Println(err.Error())
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package adapter_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+func newExportBook() *entity.Book {
+	return &entity.Book{
+		ID:          entity.NewID(),
+		ISBN:        "9780446569897",
+		Title:       "I Am Ozzy",
+		Author:      "Ozzy Osbourne",
+		Pages:       294,
+		Quantity:    2,
+		Language:    "en",
+		PublishedAt: time.Date(2010, 1, 25, 0, 0, 0, 0, time.UTC),
+		Tags:        []string{"music", "memoir"},
+		CreatedAt:   time.Now(),
+	}
+}
+
+func TestBookWriter_RoundTrip(t *testing.T) {
+	b := newExportBook()
+	for _, format := range []string{adapter.FormatCSV, adapter.FormatMARC} {
+		var buf bytes.Buffer
+		w, err := adapter.NewBookWriter(format, &buf)
+		assert.Nil(t, err)
+		assert.Nil(t, w.Write(b))
+		assert.Nil(t, w.Close())
+
+		src, err := adapter.NewRecordReader(format, &buf)
+		assert.Nil(t, err)
+		recs := readAll(t, src)
+		assert.Len(t, recs, 1)
+		assert.Nil(t, recs[0].Err)
+		got := recs[0].Book
+		assert.Equal(t, b.ISBN, got.ISBN, format)
+		assert.Equal(t, b.Title, got.Title, format)
+		assert.Equal(t, b.Author, got.Author, format)
+		assert.Equal(t, b.Pages, got.Pages, format)
+		assert.Equal(t, b.Language, got.Language, format)
+		assert.Equal(t, b.Tags, got.Tags, format)
+		assert.Equal(t, b.PublishedAt.Year(), got.PublishedAt.Year(), format)
+	}
+
+	_, err := adapter.NewBookWriter("xml", &bytes.Buffer{})
+	assert.Equal(t, entity.ErrUnknownFormat, err)
+}
+
+func TestExportBooksHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	b := newExportBook()
+	u.EXPECT().
+		ExportBooks(entity.Criteria{Language: "en"}, gomock.Any()).
+		DoAndReturn(func(c entity.Criteria, each func(b *entity.Book) error) error {
+			return each(b)
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/book/export?format=csv&language=en", nil)
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="books.csv"`, res.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,isbn,title,author,pages,quantity,language,published_at,tags\n"+
+		b.ID.String()+",9780446569897,I Am Ozzy,Ozzy Osbourne,294,2,en,2010-01-25,music;memoir\n", res.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/book/export?format=xml", nil)
+	res = httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
@@ -4,57 +4,110 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	auth "github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 )
 
-// BookHTTP JSON data
+// BookHTTP JSON data, PublishedAt is a date. Editions are the other editions of
+// the work of the book, only a single book read by id lists them
 type BookHTTP struct {
-	ID       entity.ID `json:"id"`
-	Title    string    `json:"title"`
-	Author   string    `json:"author"`
-	Pages    int       `json:"pages"`
-	Quantity int       `json:"quantity"`
+	ID          entity.ID      `json:"id"`
+	ISBN        string         `json:"isbn,omitempty"`
+	ISBN10      string         `json:"isbn_10,omitempty"`
+	Title       string         `json:"title"`
+	Author      string         `json:"author"`
+	Pages       int            `json:"pages"`
+	Quantity    int            `json:"quantity"`
+	Language    string         `json:"language,omitempty"`
+	PublishedAt string         `json:"published_at,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Subjects    []*SubjectHTTP `json:"subjects,omitempty"`
+	WorkID      *entity.ID     `json:"work_id,omitempty"`
+	Editions    []*BookHTTP    `json:"editions,omitempty"`
 }
 
-// ListBooksHTTP handler
+// dateLayout is how dates read in JSON and query strings
+const dateLayout = "2006-01-02"
+
+// BookHitHTTP JSON data of a book found by a search
+type BookHitHTTP struct {
+	BookHTTP
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// ListBooksHTTP handler, one page at a time. Books are searched by title
+// and author when q (or title) is given, the best matches first, and filtered
+// by author, published_after, available, tag and language
 func ListBooksHTTP(u usecase.BookUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error reading books"
-		var data []*entity.Book
-		var err error
-		title := r.URL.Query().Get("title")
+		var toJ interface{}
+		var n int
+		var page *repository.Page
+		opts := router.ListOptionsFromContext(r.Context())
+		c, err := criteria(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		q := r.URL.Query().Get("q")
+		if q == "" {
+			q = r.URL.Query().Get("title")
+		}
 		switch {
-		case title == "":
-			data, err = u.ListBooks()
+		case q == "":
+			var data []*entity.Book
+			data, page, err = u.ListBooks(c, opts)
+			var books []*BookHTTP
+			for _, d := range data {
+				books = append(books, NewBookHTTP(d))
+			}
+			toJ, n = books, len(books)
 		default:
-			data, err = u.SearchBooks(title)
+			var data []*entity.BookHit
+			data, page, err = u.SearchBooks(q, c, opts)
+			var hits []*BookHitHTTP
+			for _, d := range data {
+				hits = append(hits, &BookHitHTTP{
+					BookHTTP: *NewBookHTTP(&d.Book),
+					Rank:     d.Rank,
+					Snippet:  d.Snippet,
+				})
+			}
+			toJ, n = hits, len(hits)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		if err != nil && err != entity.ErrBookNotFound {
-			w.WriteHeader(http.StatusNotFound)
+		switch err {
+		case nil, entity.ErrBookNotFound:
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(errorMessage))
 			return
 		}
+		if page != nil {
+			router.SetPage(w, r, opts, page)
+		}
 
-		if data == nil {
+		if n == 0 {
 			w.WriteHeader(http.StatusNotFound)
 			w.Write([]byte(errorMessage))
 			return
 		}
-		var toJ []*BookHTTP
-		for _, d := range data {
-			toJ = append(toJ, &BookHTTP{
-				ID:       d.ID,
-				Title:    d.Title,
-				Author:   d.Author,
-				Pages:    d.Pages,
-				Quantity: d.Quantity,
-			})
-		}
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(errorMessage))
@@ -62,11 +115,64 @@ func ListBooksHTTP(u usecase.BookUseCase) http.HandlerFunc {
 	})
 }
 
-// CreateBookHTTP handler
+// criteria reads the filters of a list request
+func criteria(r *http.Request) (entity.Criteria, error) {
+	q := r.URL.Query()
+	c := entity.Criteria{
+		Author:   q.Get("author"),
+		Tags:     q["tag"],
+		Language: q.Get("language"),
+	}
+	if p := q.Get("published_after"); p != "" {
+		t, err := time.Parse(dateLayout, p)
+		if err != nil {
+			return c, entity.ErrInvalidCriteria
+		}
+		c.PublishedAfter = t
+	}
+	if a := q.Get("available"); a != "" {
+		available, err := strconv.ParseBool(a)
+		if err != nil {
+			return c, entity.ErrInvalidCriteria
+		}
+		c.Available = &available
+	}
+	return c, nil
+}
+
+// NewBookHTTP gives the JSON data of a book
+func NewBookHTTP(b *entity.Book) *BookHTTP {
+	toJ := &BookHTTP{
+		ID:       b.ID,
+		ISBN:     b.ISBN,
+		ISBN10:   entity.ISBN10(b.ISBN),
+		Title:    b.Title,
+		Author:   b.Author,
+		Pages:    b.Pages,
+		Quantity: b.Quantity,
+		Language: b.Language,
+		Tags:     b.Tags,
+	}
+	for _, s := range b.Subjects {
+		toJ.Subjects = append(toJ.Subjects, newSubjectHTTP(s))
+	}
+	if !b.WorkID.IsNil() {
+		work := b.WorkID
+		toJ.WorkID = &work
+	}
+	if !b.PublishedAt.IsZero() {
+		toJ.PublishedAt = b.PublishedAt.Format(dateLayout)
+	}
+	return toJ
+}
+
+// CreateBookHTTP handler, a book whose ISBN is already catalogued gets the new
+// copies and is returned with its whole quantity
 func CreateBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error adding book"
 		var input struct {
+			ISBN     string `json:"isbn"`
 			Title    string `json:"title"`
 			Author   string `json:"author"`
 			Pages    int    `json:"pages"`
@@ -78,21 +184,32 @@ func CreateBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
 			w.Write([]byte(errorMessage))
 			return
 		}
-		id, err := u.CreateBook(input.Title, input.Author, input.Pages, input.Quantity)
+		id, merged, err := u.CreateBook(input.Title, input.Author, input.Pages, input.Quantity, input.ISBN)
+		if err == entity.ErrInvalidBookEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(errorMessage))
 			return
 		}
-		toJ := &BookHTTP{
-			ID:       id,
-			Title:    input.Title,
-			Author:   input.Author,
-			Pages:    input.Pages,
-			Quantity: input.Quantity,
+		data, err := u.GetBook(id.String())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
 		}
+		toJ := NewBookHTTP(data)
 
-		w.WriteHeader(http.StatusCreated)
+		w.Header().Set("ETag", etag(data))
+		// the copies went to the book already catalogued under the ISBN
+		if merged {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusCreated)
+		}
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
 			log.Println(err.Error())
 			w.WriteHeader(http.StatusInternalServerError)
@@ -119,13 +236,19 @@ func GetBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
 				w.Write([]byte(errorMessage))
 				return
 			}
-			toJ := &BookHTTP{
-				ID:       data.ID,
-				Title:    data.Title,
-				Author:   data.Author,
-				Pages:    data.Pages,
-				Quantity: data.Quantity,
+			toJ := NewBookHTTP(data)
+			if !data.WorkID.IsNil() {
+				editions, err := u.ListEditions(bookID)
+				if err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(errorMessage))
+					return
+				}
+				for _, e := range editions {
+					toJ.Editions = append(toJ.Editions, NewBookHTTP(e))
+				}
 			}
+			w.Header().Set("ETag", etag(data))
 			if err := json.NewEncoder(w).Encode(toJ); err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
 				w.Write([]byte(errorMessage))
@@ -140,37 +263,229 @@ func GetBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
 	})
 }
 
+// GetBookByISBNHTTP handler, takes an ISBN-10 or an ISBN-13
+func GetBookByISBNHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading book"
+		data, err := u.GetBookByISBN(chi.URLParam(r, "isbn"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrInvalidISBN:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("ETag", etag(data))
+		if err := json.NewEncoder(w).Encode(NewBookHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// etag names the version of a book
+func etag(b *entity.Book) string {
+	return `"` + strconv.Itoa(b.Version) + `"`
+}
+
+// ifMatch reads the version of the book the client edits, 0 for "*"
+func ifMatch(r *http.Request) (int, bool) {
+	h := strings.TrimSpace(r.Header.Get("If-Match"))
+	if h == "*" {
+		return 0, true
+	}
+	v, err := strconv.Atoi(strings.Trim(h, `"`))
+	if err != nil || v <= 0 || !strings.HasPrefix(h, `"`) {
+		return 0, false
+	}
+	return v, true
+}
+
+// ReplaceBookHTTP handler, PUT takes the title, the author and the pages
+func ReplaceBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return editBookHTTP(u, true)
+}
+
+// PatchBookHTTP handler, PATCH changes only the fields given
+func PatchBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return editBookHTTP(u, false)
+}
+
+// editBookHTTP corrects the metadata of the book version named by If-Match
+func editBookHTTP(u usecase.BookUseCase, full bool) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating book"
+		if r.Header.Get("If-Match") == "" {
+			w.WriteHeader(http.StatusPreconditionRequired)
+			w.Write([]byte("If-Match required"))
+			return
+		}
+		version, ok := ifMatch(r)
+		if !ok {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(entity.ErrBookVersionConflict.Error()))
+			return
+		}
+		var input struct {
+			ISBN        *string   `json:"isbn"`
+			Title       *string   `json:"title"`
+			Author      *string   `json:"author"`
+			Pages       *int      `json:"pages"`
+			Language    *string   `json:"language"`
+			PublishedAt *string   `json:"published_at"`
+			Tags        *[]string `json:"tags"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil || (full && (input.Title == nil || input.Author == nil || input.Pages == nil)) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		p := &entity.BookUpdate{
+			ISBN:     input.ISBN,
+			Title:    input.Title,
+			Author:   input.Author,
+			Pages:    input.Pages,
+			Language: input.Language,
+			Tags:     input.Tags,
+		}
+		if input.PublishedAt != nil {
+			var published time.Time
+			if *input.PublishedAt != "" {
+				published, err = time.Parse(dateLayout, *input.PublishedAt)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(errorMessage))
+					return
+				}
+			}
+			p.PublishedAt = &published
+		}
+		data, err := u.EditBook(chi.URLParam(r, "bookID"), version, p)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidBookEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrBookVersionConflict:
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrISBNTaken:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := NewBookHTTP(data)
+		w.Header().Set("ETag", etag(data))
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
 // DeleteBookHTTP handler
 func DeleteBookHTTP(u usecase.BookUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error removing bookmark"
 		if bookID := chi.URLParam(r, "bookID"); bookID != "" {
 			err := u.DeleteBook(bookID)
-			if err != nil {
+			switch err {
+			case nil:
+			case entity.ErrBookNotFound:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(errorMessage))
+			case entity.ErrBookCannotBeDeleted:
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(err.Error()))
+			default:
 				w.WriteHeader(http.StatusInternalServerError)
 				w.Write([]byte(errorMessage))
-				return
 			}
 		}
 	})
 }
 
-// HTTPRoutes defines http routes for books
+// HTTPRoutes defines http routes for books, only staff change the catalog
 func HTTPRoutes(s *server.Server, u usecase.BookUseCase) {
 	// RESTy routes for "books" resource
 	s.Router.Chi.Route("/book", func(r chi.Router) {
-		// r.With(paginate).Get("/", ListBooksHTTP(u))
-		r.Get("/", ListBooksHTTP(u))
-		r.Post("/", CreateBookHTTP(u))     // POST /book
-		r.Get("/search", ListBooksHTTP(u)) // GET /book/search?title=something
+		r.With(router.Paginate).Get("/", ListBooksHTTP(u))
+		r.With(router.Authorize(auth.Staff)).Post("/", CreateBookHTTP(u))        // POST /book
+		r.With(router.Paginate).Get("/search", ListBooksHTTP(u))                 // GET /book/search?q=something
+		r.Get("/isbn/{isbn}", GetBookByISBNHTTP(u))                              // GET /book/isbn/978-0-306-40615-7
+		r.With(router.Authorize(auth.Staff)).Post("/import", ImportBooksHTTP(u)) // POST /book/import?format=csv
+		r.With(router.Authorize(auth.Staff)).Get("/export", ExportBooksHTTP(u))  // GET /book/export?format=marc
 
 		r.Route("/{bookID}", func(r chi.Router) {
 			r.Get("/", GetBookHTTP(u)) // GET /book/123
-			// r.Put("/", UpdateArticle)    // PUT /book/123
-			r.Delete("/", DeleteBookHTTP(u)) // DELETE /book/123
+			r.With(router.Authorize(auth.Staff)).Put("/", ReplaceBookHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Patch("/", PatchBookHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Delete("/", DeleteBookHTTP(u)) // DELETE /book/123
+			r.Get("/copies", ListCopiesHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Post("/copies", AddCopyHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Put("/subjects", SetBookSubjectsHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Put("/work", SetBookWorkHTTP(u))
 		})
 
 		// GET /book/whats-up
 		// r.With(BookCtx).Get("/{bookTitle:[a-z-]+}", GetBookHTTP(u))
 	})
+	s.Router.Chi.Route("/copy", func(r chi.Router) {
+		r.Get("/{barcode}", GetCopyHTTP(u))
+		r.With(router.Authorize(auth.Staff)).Put("/{barcode}", UpdateCopyHTTP(u))
+	})
+	s.Router.Chi.Route("/subject", func(r chi.Router) {
+		r.With(router.Paginate).Get("/", ListSubjectsHTTP(u))
+		r.With(router.Authorize(auth.Staff)).Post("/", CreateSubjectHTTP(u)) // POST /subject
+
+		r.Route("/{subjectID}", func(r chi.Router) {
+			r.Get("/", GetSubjectHTTP(u)) // GET /subject/123
+			r.With(router.Authorize(auth.Staff)).Put("/", UpdateSubjectHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Delete("/", DeleteSubjectHTTP(u))
+			r.With(router.Paginate).Get("/books", ListSubjectBooksHTTP(u)) // GET /subject/123/books
+		})
+	})
+	s.Router.Chi.Route("/work", func(r chi.Router) {
+		r.With(router.Paginate).Get("/", ListWorksHTTP(u))
+		r.With(router.Authorize(auth.Staff)).Post("/", CreateWorkHTTP(u)) // POST /work
+
+		r.Route("/{workID}", func(r chi.Router) {
+			r.Get("/", GetWorkHTTP(u)) // GET /work/123
+			r.With(router.Authorize(auth.Staff)).Put("/", UpdateWorkHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Delete("/", DeleteWorkHTTP(u))
+			r.Get("/editions", ListWorkEditionsHTTP(u)) // GET /work/123/editions
+		})
+	})
+	s.Router.Chi.Route("/series", func(r chi.Router) {
+		r.With(router.Paginate).Get("/", ListSeriesHTTP(u))
+		r.With(router.Authorize(auth.Staff)).Post("/", CreateSeriesHTTP(u)) // POST /series
+
+		r.Route("/{seriesID}", func(r chi.Router) {
+			r.Get("/", GetSeriesHTTP(u)) // GET /series/123
+			r.With(router.Authorize(auth.Staff)).Put("/", UpdateSeriesHTTP(u))
+			r.With(router.Authorize(auth.Staff)).Delete("/", DeleteSeriesHTTP(u))
+			r.Get("/works", ListSeriesWorksHTTP(u)) // GET /series/123/works
+		})
+	})
 }
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	"github.com/sgraham785/gocleanarch-example/internal/book/adapter"
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 )
@@ -23,6 +25,7 @@ func TestListBooksHTTP(t *testing.T) {
 	defer controller.Finish()
 	u := mock.NewMockBookUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -31,8 +34,8 @@ func TestListBooksHTTP(t *testing.T) {
 		ID: entity.NewID(),
 	}
 	u.EXPECT().
-		ListBooks().
-		Return([]*entity.Book{b}, nil)
+		ListBooks(entity.Criteria{}, repository.ListOptions{Limit: router.DefaultLimit, Direction: repository.Asc}).
+		Return([]*entity.Book{b}, &repository.Page{Total: 1}, nil)
 
 	adapter.HTTPRoutes(s, u)
 	h := adapter.ListBooksHTTP(u)
@@ -55,14 +58,130 @@ func TestListBooksHTTP_NotFound(t *testing.T) {
 	defer ts.Close()
 
 	u.EXPECT().
-		SearchBooks("book of books").
-		Return(nil, entity.ErrBookNotFound)
+		SearchBooks("book of books", entity.Criteria{}, repository.ListOptions{Limit: router.DefaultLimit, Direction: repository.Asc}).
+		Return(nil, &repository.Page{}, entity.ErrBookNotFound)
 
 	res, err := http.Get(ts.URL + "?title=book+of+books")
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusNotFound, res.StatusCode)
 }
 
+func TestListBooksHTTP_Pages(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	b := &entity.Book{
+		ID: entity.NewID(),
+	}
+
+	t.Run("cursor", func(t *testing.T) {
+		u.EXPECT().
+			ListBooks(entity.Criteria{}, repository.ListOptions{Limit: 1, Sort: "title", Direction: repository.Desc}).
+			Return([]*entity.Book{b}, &repository.Page{Total: 3, Next: "abc"}, nil)
+		req := httptest.NewRequest("GET", "/book?limit=1&sort=title&order=desc", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "3", rr.Header().Get("X-Total-Count"))
+		assert.Equal(t, `</book?limit=1&order=desc&sort=title>; rel="first", </book?cursor=abc&limit=1&order=desc&sort=title>; rel="next"`, rr.Header().Get("Link"))
+	})
+
+	t.Run("offset", func(t *testing.T) {
+		u.EXPECT().
+			SearchBooks("ozzy", entity.Criteria{}, repository.ListOptions{Limit: 2, Offset: 2, Direction: repository.Asc}).
+			Return([]*entity.BookHit{{Book: *b, Rank: 1, Snippet: "I Am <b>Ozzy</b>"}}, &repository.Page{Total: 5}, nil)
+		req := httptest.NewRequest("GET", "/book?title=ozzy&limit=2&offset=2", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d []*adapter.BookHitHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, "I Am <b>Ozzy</b>", d[0].Snippet)
+		assert.Equal(t, b.ID, d[0].ID)
+		assert.Equal(t, `</book?limit=2&title=ozzy>; rel="first", </book?limit=2&offset=0&title=ozzy>; rel="prev", </book?limit=2&offset=4&title=ozzy>; rel="next", </book?limit=2&offset=4&title=ozzy>; rel="last"`, rr.Header().Get("Link"))
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/book?limit=100000", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		u.EXPECT().
+			ListBooks(entity.Criteria{}, repository.ListOptions{Limit: router.DefaultLimit, Sort: "isbn", Direction: repository.Asc}).
+			Return(nil, nil, repository.ErrInvalidSort)
+		req := httptest.NewRequest("GET", "/book?sort=isbn", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestListBooksHTTP_Criteria(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	opts := repository.ListOptions{Limit: router.DefaultLimit, Direction: repository.Asc}
+
+	t.Run("filters", func(t *testing.T) {
+		available := true
+		after, _ := time.Parse("2006-01-02", "2000-01-01")
+		published, _ := time.Parse("2006-01-02", "2010-01-25")
+		b := &entity.Book{ID: entity.NewID(), Title: "I Am Ozzy", Language: "en", PublishedAt: published, Tags: []string{"memoir"}}
+		u.EXPECT().
+			ListBooks(entity.Criteria{
+				Author:         "ozzy",
+				PublishedAfter: after,
+				Available:      &available,
+				Tags:           []string{"memoir", "metal"},
+				Language:       "en",
+			}, opts).
+			Return([]*entity.Book{b}, &repository.Page{Total: 1}, nil)
+		req := httptest.NewRequest("GET", "/book?author=ozzy&published_after=2000-01-01&available=true&tag=memoir&tag=metal&language=en", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d []*adapter.BookHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, "2010-01-25", d[0].PublishedAt)
+		assert.Equal(t, "en", d[0].Language)
+		assert.Equal(t, []string{"memoir"}, d[0].Tags)
+	})
+
+	t.Run("with a search", func(t *testing.T) {
+		u.EXPECT().
+			SearchBooks("madman", entity.Criteria{Language: "en"}, opts).
+			Return(nil, &repository.Page{}, entity.ErrBookNotFound)
+		req := httptest.NewRequest("GET", "/book?q=madman&language=en", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, q := range []string{"published_after=yesterday", "available=maybe"} {
+			req := httptest.NewRequest("GET", "/book?"+q, nil)
+			rr := httptest.NewRecorder()
+			r.Chi.ServeHTTP(rr, req)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		}
+	})
+}
+
 // func TestListBooks_Search(t *testing.T) {
 // 	controller := gomock.NewController(t)
 // 	defer controller.Finish()
@@ -85,13 +204,22 @@ func TestCreateBookHTTP(t *testing.T) {
 	defer controller.Finish()
 	u := mock.NewMockBookUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
 	s := &server.Server{
 		Router: r,
 	}
 
+	id := entity.NewID()
+	u.EXPECT().
+		CreateBook("I Am Ozzy", "Ozzy Osbourne", 294, 1, "0-306-40615-2").
+		Return(id, false, nil)
+	u.EXPECT().
+		CreateBook("I Am Ozzy", "Ozzy Osbourne", 294, 1, "0-306-40615-2").
+		Return(id, true, nil)
 	u.EXPECT().
-		CreateBook(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(entity.NewID(), nil)
+		GetBook(id.String()).
+		Return(&entity.Book{ID: id, ISBN: "9780306406157", Title: "I Am Ozzy", Author: "Ozzy Osbourne", Pages: 294, Quantity: 3, Version: 1}, nil).
+		Times(2)
 
 	adapter.HTTPRoutes(s, u)
 	h := adapter.CreateBookHTTP(u)
@@ -101,15 +229,62 @@ func TestCreateBookHTTP(t *testing.T) {
 		"title": "I Am Ozzy",
 		"author": "Ozzy Osbourne",
 		"pages": 294,
-		"quantity":1
+		"quantity":1,
+		"isbn": "0-306-40615-2"
 	}`)
 
 	resp, _ := http.Post(ts.URL+"/book", "application/json", strings.NewReader(payload))
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
-	var b *entity.Book
+	var b *adapter.BookHTTP
 	json.NewDecoder(resp.Body).Decode(&b)
 	assert.Equal(t, "Ozzy Osbourne", b.Author)
+	assert.Equal(t, "9780306406157", b.ISBN)
+	assert.Equal(t, "0306406152", b.ISBN10)
+	assert.Equal(t, 3, b.Quantity)
+
+	// the same ISBN again only adds copies to the book
+	resp, _ = http.Post(ts.URL+"/book", "application/json", strings.NewReader(payload))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGetBookByISBNHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	b := &entity.Book{ID: entity.NewID(), ISBN: "9780306406157", Version: 2}
+
+	t.Run("found", func(t *testing.T) {
+		u.EXPECT().GetBookByISBN("0-306-40615-2").Return(b, nil)
+		req := httptest.NewRequest("GET", "/book/isbn/0-306-40615-2", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+		var d *adapter.BookHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, b.ID, d.ID)
+	})
+	t.Run("not found", func(t *testing.T) {
+		u.EXPECT().GetBookByISBN("9791090636071").Return(nil, entity.ErrBookNotFound)
+		req := httptest.NewRequest("GET", "/book/isbn/9791090636071", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		u.EXPECT().GetBookByISBN("12345").Return(nil, entity.ErrInvalidISBN)
+		req := httptest.NewRequest("GET", "/book/isbn/12345", nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
 
 func TestGetBookHTTP(t *testing.T) {
@@ -117,6 +292,7 @@ func TestGetBookHTTP(t *testing.T) {
 	defer controller.Finish()
 	u := mock.NewMockBookUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -151,6 +327,7 @@ func TestDeleteBookHTTP(t *testing.T) {
 
 	u := mock.NewMockBookUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -172,4 +349,113 @@ func TestDeleteBookHTTP(t *testing.T) {
 	rr := httptest.NewRecorder()
 	r.Chi.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusOK, rr.Code)
+
+	for err, code := range map[error]int{
+		entity.ErrBookNotFound:        http.StatusNotFound,
+		entity.ErrBookCannotBeDeleted: http.StatusConflict,
+	} {
+		u.EXPECT().DeleteBook(b.ID.String()).Return(err)
+		rr = httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, code, rr.Code)
+	}
+}
+
+func TestEditBookHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	bID := entity.NewID()
+	title := "I Am Ozzy: A Memoir"
+
+	t.Run("get etag", func(t *testing.T) {
+		u.EXPECT().GetBook(bID.String()).Return(&entity.Book{ID: bID, Version: 3}, nil)
+		req := httptest.NewRequest("GET", "/book/"+bID.String(), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+	})
+
+	t.Run("if-match required", func(t *testing.T) {
+		req := httptest.NewRequest("PATCH", "/book/"+bID.String(), strings.NewReader(`{"title":"I Am Ozzy: A Memoir"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusPreconditionRequired, rr.Code)
+	})
+
+	t.Run("put takes every field", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/book/"+bID.String(), strings.NewReader(`{"title":"I Am Ozzy: A Memoir"}`))
+		req.Header.Set("If-Match", `"3"`)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		u.EXPECT().EditBook(bID.String(), 2, &entity.BookUpdate{Title: &title}).Return(nil, entity.ErrBookVersionConflict)
+		req := httptest.NewRequest("PATCH", "/book/"+bID.String(), strings.NewReader(`{"title":"I Am Ozzy: A Memoir"}`))
+		req.Header.Set("If-Match", `"2"`)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		u.EXPECT().EditBook(bID.String(), 3, &entity.BookUpdate{Title: &title}).Return(&entity.Book{ID: bID, Title: title, Version: 4}, nil)
+		req := httptest.NewRequest("PATCH", "/book/"+bID.String(), strings.NewReader(`{"title":"I Am Ozzy: A Memoir"}`))
+		req.Header.Set("If-Match", `"3"`)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"4"`, rr.Header().Get("ETag"))
+		var d *adapter.BookHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, title, d.Title)
+	})
+}
+
+func TestBookHTTP_Authorization(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{ID: "member", Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+
+	t.Run("create", func(t *testing.T) {
+		payload := `{"title": "Dark Tales", "author": "Ozzy Osbourne", "pages": 100, "quantity": 1}`
+		req := httptest.NewRequest("POST", "/book", strings.NewReader(payload))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		var d router.Denial
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, http.StatusForbidden, d.Status)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/book/"+entity.NewID().String(), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+// signedIn plays the authentication middleware, every request is made by p
+func signedIn(p router.Principal) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(router.WithPrincipal(r.Context(), p)))
+		})
+	}
 }
@@ -0,0 +1,134 @@
+package adapter
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+)
+
+// Catalog file formats
+const (
+	FormatCSV  = "csv"
+	FormatMARC = "marc"
+)
+
+// NewRecordReader reads a catalog file in format
+func NewRecordReader(format string, r io.Reader) (entity.RecordReader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(r)
+	case FormatMARC:
+		return NewMARCReader(r), nil
+	}
+	return nil, entity.ErrUnknownFormat
+}
+
+// ImportReportHTTP JSON data
+type ImportReportHTTP struct {
+	DryRun  bool               `json:"dry_run"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Skipped int                `json:"skipped"`
+	Invalid int                `json:"invalid"`
+	Rows    []ImportResultHTTP `json:"rows"`
+}
+
+// ImportResultHTTP JSON data of a record of an import
+type ImportResultHTTP struct {
+	Row    int                 `json:"row"`
+	Status entity.ImportStatus `json:"status"`
+	ID     string              `json:"id,omitempty"`
+	ISBN   string              `json:"isbn,omitempty"`
+	Title  string              `json:"title,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// NewImportReportHTTP gives the JSON data of a report
+func NewImportReportHTTP(r *entity.ImportReport) *ImportReportHTTP {
+	toJ := &ImportReportHTTP{
+		DryRun:  r.DryRun,
+		Created: r.Created,
+		Updated: r.Updated,
+		Skipped: r.Skipped,
+		Invalid: r.Invalid,
+		Rows:    []ImportResultHTTP{},
+	}
+	for _, res := range r.Rows {
+		row := ImportResultHTTP{
+			Row:    res.Row,
+			Status: res.Status,
+			ISBN:   res.ISBN,
+			Title:  res.Title,
+			Error:  res.Error,
+		}
+		if !res.BookID.IsNil() {
+			row.ID = res.BookID.String()
+		}
+		toJ.Rows = append(toJ.Rows, row)
+	}
+	return toJ
+}
+
+// importFormat is the format parameter, or the one the Content-Type names
+func importFormat(r *http.Request) string {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f
+	}
+	t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch t {
+	case "text/csv":
+		return FormatCSV
+	case "application/marc":
+		return FormatMARC
+	}
+	return ""
+}
+
+// ImportBooksHTTP handler, the body is a catalog file in the format the format
+// parameter (csv or marc) or the Content-Type (text/csv or application/marc) names.
+// dry_run=true validates the file and reports what an import would do.
+func ImportBooksHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error importing books"
+		dryRun := false
+		if d := r.URL.Query().Get("dry_run"); d != "" {
+			var err error
+			dryRun, err = strconv.ParseBool(d)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("Invalid dry_run"))
+				return
+			}
+		}
+		src, err := NewRecordReader(importFormat(r), r.Body)
+		switch err {
+		case nil:
+		case entity.ErrUnknownFormat, entity.ErrInvalidCatalog:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		report, err := u.ImportBooks(src, dryRun)
+		if err != nil {
+			log.Println(err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(NewImportReportHTTP(report)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+// marc encodes an ISO 2709 record of fields given as tag and data,
+// subfields written $a...
+func marc(fields ...[2]string) string {
+	var dir, data strings.Builder
+	for _, f := range fields {
+		d := strings.ReplaceAll(f[1], "$", "\x1f") + "\x1e"
+		fmt.Fprintf(&dir, "%s%04d%05d", f[0], len(d), data.Len())
+		data.WriteString(d)
+	}
+	base := 24 + dir.Len() + 1
+	length := base + data.Len() + 1
+	return fmt.Sprintf("%05dnam a22%05d   4500", length, base) + dir.String() + "\x1e" + data.String() + "\x1d"
+}
+
+func readAll(t *testing.T, src entity.RecordReader) []*entity.ImportRecord {
+	var recs []*entity.ImportRecord
+	for {
+		rec, err := src.Read()
+		if err == io.EOF {
+			return recs
+		}
+		assert.Nil(t, err)
+		recs = append(recs, rec)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	src, err := adapter.NewCSVReader(strings.NewReader(
+		"Title,Author,Pages,ISBN,Tags,Published_At,Shelf\n" +
+			"I Am Ozzy,Ozzy Osbourne,294,978-0-446-56989-7,music; biography,2010-01-25,B12\n" +
+			"Signals,Rush,forty,,,,\n" +
+			"\"Moving Pictures,Rush,40\n"))
+	assert.Nil(t, err)
+	recs := readAll(t, src)
+	assert.Len(t, recs, 3)
+	assert.Equal(t, &entity.Book{
+		ISBN:        "978-0-446-56989-7",
+		Title:       "I Am Ozzy",
+		Author:      "Ozzy Osbourne",
+		Pages:       294,
+		Quantity:    1,
+		PublishedAt: time.Date(2010, 1, 25, 0, 0, 0, 0, time.UTC),
+		Tags:        []string{"music", " biography"},
+	}, recs[0].Book)
+	assert.Equal(t, `Invalid pages "forty"`, recs[1].Err.Error())
+	assert.Equal(t, 3, recs[2].Row)
+	assert.NotNil(t, recs[2].Err)
+
+	_, err = adapter.NewCSVReader(strings.NewReader("isbn,title\n"))
+	assert.Equal(t, entity.ErrInvalidCatalog, err)
+}
+
+func TestMARCReader(t *testing.T) {
+	file := marc(
+		[2]string{"001", "ocm12345"},
+		[2]string{"008", "100125s2010    nyu           000 0 eng d"},
+		[2]string{"020", "  $a0446569895 (hbk.)"},
+		[2]string{"100", "1 $aOsbourne, Ozzy,$d1948-"},
+		[2]string{"245", "10$aI am Ozzy /$cOzzy Osbourne."},
+		[2]string{"300", "  $axii, 294 p. :$bill. ;$c24 cm."},
+		[2]string{"650", " 0$aRock musicians.$vBiography."},
+		[2]string{"650", " 0$aHeavy metal."},
+	) + "\n" + marc([2]string{"245", "00$aNo author"})[:30] + "\x1d"
+	recs := readAll(t, adapter.NewMARCReader(strings.NewReader(file)))
+	assert.Len(t, recs, 2)
+	assert.Nil(t, recs[0].Err)
+	assert.Equal(t, &entity.Book{
+		ISBN:        "0446569895",
+		Title:       "I am Ozzy",
+		Author:      "Osbourne, Ozzy",
+		Pages:       294,
+		Quantity:    1,
+		Language:    "eng",
+		PublishedAt: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC),
+		Tags:        []string{"Rock musicians", "Heavy metal"},
+	}, recs[0].Book)
+	assert.Equal(t, 2, recs[1].Row)
+	assert.NotNil(t, recs[1].Err)
+}
+
+func TestMARCReader_SignedDirectory(t *testing.T) {
+	bad := marc([2]string{"245", "00$aNegative"})
+	// the length of the first directory entry
+	bad = bad[:27] + "-001" + bad[31:]
+	file := bad + marc([2]string{"245", "00$aSignals"})
+	recs := readAll(t, adapter.NewMARCReader(strings.NewReader(file)))
+	assert.Len(t, recs, 2)
+	assert.NotNil(t, recs[0].Err)
+	assert.Nil(t, recs[1].Err)
+	assert.Equal(t, "Signals", recs[1].Book.Title)
+}
+
+func TestImportBooksHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	id := entity.NewID()
+	u.EXPECT().
+		ImportBooks(gomock.Any(), true).
+		DoAndReturn(func(src entity.RecordReader, dryRun bool) (*entity.ImportReport, error) {
+			report := &entity.ImportReport{DryRun: dryRun}
+			rec, err := src.Read()
+			assert.Nil(t, err)
+			assert.Equal(t, "Signals", rec.Book.Title)
+			report.Add(entity.ImportResult{Row: rec.Row, Status: entity.ImportCreated, BookID: id, Title: rec.Book.Title})
+			return report, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPost, "/book/import?dry_run=true", strings.NewReader("title,author,pages\nSignals,Rush,40\n"))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	var report adapter.ImportReportHTTP
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&report))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, []adapter.ImportResultHTTP{{Row: 1, Status: entity.ImportCreated, ID: id.String(), Title: "Signals"}}, report.Rows)
+
+	req = httptest.NewRequest(http.MethodPost, "/book/import", strings.NewReader("title,author,pages\n"))
+	res = httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Equal(t, entity.ErrUnknownFormat.Error(), res.Body.String())
+}
+
+func TestImportBooksHTTP_Forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+
+	req := httptest.NewRequest(http.MethodPost, "/book/import?format=marc", strings.NewReader(""))
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusForbidden, res.Code)
+}
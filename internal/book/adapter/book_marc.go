@@ -0,0 +1,322 @@
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+// ISO 2709 delimiters
+const (
+	marcSubfieldDelimiter = 0x1f
+	marcFieldTerminator   = 0x1e
+	marcRecordTerminator  = 0x1d
+	marcLeaderLength      = 24
+	marcEntryLength       = 12
+)
+
+var errInvalidMARC = errors.New("Invalid MARC record")
+
+// marcField is a control field (tag 00X) with its data, or a data field with its
+// subfields. The data of a data field is written before the subfields, its indicators.
+type marcField struct {
+	tag       string
+	data      string
+	subfields []marcSubfield
+}
+
+type marcSubfield struct {
+	code  byte
+	value string
+}
+
+type marcRecord []marcField
+
+// values of the subfields code of the fields tag, in the order of the record
+func (m marcRecord) values(tag string, code byte) []string {
+	var v []string
+	for _, f := range m {
+		if f.tag != tag {
+			continue
+		}
+		for _, s := range f.subfields {
+			if s.code == code {
+				v = append(v, s.value)
+			}
+		}
+	}
+	return v
+}
+
+func (m marcRecord) value(tag string, code byte) string {
+	if v := m.values(tag, code); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (m marcRecord) control(tag string) string {
+	for _, f := range m {
+		if f.tag == tag {
+			return f.data
+		}
+	}
+	return ""
+}
+
+type marcReader struct {
+	r   *bufio.Reader
+	row int
+}
+
+// NewMARCReader reads a catalog of binary MARC 21 records (ISO 2709), read as UTF-8.
+// The book is taken from the ISBN (020), the title (245), the main entry (100, 110
+// or the first 700), the extent (300), the language and date of 008 (or 041 and
+// 264 or 260) and the topical subjects (650) as tags. Every record is one copy.
+func NewMARCReader(r io.Reader) entity.RecordReader {
+	return &marcReader{r: bufio.NewReader(r)}
+}
+
+// Read the next record, a broken one is reported and the reader moves on to the next
+func (m *marcReader) Read() (*entity.ImportRecord, error) {
+	raw, err := m.r.ReadBytes(marcRecordTerminator)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	// line breaks are often put between records
+	raw = bytes.TrimLeft(raw, "\r\n")
+	if err == io.EOF && len(bytes.TrimSpace(raw)) == 0 {
+		return nil, io.EOF
+	}
+	m.row++
+	rec := &entity.ImportRecord{Row: m.row}
+	fields, err := parseMARC(raw)
+	if err != nil {
+		rec.Err = err
+		return rec, nil
+	}
+	rec.Book = marcBook(fields)
+	return rec, nil
+}
+
+// parseMARC splits a record on its directory, the lengths and offsets have to add up
+func parseMARC(raw []byte) (marcRecord, error) {
+	if len(raw) < marcLeaderLength+1 || raw[len(raw)-1] != marcRecordTerminator {
+		return nil, errInvalidMARC
+	}
+	length, ok := marcNumber(raw[0:5])
+	if !ok || length != len(raw) {
+		return nil, errInvalidMARC
+	}
+	base, ok := marcNumber(raw[12:17])
+	if !ok || base <= marcLeaderLength || base > len(raw) || raw[base-1] != marcFieldTerminator {
+		return nil, errInvalidMARC
+	}
+	dir := raw[marcLeaderLength : base-1]
+	if len(dir)%marcEntryLength != 0 {
+		return nil, errInvalidMARC
+	}
+	var m marcRecord
+	for i := 0; i < len(dir); i += marcEntryLength {
+		e := dir[i : i+marcEntryLength]
+		l, ok1 := marcNumber(e[3:7])
+		start, ok2 := marcNumber(e[7:12])
+		if !ok1 || !ok2 || base+start+l > len(raw)-1 {
+			return nil, errInvalidMARC
+		}
+		data := bytes.TrimSuffix(raw[base+start:base+start+l], []byte{marcFieldTerminator})
+		f := marcField{tag: string(e[0:3])}
+		if strings.HasPrefix(f.tag, "00") {
+			f.data = string(data)
+			m = append(m, f)
+			continue
+		}
+		// two indicators come before the subfields
+		parts := bytes.Split(data, []byte{marcSubfieldDelimiter})
+		for _, p := range parts[1:] {
+			if len(p) == 0 {
+				continue
+			}
+			f.subfields = append(f.subfields, marcSubfield{code: p[0], value: strings.TrimSpace(string(p[1:]))})
+		}
+		m = append(m, f)
+	}
+	return m, nil
+}
+
+// marcNumber reads a fixed width number of the leader or the directory, digits only
+func marcNumber(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+type marcWriter struct {
+	w io.Writer
+}
+
+// NewMARCWriter writes a catalog of binary MARC 21 records (ISO 2709) in UTF-8, the book id
+// is the control number (001). The fields are those NewMARCReader reads, languages
+// that are not three letters go in 041 only.
+func NewMARCWriter(w io.Writer) BookWriter {
+	return &marcWriter{w: w}
+}
+
+// Write the record of a book
+func (m *marcWriter) Write(b *entity.Book) error {
+	year, date := "    ", "n"
+	if !b.PublishedAt.IsZero() {
+		year, date = b.PublishedAt.Format("2006"), "s"
+	}
+	language := "|||"
+	if len(b.Language) == 3 {
+		language = b.Language
+	}
+	f008 := b.CreatedAt.Format("060102") + date + year + "    " + "xx " + strings.Repeat(" ", 17) + language + " d"
+	fields := []marcField{
+		{tag: "001", data: b.ID.String()},
+		{tag: "008", data: f008},
+	}
+	data := func(tag string, ind string, code byte, value string) {
+		if value != "" {
+			fields = append(fields, marcField{tag: tag, data: ind, subfields: []marcSubfield{{code: code, value: value}}})
+		}
+	}
+	data("020", "  ", 'a', b.ISBN)
+	data("041", "0 ", 'a', b.Language)
+	data("100", "1 ", 'a', b.Author)
+	data("245", "10", 'a', b.Title)
+	data("300", "  ", 'a', fmt.Sprintf("%d p.", b.Pages))
+	for _, t := range b.Tags {
+		data("650", " 4", 'a', t)
+	}
+	_, err := m.w.Write(encodeMARC(fields))
+	return err
+}
+
+// Close has nothing to flush, records are written whole
+func (m *marcWriter) Close() error {
+	return nil
+}
+
+// encodeMARC lays out a record: leader, directory and fields. The data of
+// a data field holds its indicators.
+func encodeMARC(fields []marcField) []byte {
+	var dir, data bytes.Buffer
+	for _, f := range fields {
+		start := data.Len()
+		data.WriteString(f.data)
+		for _, s := range f.subfields {
+			data.WriteByte(marcSubfieldDelimiter)
+			data.WriteByte(s.code)
+			data.WriteString(s.value)
+		}
+		data.WriteByte(marcFieldTerminator)
+		fmt.Fprintf(&dir, "%s%04d%05d", f.tag, data.Len()-start, start)
+	}
+	base := marcLeaderLength + dir.Len() + 1
+	var rec bytes.Buffer
+	fmt.Fprintf(&rec, "%05dnam a22%05d   4500", base+data.Len()+1, base)
+	rec.Write(dir.Bytes())
+	rec.WriteByte(marcFieldTerminator)
+	rec.Write(data.Bytes())
+	rec.WriteByte(marcRecordTerminator)
+	return rec.Bytes()
+}
+
+// marcBook maps a record to a book, what cannot be read is left for Validate to refuse
+func marcBook(m marcRecord) *entity.Book {
+	b := &entity.Book{Quantity: 1}
+	for _, v := range m.values("020", 'a') {
+		// qualifiers follow the ISBN: 0306406152 (pbk.)
+		fields := strings.Fields(v)
+		if len(fields) == 0 {
+			continue
+		}
+		if b.ISBN == "" {
+			b.ISBN = fields[0]
+		}
+		if _, err := entity.NormalizeISBN(fields[0]); err == nil {
+			b.ISBN = fields[0]
+			break
+		}
+	}
+	b.Title = trimISBD(m.value("245", 'a'))
+	if sub := trimISBD(m.value("245", 'b')); sub != "" {
+		b.Title += ": " + sub
+	}
+	for _, tag := range []string{"100", "110", "700"} {
+		if a := trimISBD(m.value(tag, 'a')); a != "" {
+			b.Author = a
+			break
+		}
+	}
+	b.Pages = largestNumber(m.value("300", 'a'))
+
+	f008 := m.control("008")
+	if len(f008) >= 38 {
+		b.Language = strings.TrimSpace(f008[35:38])
+	}
+	if b.Language == "" || strings.Contains(b.Language, "|") {
+		b.Language = m.value("041", 'a')
+	}
+	b.Language = strings.ToLower(b.Language)
+	year := ""
+	if len(f008) >= 11 && isYear(f008[7:11]) {
+		year = f008[7:11]
+	}
+	for _, tag := range []string{"264", "260"} {
+		if year == "" {
+			year = findYear(m.value(tag, 'c'))
+		}
+	}
+	if year != "" {
+		b.PublishedAt, _ = time.Parse("2006", year)
+	}
+	for _, s := range m.values("650", 'a') {
+		b.Tags = append(b.Tags, trimISBD(s))
+	}
+	return b
+}
+
+// trimISBD drops the punctuation cataloguers end subfields with: Title / or Osbourne, Ozzy,
+func trimISBD(s string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(s), " /:;,.="))
+}
+
+// largestNumber of a text, the pages of an extent like "xii, 294 p."
+func largestNumber(s string) int {
+	n := 0
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r < '0' || r > '9' }) {
+		if v, err := strconv.Atoi(f); err == nil && v > n {
+			n = v
+		}
+	}
+	return n
+}
+
+func isYear(s string) bool {
+	return len(s) == 4 && s[0] != '0' && strings.Trim(s, "0123456789") == ""
+}
+
+// findYear finds the first year of a date like "c2011." or "[1998?]"
+func findYear(s string) string {
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r < '0' || r > '9' }) {
+		if isYear(f) {
+			return f
+		}
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+)
+
+// CopyHTTP JSON data
+type CopyHTTP struct {
+	Barcode   string               `json:"barcode"`
+	BookID    entity.ID            `json:"book_id"`
+	Condition entity.CopyCondition `json:"condition"`
+	Location  string               `json:"location"`
+	Status    entity.CopyStatus    `json:"status"`
+}
+
+func newCopyHTTP(c *entity.Copy) *CopyHTTP {
+	return &CopyHTTP{
+		Barcode:   c.Barcode,
+		BookID:    c.BookID,
+		Condition: c.Condition,
+		Location:  c.Location,
+		Status:    c.Status,
+	}
+}
+
+// ListCopiesHTTP handler
+func ListCopiesHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading copies"
+		data, err := u.ListCopies(chi.URLParam(r, "bookID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := []*CopyHTTP{}
+		for _, d := range data {
+			toJ = append(toJ, newCopyHTTP(d))
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// AddCopyHTTP handler
+func AddCopyHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding copy"
+		var input struct {
+			Barcode   string               `json:"barcode"`
+			Condition entity.CopyCondition `json:"condition"`
+			Location  string               `json:"location"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		c, err := u.AddCopy(chi.URLParam(r, "bookID"), input.Barcode, input.Condition, input.Location)
+		switch err {
+		case nil:
+		case entity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidCopyEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrBarcodeTaken:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newCopyHTTP(c)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// GetCopyHTTP handler
+func GetCopyHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading copy"
+		c, err := u.GetCopy(chi.URLParam(r, "barcode"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrCopyNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newCopyHTTP(c)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// UpdateCopyHTTP handler
+func UpdateCopyHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating copy"
+		var input struct {
+			Condition entity.CopyCondition `json:"condition"`
+			Location  string               `json:"location"`
+			Status    entity.CopyStatus    `json:"status"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		c, err := u.UpdateCopy(chi.URLParam(r, "barcode"), input.Condition, input.Location, input.Status)
+		switch err {
+		case nil:
+		case entity.ErrCopyNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidCopyEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrCopyOnLoan, entity.ErrCopyNotAvailable:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(newCopyHTTP(c)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+func TestCopyHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	bID := entity.NewID()
+	c, _ := entity.NewCopy(bID, "LIB-0001", entity.ConditionGood, "Music A-12")
+
+	t.Run("list", func(t *testing.T) {
+		u.EXPECT().ListCopies(bID.String()).Return([]*entity.Copy{c}, nil)
+		res, err := http.Get(fmt.Sprintf("%s/book/%s/copies", ts.URL, bID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d []*adapter.CopyHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, 1, len(d))
+		assert.Equal(t, "LIB-0001", d[0].Barcode)
+	})
+
+	t.Run("add barcode taken", func(t *testing.T) {
+		u.EXPECT().AddCopy(bID.String(), "LIB-0001", entity.ConditionGood, "").Return(nil, entity.ErrBarcodeTaken)
+		payload := `{"barcode": "LIB-0001", "condition": "good"}`
+		res, err := http.Post(fmt.Sprintf("%s/book/%s/copies", ts.URL, bID.String()), "application/json", strings.NewReader(payload))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		u.EXPECT().GetCopy("LIB-9999").Return(nil, entity.ErrCopyNotFound)
+		res, err := http.Get(fmt.Sprintf("%s/copy/LIB-9999", ts.URL))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("update on loan", func(t *testing.T) {
+		u.EXPECT().UpdateCopy("LIB-0001", entity.CopyCondition(""), "", entity.CopyLost).Return(nil, entity.ErrCopyOnLoan)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/copy/LIB-0001", ts.URL), strings.NewReader(`{"status": "lost"}`))
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+}
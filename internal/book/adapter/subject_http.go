@@ -0,0 +1,262 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+)
+
+// SubjectHTTP JSON data, a subject at the top has no parent_id
+type SubjectHTTP struct {
+	ID     entity.ID  `json:"id"`
+	Name   string     `json:"name"`
+	Parent *entity.ID `json:"parent_id,omitempty"`
+}
+
+func newSubjectHTTP(s *entity.Subject) *SubjectHTTP {
+	toJ := &SubjectHTTP{
+		ID:   s.ID,
+		Name: s.Name,
+	}
+	if !s.Parent.IsNil() {
+		parent := s.Parent
+		toJ.Parent = &parent
+	}
+	return toJ
+}
+
+// subjectInput is what a subject is created or updated from
+type subjectInput struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent_id"`
+}
+
+func writeSubjects(w http.ResponseWriter, data []*entity.Subject, errorMessage string) {
+	toJ := []*SubjectHTTP{}
+	for _, d := range data {
+		toJ = append(toJ, newSubjectHTTP(d))
+	}
+	if err := json.NewEncoder(w).Encode(toJ); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+// ListSubjectsHTTP handler, one page at a time. The tree is drawn from the parent_id of every subject
+func ListSubjectsHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing subjects"
+		opts := router.ListOptionsFromContext(r.Context())
+		data, page, err := u.ListSubjects(opts)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		router.SetPage(w, r, opts, page)
+		if len(data) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeSubjects(w, data, errorMessage)
+	})
+}
+
+// CreateSubjectHTTP handler, under parent_id when given
+func CreateSubjectHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding subject"
+		var input subjectInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		id, err := u.CreateSubject(input.Name, input.Parent)
+		switch err {
+		case nil:
+		case entity.ErrInvalidSubjectEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.GetSubject(id.String())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newSubjectHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// GetSubjectHTTP handler
+func GetSubjectHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading subject"
+		data, err := u.GetSubject(chi.URLParam(r, "subjectID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrSubjectNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newSubjectHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// UpdateSubjectHTTP handler, PUT takes the name and the parent_id, none moves it to the top
+func UpdateSubjectHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating subject"
+		var input subjectInput
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.UpdateSubject(chi.URLParam(r, "subjectID"), input.Name, input.Parent)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrSubjectNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidSubjectEntity, entity.ErrSubjectCycle:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newSubjectHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// DeleteSubjectHTTP handler, not while other subjects are under it
+func DeleteSubjectHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing subject"
+		err := u.DeleteSubject(chi.URLParam(r, "subjectID"))
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusNoContent)
+		case entity.ErrSubjectNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+		case entity.ErrSubjectCannotBeDeleted:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListSubjectBooksHTTP handler, the books of a subject and of the subjects below it one page at a time
+func ListSubjectBooksHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading books"
+		opts := router.ListOptionsFromContext(r.Context())
+		data, page, err := u.ListSubjectBooks(chi.URLParam(r, "subjectID"), opts)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrSubjectNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		router.SetPage(w, r, opts, page)
+		toJ := []*BookHTTP{}
+		for _, d := range data {
+			toJ = append(toJ, NewBookHTTP(d))
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// SetBookSubjectsHTTP handler, takes the ids of the subjects to file a book under
+func SetBookSubjectsHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error filing book"
+		var input struct {
+			Subjects []string `json:"subjects"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.SetBookSubjects(chi.URLParam(r, "bookID"), input.Subjects)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrSubjectNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeSubjects(w, data, errorMessage)
+	})
+}
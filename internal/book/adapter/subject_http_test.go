@@ -0,0 +1,102 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+func TestSubjectHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	fiction, _ := entity.NewSubject("Fiction", entity.ID{})
+	sf, _ := entity.NewSubject("Science fiction", fiction.ID)
+	b, _ := entity.New("Dune", "Frank Herbert", 412, 1)
+	b.Tags = []string{"classic"}
+	b.Subjects = []*entity.Subject{sf}
+
+	t.Run("create", func(t *testing.T) {
+		u.EXPECT().CreateSubject("Science fiction", fiction.ID.String()).Return(sf.ID, nil)
+		u.EXPECT().GetSubject(sf.ID.String()).Return(sf, nil)
+		payload := fmt.Sprintf(`{"name": "Science fiction", "parent_id": "%s"}`, fiction.ID.String())
+		res, err := http.Post(ts.URL+"/subject", "application/json", strings.NewReader(payload))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d adapter.SubjectHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, sf.ID, d.ID)
+		assert.Equal(t, fiction.ID, *d.Parent)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		u.EXPECT().ListSubjects(gomock.Any()).Return([]*entity.Subject{fiction, sf}, &repository.Page{Total: 2}, nil)
+		res, err := http.Get(ts.URL + "/subject")
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d []map[string]interface{}
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, 2, len(d))
+		assert.NotContains(t, d[0], "parent_id")
+		assert.Equal(t, fiction.ID.String(), d[1]["parent_id"])
+	})
+
+	t.Run("move under itself", func(t *testing.T) {
+		u.EXPECT().UpdateSubject(fiction.ID.String(), "Fiction", sf.ID.String()).Return(nil, entity.ErrSubjectCycle)
+		payload := fmt.Sprintf(`{"name": "Fiction", "parent_id": "%s"}`, sf.ID.String())
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/subject/%s", ts.URL, fiction.ID.String()), strings.NewReader(payload))
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("delete with subjects under it", func(t *testing.T) {
+		u.EXPECT().DeleteSubject(fiction.ID.String()).Return(entity.ErrSubjectCannotBeDeleted)
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/subject/%s", ts.URL, fiction.ID.String()), nil)
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("books", func(t *testing.T) {
+		u.EXPECT().ListSubjectBooks(fiction.ID.String(), gomock.Any()).Return([]*entity.Book{b}, &repository.Page{Total: 1}, nil)
+		res, err := http.Get(fmt.Sprintf("%s/subject/%s/books", ts.URL, fiction.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d []*adapter.BookHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, 1, len(d))
+		assert.Equal(t, []string{"classic"}, d[0].Tags)
+		assert.Equal(t, "Science fiction", d[0].Subjects[0].Name)
+	})
+
+	t.Run("file a book", func(t *testing.T) {
+		u.EXPECT().SetBookSubjects(b.ID.String(), []string{"unknown"}).Return(nil, entity.ErrSubjectNotFound)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/book/%s/subjects", ts.URL, b.ID.String()), strings.NewReader(`{"subjects": ["unknown"]}`))
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
@@ -0,0 +1,456 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+)
+
+// WorkHTTP JSON data, a work in no series has no series_id nor series_position
+type WorkHTTP struct {
+	ID       entity.ID  `json:"id"`
+	Title    string     `json:"title"`
+	SeriesID *entity.ID `json:"series_id,omitempty"`
+	Position int        `json:"series_position,omitempty"`
+}
+
+func newWorkHTTP(w *entity.Work) *WorkHTTP {
+	toJ := &WorkHTTP{
+		ID:    w.ID,
+		Title: w.Title,
+	}
+	if !w.SeriesID.IsNil() {
+		series := w.SeriesID
+		toJ.SeriesID, toJ.Position = &series, w.Position
+	}
+	return toJ
+}
+
+// SeriesHTTP JSON data
+type SeriesHTTP struct {
+	ID   entity.ID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func newSeriesHTTP(s *entity.Series) *SeriesHTTP {
+	return &SeriesHTTP{
+		ID:   s.ID,
+		Name: s.Name,
+	}
+}
+
+func writeWorks(w http.ResponseWriter, data []*entity.Work, errorMessage string) {
+	toJ := []*WorkHTTP{}
+	for _, d := range data {
+		toJ = append(toJ, newWorkHTTP(d))
+	}
+	if err := json.NewEncoder(w).Encode(toJ); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+func writeBooks(w http.ResponseWriter, data []*entity.Book, errorMessage string) {
+	toJ := []*BookHTTP{}
+	for _, d := range data {
+		toJ = append(toJ, NewBookHTTP(d))
+	}
+	if err := json.NewEncoder(w).Encode(toJ); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+// ListWorksHTTP handler, one page at a time
+func ListWorksHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing works"
+		opts := router.ListOptionsFromContext(r.Context())
+		data, page, err := u.ListWorks(opts)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		router.SetPage(w, r, opts, page)
+		if len(data) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeWorks(w, data, errorMessage)
+	})
+}
+
+// CreateWorkHTTP handler
+func CreateWorkHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding work"
+		var input struct {
+			Title string `json:"title"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		id, err := u.CreateWork(input.Title)
+		if err == entity.ErrInvalidWorkEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.GetWork(id.String())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newWorkHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// GetWorkHTTP handler
+func GetWorkHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading work"
+		data, err := u.GetWork(chi.URLParam(r, "workID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrWorkNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newWorkHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// UpdateWorkHTTP handler, PUT takes the title, the series_id and the series_position.
+// A work without series_id is taken out of its series
+func UpdateWorkHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating work"
+		var input struct {
+			Title    string `json:"title"`
+			SeriesID string `json:"series_id"`
+			Position int    `json:"series_position"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.UpdateWork(chi.URLParam(r, "workID"), input.Title, input.SeriesID, input.Position)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrWorkNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidWorkEntity, entity.ErrSeriesNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newWorkHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// DeleteWorkHTTP handler, not while books are editions of it
+func DeleteWorkHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing work"
+		err := u.DeleteWork(chi.URLParam(r, "workID"))
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusNoContent)
+		case entity.ErrWorkNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+		case entity.ErrWorkCannotBeDeleted:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListWorkEditionsHTTP handler, the oldest edition first
+func ListWorkEditionsHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading editions"
+		data, err := u.ListWorkEditions(chi.URLParam(r, "workID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrWorkNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeBooks(w, data, errorMessage)
+	})
+}
+
+// SetBookWorkHTTP handler, takes the work_id the book is an edition of, none ungroups it
+func SetBookWorkHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error grouping book"
+		var input struct {
+			WorkID string `json:"work_id"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.SetBookWork(chi.URLParam(r, "bookID"), input.WorkID)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrBookNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrWorkNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrBookVersionConflict:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("ETag", etag(data))
+		if err := json.NewEncoder(w).Encode(NewBookHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListSeriesHTTP handler, one page at a time
+func ListSeriesHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing series"
+		opts := router.ListOptionsFromContext(r.Context())
+		data, page, err := u.ListSeries(opts)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		router.SetPage(w, r, opts, page)
+		if len(data) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := []*SeriesHTTP{}
+		for _, d := range data {
+			toJ = append(toJ, newSeriesHTTP(d))
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// CreateSeriesHTTP handler
+func CreateSeriesHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error adding series"
+		var input struct {
+			Name string `json:"name"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		id, err := u.CreateSeries(input.Name)
+		if err == entity.ErrInvalidSeriesEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.GetSeries(id.String())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newSeriesHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// GetSeriesHTTP handler
+func GetSeriesHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading series"
+		data, err := u.GetSeries(chi.URLParam(r, "seriesID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrSeriesNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newSeriesHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// UpdateSeriesHTTP handler, PUT takes the name
+func UpdateSeriesHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating series"
+		var input struct {
+			Name string `json:"name"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.UpdateSeries(chi.URLParam(r, "seriesID"), input.Name)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrSeriesNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidSeriesEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newSeriesHTTP(data)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// DeleteSeriesHTTP handler, its works stay in the catalog
+func DeleteSeriesHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error removing series"
+		err := u.DeleteSeries(chi.URLParam(r, "seriesID"))
+		switch err {
+		case nil:
+			w.WriteHeader(http.StatusNoContent)
+		case entity.ErrSeriesNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListSeriesWorksHTTP handler, the works of a series in order
+func ListSeriesWorksHTTP(u usecase.BookUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading works"
+		data, err := u.ListSeriesWorks(chi.URLParam(r, "seriesID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrSeriesNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		writeWorks(w, data, errorMessage)
+	})
+}
@@ -0,0 +1,93 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/book/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+func TestWorkHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	u := mock.NewMockBookUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, u)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	chronicles, _ := entity.NewSeries("Dune Chronicles")
+	dune, _ := entity.NewWork("Dune")
+	_ = dune.SetSeries(chronicles.ID, 1)
+	messiah, _ := entity.NewWork("Dune Messiah")
+	_ = messiah.SetSeries(chronicles.ID, 2)
+	first, _ := entity.New("Dune", "Frank Herbert", 412, 1)
+	first.WorkID = dune.ID
+	anniversary, _ := entity.New("Dune (50th anniversary)", "Frank Herbert", 617, 2)
+	anniversary.WorkID = dune.ID
+
+	t.Run("book lists its sibling editions", func(t *testing.T) {
+		u.EXPECT().GetBook(first.ID.String()).Return(first, nil)
+		u.EXPECT().ListEditions(first.ID.String()).Return([]*entity.Book{anniversary}, nil)
+		res, err := http.Get(fmt.Sprintf("%s/book/%s", ts.URL, first.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d adapter.BookHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, dune.ID, *d.WorkID)
+		assert.Len(t, d.Editions, 1)
+		assert.Equal(t, anniversary.ID, d.Editions[0].ID)
+	})
+
+	t.Run("group a book", func(t *testing.T) {
+		u.EXPECT().SetBookWork(first.ID.String(), "unknown").Return(nil, entity.ErrWorkNotFound)
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/book/%s/work", ts.URL, first.ID.String()), strings.NewReader(`{"work_id": "unknown"}`))
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("place in a series", func(t *testing.T) {
+		u.EXPECT().UpdateWork(messiah.ID.String(), "Dune Messiah", chronicles.ID.String(), 0).Return(nil, entity.ErrInvalidWorkEntity)
+		payload := fmt.Sprintf(`{"title": "Dune Messiah", "series_id": "%s"}`, chronicles.ID.String())
+		req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/work/%s", ts.URL, messiah.ID.String()), strings.NewReader(payload))
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("series works in order", func(t *testing.T) {
+		u.EXPECT().ListSeriesWorks(chronicles.ID.String()).Return([]*entity.Work{dune, messiah}, nil)
+		res, err := http.Get(fmt.Sprintf("%s/series/%s/works", ts.URL, chronicles.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d []adapter.WorkHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Len(t, d, 2)
+		assert.Equal(t, chronicles.ID, *d[1].SeriesID)
+		assert.Equal(t, 2, d[1].Position)
+	})
+
+	t.Run("delete a work with editions", func(t *testing.T) {
+		u.EXPECT().DeleteWork(dune.ID.String()).Return(entity.ErrWorkCannotBeDeleted)
+		req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/work/%s", ts.URL, dune.ID.String()), nil)
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Criteria narrows a list of books, a book has to meet every criterion set.
+// Author matches part of the name in any case, PublishedAfter excludes the
+// books of unknown date and Tags are all required.
+type Criteria struct {
+	Author         string
+	PublishedAfter time.Time
+	Available      *bool
+	Tags           []string
+	Language       string
+}
+
+// Match tells if a book meets the criteria, available tells if a copy of it is on the shelf
+func (c Criteria) Match(b *Book, available bool) bool {
+	if c.Author != "" && !strings.Contains(strings.ToLower(b.Author), strings.ToLower(c.Author)) {
+		return false
+	}
+	if !c.PublishedAfter.IsZero() && !b.PublishedAt.After(c.PublishedAfter) {
+		return false
+	}
+	if c.Available != nil && *c.Available != available {
+		return false
+	}
+	for _, t := range NormalizeTags(c.Tags) {
+		if !b.HasTag(t) {
+			return false
+		}
+	}
+	if c.Language != "" && !strings.EqualFold(b.Language, c.Language) {
+		return false
+	}
+	return true
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/rs/xid"
@@ -19,15 +21,28 @@ func IDFromString(id string) (xid.ID, error) {
 	return i, err
 }
 
-// Book entity
+// Book entity, Quantity is the number of copies available to borrow.
+// Version goes up with every update so concurrent edits can be told apart.
+// Language is an ISO 639 code and PublishedAt is zero when unknown.
+// ISBN is kept as an ISBN-13 of digits only, "" when the book has none.
+// Tags are free words while Subjects are taken from the taxonomy.
+// Author names the authors the book is credited to, see AuthorNames.
+// WorkID is the work the book is an edition of, the nil ID when none.
 type Book struct {
-	ID        ID
-	Title     string
-	Author    string
-	Pages     int
-	Quantity  int
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          ID
+	ISBN        string
+	Title       string
+	Author      string
+	Pages       int
+	Quantity    int
+	Version     int
+	Language    string
+	PublishedAt time.Time
+	Tags        []string
+	Subjects    []*Subject
+	WorkID      ID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // New creates a new book
@@ -38,19 +53,101 @@ func New(title string, author string, pages int, quantity int) (*Book, error) {
 		Author:    author,
 		Pages:     pages,
 		Quantity:  quantity,
+		Version:   1,
 		CreatedAt: time.Now(),
 	}
 	err := b.Validate()
-	if err != nil {
+	if err != nil || quantity <= 0 {
 		return nil, ErrInvalidBookEntity
 	}
 	return b, nil
 }
 
-// Validate validate book
+// authorSeparator splits co-authors the way catalogs write them
+var authorSeparator = regexp.MustCompile(`\s*(;|&|\s+and\s+)\s*`)
+
+// AuthorNames splits the author of the book into the names it is credited to:
+// "Ozzy Osbourne & Chris Ayres", "Ozzy Osbourne and Chris Ayres" or "Ozzy Osbourne; Chris Ayres"
+func (b *Book) AuthorNames() []string {
+	var names []string
+	for _, n := range authorSeparator.Split(b.Author, -1) {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// JoinAuthors writes the names of the authors of a book as its Author
+func JoinAuthors(names []string) string {
+	return strings.Join(names, "; ")
+}
+
+// Validate validate book, all copies may be out
 func (b *Book) Validate() error {
-	if b.Title == "" || b.Author == "" || b.Pages <= 0 || b.Quantity <= 0 {
+	if b.Title == "" || b.Author == "" || b.Pages <= 0 || b.Quantity < 0 {
 		return ErrInvalidBookEntity
 	}
+	if b.Language != "" && !validLanguage(b.Language) {
+		return ErrInvalidBookEntity
+	}
+	if b.ISBN != "" {
+		isbn, err := NormalizeISBN(b.ISBN)
+		if err != nil || isbn != b.ISBN {
+			return ErrInvalidBookEntity
+		}
+	}
+	return nil
+}
+
+// SetISBN sets the ISBN-10 or ISBN-13 of the book, normalized to ISBN-13
+func (b *Book) SetISBN(isbn string) error {
+	if isbn == "" {
+		b.ISBN = ""
+		return nil
+	}
+	n, err := NormalizeISBN(isbn)
+	if err != nil {
+		return err
+	}
+	b.ISBN = n
 	return nil
 }
+
+// HasTag tells if the book is tagged with tag
+func (b *Book) HasTag(tag string) bool {
+	for _, t := range b.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTags lower cases tags and drops the blank and repeated ones
+func NormalizeTags(tags []string) []string {
+	var d []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		d = append(d, t)
+	}
+	return d
+}
+
+// validLanguage accepts the two and three letters ISO 639 codes, in lower case
+func validLanguage(l string) bool {
+	if len(l) < 2 || len(l) > 3 {
+		return false
+	}
+	for _, c := range l {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -67,3 +67,21 @@ func TestBook_Validate(t *testing.T) {
 	}
 
 }
+
+func TestBook_ValidateAllCopiesOut(t *testing.T) {
+	b, _ := entity.New("American Gods", "Neil Gaiman", 100, 1)
+	b.Quantity = 0
+	assert.Nil(t, b.Validate())
+	b.Quantity = -1
+	assert.Equal(t, entity.ErrInvalidBookEntity, b.Validate())
+}
+
+func TestBook_AuthorNames(t *testing.T) {
+	b := &entity.Book{Author: "Ozzy Osbourne & Chris Ayres"}
+	assert.Equal(t, []string{"Ozzy Osbourne", "Chris Ayres"}, b.AuthorNames())
+	b.Author = "Neil Gaiman and Terry Pratchett; "
+	assert.Equal(t, []string{"Neil Gaiman", "Terry Pratchett"}, b.AuthorNames())
+	b.Author = "Alexander Anderson"
+	assert.Equal(t, []string{"Alexander Anderson"}, b.AuthorNames())
+	assert.Equal(t, "Neil Gaiman; Terry Pratchett", entity.JoinAuthors([]string{"Neil Gaiman", "Terry Pratchett"}))
+}
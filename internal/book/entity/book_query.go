@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Term is a part of a search query: a word, a prefix when it ends with *,
+// or a phrase of consecutive words when it is quoted
+type Term struct {
+	Words  []string
+	Prefix bool
+}
+
+// IsPhrase tells if the term matches several consecutive words
+func (t Term) IsPhrase() bool {
+	return len(t.Words) > 1
+}
+
+// Query is a search query, a book matches it when it matches every term
+type Query []Term
+
+// ParseQuery reads a search query: words, "quoted phrases" and prefixes like ozz*
+func ParseQuery(s string) Query {
+	var q Query
+	for i, part := range strings.Split(s, `"`) {
+		if i%2 == 1 {
+			if words := Words(part); len(words) > 0 {
+				q = append(q, Term{Words: words})
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			words := Words(field)
+			if len(words) == 0 {
+				continue
+			}
+			prefix := strings.HasSuffix(field, "*")
+			for n, w := range words {
+				q = append(q, Term{Words: []string{w}, Prefix: prefix && n == len(words)-1})
+			}
+		}
+	}
+	return q
+}
+
+// Words splits a text in lower case words, anything but letters and digits separates them
+func Words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// BookHit is a book found by a search. Rank tells how well it matches the query
+// and Snippet shows its title and author, escaped for HTML, with the matched words between <b> and </b>
+type BookHit struct {
+	Book
+	Rank    float64
+	Snippet string
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// BookUpdate are the metadata fields a librarian corrects, nil fields are left as they are
+type BookUpdate struct {
+	ISBN        *string
+	Title       *string
+	Author      *string
+	Pages       *int
+	Language    *string
+	PublishedAt *time.Time
+	Tags        *[]string
+}
+
+// Apply copies the fields set to the book, an invalid ISBN is kept as given for Validate to refuse
+func (p *BookUpdate) Apply(b *Book) {
+	if p.ISBN != nil && b.SetISBN(*p.ISBN) != nil {
+		b.ISBN = *p.ISBN
+	}
+	if p.Title != nil {
+		b.Title = *p.Title
+	}
+	if p.Author != nil {
+		b.Author = *p.Author
+	}
+	if p.Pages != nil {
+		b.Pages = *p.Pages
+	}
+	if p.Language != nil {
+		b.Language = strings.ToLower(*p.Language)
+	}
+	if p.PublishedAt != nil {
+		b.PublishedAt = *p.PublishedAt
+	}
+	if p.Tags != nil {
+		b.Tags = NormalizeTags(*p.Tags)
+	}
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// CopyCondition is the physical state of a copy
+type CopyCondition string
+
+const (
+	// ConditionNew never lent
+	ConditionNew CopyCondition = "new"
+	// ConditionGood usual wear
+	ConditionGood CopyCondition = "good"
+	// ConditionPoor worn but still lendable
+	ConditionPoor CopyCondition = "poor"
+	// ConditionDamaged needs repair before it can be lent again
+	ConditionDamaged CopyCondition = "damaged"
+)
+
+// CopyStatus is where a copy is in circulation
+type CopyStatus string
+
+const (
+	// CopyAvailable on the shelf
+	CopyAvailable CopyStatus = "available"
+	// CopyOnLoan borrowed by an user
+	CopyOnLoan CopyStatus = "on_loan"
+	// CopyInRepair out of circulation until repaired
+	CopyInRepair CopyStatus = "in_repair"
+	// CopyLost the copy went missing
+	CopyLost CopyStatus = "lost"
+	// CopyWithdrawn removed from the collection
+	CopyWithdrawn CopyStatus = "withdrawn"
+)
+
+// Copy is a physical copy of a book, identified by the barcode on its spine
+type Copy struct {
+	Barcode   string
+	BookID    ID
+	Condition CopyCondition
+	Location  string
+	Status    CopyStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBarcode generates a barcode for copies that were not labelled yet
+func NewBarcode() string {
+	return strings.ToUpper(xid.New().String())
+}
+
+// NewCopy adds a copy of a book to the shelves
+func NewCopy(bookID ID, barcode string, condition CopyCondition, location string) (*Copy, error) {
+	if barcode == "" {
+		barcode = NewBarcode()
+	}
+	if condition == "" {
+		condition = ConditionNew
+	}
+	c := &Copy{
+		Barcode:   barcode,
+		BookID:    bookID,
+		Condition: condition,
+		Location:  location,
+		Status:    CopyAvailable,
+		CreatedAt: time.Now(),
+	}
+	if condition == ConditionDamaged {
+		c.Status = CopyInRepair
+	}
+	err := c.Validate()
+	if err != nil {
+		return nil, ErrInvalidCopyEntity
+	}
+	return c, nil
+}
+
+// Available tells if the copy can be lent
+func (c *Copy) Available() bool {
+	return c.Status == CopyAvailable
+}
+
+// CheckOut lends the copy
+func (c *Copy) CheckOut() error {
+	if !c.Available() {
+		return ErrCopyNotAvailable
+	}
+	c.Status = CopyOnLoan
+	return nil
+}
+
+// CheckIn puts a returned copy back on the shelf, or sends it to repair when
+// it came back damaged. An empty condition keeps the current one.
+func (c *Copy) CheckIn(condition CopyCondition) error {
+	if c.Status != CopyOnLoan {
+		return ErrCopyNotOnLoan
+	}
+	c.Status = CopyAvailable
+	if condition != "" {
+		return c.SetCondition(condition)
+	}
+	return nil
+}
+
+// SetCondition records the state of the copy, a damaged copy on the shelf goes to repair
+func (c *Copy) SetCondition(condition CopyCondition) error {
+	if !validCondition(condition) {
+		return ErrInvalidCopyEntity
+	}
+	c.Condition = condition
+	if condition == ConditionDamaged && c.Status == CopyAvailable {
+		c.Status = CopyInRepair
+	}
+	return nil
+}
+
+// SetStatus moves a copy in or out of circulation, loans go through CheckOut and CheckIn
+func (c *Copy) SetStatus(status CopyStatus) error {
+	if c.Status == CopyOnLoan || status == CopyOnLoan {
+		return ErrCopyOnLoan
+	}
+	if !validStatus(status) {
+		return ErrInvalidCopyEntity
+	}
+	if status == CopyAvailable && c.Condition == ConditionDamaged {
+		return ErrCopyNotAvailable
+	}
+	c.Status = status
+	return nil
+}
+
+// Validate validate copy
+func (c *Copy) Validate() error {
+	if c.Barcode == "" || c.BookID.IsNil() || !validCondition(c.Condition) || !validStatus(c.Status) {
+		return ErrInvalidCopyEntity
+	}
+	return nil
+}
+
+func validCondition(c CopyCondition) bool {
+	switch c {
+	case ConditionNew, ConditionGood, ConditionPoor, ConditionDamaged:
+		return true
+	}
+	return false
+}
+
+func validStatus(s CopyStatus) bool {
+	switch s {
+	case CopyAvailable, CopyOnLoan, CopyInRepair, CopyLost, CopyWithdrawn:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCopy(t *testing.T) {
+	bID := entity.NewID()
+	c, err := entity.NewCopy(bID, "", "", "Music A-12")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, c.Barcode)
+	assert.Equal(t, entity.ConditionNew, c.Condition)
+	assert.Equal(t, entity.CopyAvailable, c.Status)
+	assert.True(t, c.Available())
+
+	c, err = entity.NewCopy(bID, "LIB-0001", entity.ConditionDamaged, "")
+	assert.Nil(t, err)
+	assert.Equal(t, entity.CopyInRepair, c.Status)
+}
+
+func TestCopy_Circulation(t *testing.T) {
+	c, _ := entity.NewCopy(entity.NewID(), "LIB-0001", entity.ConditionGood, "")
+	assert.Equal(t, entity.ErrCopyNotOnLoan, c.CheckIn(""))
+	assert.Nil(t, c.CheckOut())
+	assert.Equal(t, entity.CopyOnLoan, c.Status)
+	assert.Equal(t, entity.ErrCopyNotAvailable, c.CheckOut())
+	assert.Equal(t, entity.ErrCopyOnLoan, c.SetStatus(entity.CopyLost))
+
+	assert.Nil(t, c.CheckIn(entity.ConditionDamaged))
+	assert.Equal(t, entity.CopyInRepair, c.Status)
+	assert.Equal(t, entity.ErrCopyNotAvailable, c.SetStatus(entity.CopyAvailable))
+	assert.Nil(t, c.SetCondition(entity.ConditionPoor))
+	assert.Nil(t, c.SetStatus(entity.CopyAvailable))
+	assert.True(t, c.Available())
+}
+
+func TestCopy_Validate(t *testing.T) {
+	type test struct {
+		bookID    entity.ID
+		barcode   string
+		condition entity.CopyCondition
+		want      error
+	}
+
+	tests := []test{
+		{
+			bookID:    entity.NewID(),
+			barcode:   "LIB-0001",
+			condition: entity.ConditionGood,
+			want:      nil,
+		},
+		{
+			barcode:   "LIB-0001",
+			condition: entity.ConditionGood,
+			want:      entity.ErrInvalidCopyEntity,
+		},
+		{
+			bookID:    entity.NewID(),
+			barcode:   "LIB-0001",
+			condition: "mint",
+			want:      entity.ErrInvalidCopyEntity,
+		},
+	}
+	for _, tc := range tests {
+		_, err := entity.NewCopy(tc.bookID, tc.barcode, tc.condition, "")
+		assert.Equal(t, tc.want, err)
+	}
+}
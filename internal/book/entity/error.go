@@ -8,5 +8,71 @@ var ErrBookNotFound = errors.New("Book not found")
 // ErrInvalidBookEntity invalid book entity
 var ErrInvalidBookEntity = errors.New("Invalid book entity")
 
-// ErrBookCannotBeDeleted cannot be deleted
+// ErrBookCannotBeDeleted cannot be deleted while a copy is lent or a hold waits for it
 var ErrBookCannotBeDeleted = errors.New("Book cannot be deleted")
+
+// ErrCopyNotFound not found
+var ErrCopyNotFound = errors.New("Copy not found")
+
+// ErrInvalidCopyEntity invalid copy entity
+var ErrInvalidCopyEntity = errors.New("Invalid copy entity")
+
+// ErrCopyNotAvailable cannot be lent
+var ErrCopyNotAvailable = errors.New("Copy not available")
+
+// ErrCopyNotOnLoan cannot be checked in
+var ErrCopyNotOnLoan = errors.New("Copy not on loan")
+
+// ErrCopyOnLoan cannot change while lent
+var ErrCopyOnLoan = errors.New("Copy on loan")
+
+// ErrCopyStatusChanged someone else moved the copy first
+var ErrCopyStatusChanged = errors.New("Copy status changed")
+
+// ErrBarcodeTaken another copy has the barcode
+var ErrBarcodeTaken = errors.New("Barcode taken")
+
+// ErrBookVersionConflict someone else updated the book first
+var ErrBookVersionConflict = errors.New("Book version conflict")
+
+// ErrInvalidCriteria a filter of a book list cannot be read
+var ErrInvalidCriteria = errors.New("Invalid criteria")
+
+// ErrInvalidISBN the checksum of an ISBN does not add up
+var ErrInvalidISBN = errors.New("Invalid ISBN")
+
+// ErrISBNTaken another book has the ISBN
+var ErrISBNTaken = errors.New("ISBN taken")
+
+// ErrInvalidCatalog a catalog file cannot be read
+var ErrInvalidCatalog = errors.New("Invalid catalog file")
+
+// ErrUnknownFormat the catalog format is not one of those supported
+var ErrUnknownFormat = errors.New("Unknown format")
+
+// ErrSubjectNotFound not found
+var ErrSubjectNotFound = errors.New("Subject not found")
+
+// ErrInvalidSubjectEntity invalid subject entity
+var ErrInvalidSubjectEntity = errors.New("Invalid subject entity")
+
+// ErrSubjectCycle a subject cannot go under one of the subjects below it
+var ErrSubjectCycle = errors.New("Subject cannot go under itself")
+
+// ErrSubjectCannotBeDeleted cannot be deleted while other subjects are under it
+var ErrSubjectCannotBeDeleted = errors.New("Subject cannot be deleted")
+
+// ErrWorkNotFound not found
+var ErrWorkNotFound = errors.New("Work not found")
+
+// ErrInvalidWorkEntity invalid work entity
+var ErrInvalidWorkEntity = errors.New("Invalid work entity")
+
+// ErrWorkCannotBeDeleted cannot be deleted while books are editions of it or patrons hold it
+var ErrWorkCannotBeDeleted = errors.New("Work cannot be deleted")
+
+// ErrSeriesNotFound not found
+var ErrSeriesNotFound = errors.New("Series not found")
+
+// ErrInvalidSeriesEntity invalid series entity
+var ErrInvalidSeriesEntity = errors.New("Invalid series entity")
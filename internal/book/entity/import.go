@@ -0,0 +1,63 @@
+package entity
+
+// ImportRecord is a record read from a catalog file, Row counts the records from 1.
+// Err tells why the record could not be read into Book.
+type ImportRecord struct {
+	Row  int
+	Book *Book
+	Err  error
+}
+
+// RecordReader reads the records of a catalog file one at a time, io.EOF ends the file
+type RecordReader interface {
+	Read() (*ImportRecord, error)
+}
+
+// ImportStatus is what an import did with a record
+type ImportStatus string
+
+const (
+	// ImportCreated the record is a new book
+	ImportCreated ImportStatus = "created"
+	// ImportUpdated the record corrected the book with its ISBN
+	ImportUpdated ImportStatus = "updated"
+	// ImportSkipped the book with its ISBN is already as the record says
+	ImportSkipped ImportStatus = "skipped"
+	// ImportInvalid the record is not a valid book
+	ImportInvalid ImportStatus = "invalid"
+)
+
+// ImportResult is the line of an import report about a record
+type ImportResult struct {
+	Row    int
+	Status ImportStatus
+	BookID ID
+	ISBN   string
+	Title  string
+	Error  string
+}
+
+// ImportReport tells what an import did with every record, a dry run writes nothing
+type ImportReport struct {
+	DryRun  bool
+	Created int
+	Updated int
+	Skipped int
+	Invalid int
+	Rows    []ImportResult
+}
+
+// Add a result to the report
+func (r *ImportReport) Add(res ImportResult) {
+	switch res.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportSkipped:
+		r.Skipped++
+	case ImportInvalid:
+		r.Invalid++
+	}
+	r.Rows = append(r.Rows, res)
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"strings"
+)
+
+// NormalizeISBN checks the checksum of an ISBN-10 or ISBN-13, hyphens and spaces
+// aside, and returns it as an ISBN-13 of digits only
+func NormalizeISBN(s string) (string, error) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	switch len(isbn) {
+	case 10:
+		if !validISBN10(isbn) {
+			return "", ErrInvalidISBN
+		}
+		isbn = "978" + isbn[:9]
+		return isbn + string(isbn13CheckDigit(isbn)), nil
+	case 13:
+		if !digits(isbn) || !(strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) ||
+			isbn13CheckDigit(isbn[:12]) != isbn[12] {
+			return "", ErrInvalidISBN
+		}
+		return isbn, nil
+	}
+	return "", ErrInvalidISBN
+}
+
+// ISBN10 gives the ISBN-10 of an ISBN-13, "" for the 979 ones which have none
+func ISBN10(isbn13 string) string {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+	isbn := isbn13[3:12]
+	sum := 0
+	for i, c := range isbn {
+		sum += (10 - i) * int(c-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return isbn + "X"
+	}
+	return isbn + string(rune('0'+check))
+}
+
+// validISBN10 weighs the digits 10 to 1, X is 10 and only comes last
+func validISBN10(isbn string) bool {
+	if !digits(isbn[:9]) {
+		return false
+	}
+	sum := 0
+	for i, c := range isbn {
+		v := int(c - '0')
+		switch {
+		case c == 'X' && i == 9:
+			v = 10
+		case c < '0' || c > '9':
+			return false
+		}
+		sum += (10 - i) * v
+	}
+	return sum%11 == 0
+}
+
+// isbn13CheckDigit weighs the first 12 digits 1 and 3 alternately
+func isbn13CheckDigit(isbn string) byte {
+	sum := 0
+	for i, c := range isbn[:12] {
+		v := int(c - '0')
+		if i%2 == 1 {
+			v *= 3
+		}
+		sum += v
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
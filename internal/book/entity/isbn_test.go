@@ -0,0 +1,54 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	type test struct {
+		isbn string
+		want string
+		err  error
+	}
+
+	tests := []test{
+		{isbn: "978-0-306-40615-7", want: "9780306406157"},
+		{isbn: "0-306-40615-2", want: "9780306406157"},
+		{isbn: "080442957x", want: "9780804429573"},
+		{isbn: "979 10 90636 07 1", want: "9791090636071"},
+		{isbn: "978-0-306-40615-8", err: entity.ErrInvalidISBN},
+		{isbn: "0-306-40615-3", err: entity.ErrInvalidISBN},
+		{isbn: "X306406152", err: entity.ErrInvalidISBN},
+		{isbn: "977-0-306-40615-4", err: entity.ErrInvalidISBN},
+		{isbn: "12345", err: entity.ErrInvalidISBN},
+	}
+	for _, tc := range tests {
+		isbn, err := entity.NormalizeISBN(tc.isbn)
+		assert.Equal(t, tc.err, err, tc.isbn)
+		assert.Equal(t, tc.want, isbn, tc.isbn)
+	}
+}
+
+func TestISBN10(t *testing.T) {
+	assert.Equal(t, "0306406152", entity.ISBN10("9780306406157"))
+	assert.Equal(t, "080442957X", entity.ISBN10("9780804429573"))
+	assert.Equal(t, "", entity.ISBN10("9791090636071"))
+}
+
+func TestBook_SetISBN(t *testing.T) {
+	b, _ := entity.New("American Gods", "Neil Gaiman", 100, 1)
+	err := b.SetISBN("0-380-97365-0")
+	assert.Nil(t, err)
+	assert.Equal(t, "9780380973651", b.ISBN)
+	assert.Nil(t, b.Validate())
+
+	err = b.SetISBN("0-380-97365-1")
+	assert.Equal(t, entity.ErrInvalidISBN, err)
+	assert.Equal(t, "9780380973651", b.ISBN)
+
+	b.ISBN = "0380973650"
+	assert.Equal(t, entity.ErrInvalidBookEntity, b.Validate())
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// Subject of the taxonomy books are browsed by, genres included. Subjects make a
+// tree: Parent is the subject right above, the nil ID for the subjects at the top.
+type Subject struct {
+	ID        ID
+	Name      string
+	Parent    ID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewSubject creates a new subject under parent
+func NewSubject(name string, parent ID) (*Subject, error) {
+	s := &Subject{
+		ID:        xid.New(),
+		Name:      strings.TrimSpace(name),
+		Parent:    parent,
+		CreatedAt: time.Now(),
+	}
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate validate subject, it cannot be its own parent
+func (s *Subject) Validate() error {
+	if strings.TrimSpace(s.Name) == "" || s.Parent == s.ID {
+		return ErrInvalidSubjectEntity
+	}
+	return nil
+}
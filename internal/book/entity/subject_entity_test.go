@@ -0,0 +1,25 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSubject(t *testing.T) {
+	fiction, err := entity.NewSubject(" Fiction ", entity.ID{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Fiction", fiction.Name)
+	assert.True(t, fiction.Parent.IsNil())
+
+	sf, err := entity.NewSubject("Science fiction", fiction.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, fiction.ID, sf.Parent)
+
+	_, err = entity.NewSubject(" ", entity.ID{})
+	assert.Equal(t, entity.ErrInvalidSubjectEntity, err)
+
+	sf.Parent = sf.ID
+	assert.Equal(t, entity.ErrInvalidSubjectEntity, sf.Validate())
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// Work is what the editions of a book have in common, whatever their language,
+// printing or format. A work may be part of a series, at Position in its order.
+type Work struct {
+	ID        ID
+	Title     string
+	SeriesID  ID
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewWork creates a new work, in no series
+func NewWork(title string) (*Work, error) {
+	w := &Work{
+		ID:        xid.New(),
+		Title:     strings.TrimSpace(title),
+		CreatedAt: time.Now(),
+	}
+	err := w.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// SetSeries places the work in a series at position, a nil series takes it out
+func (w *Work) SetSeries(series ID, position int) error {
+	if series.IsNil() {
+		w.SeriesID, w.Position = series, 0
+		return nil
+	}
+	if position <= 0 {
+		return ErrInvalidWorkEntity
+	}
+	w.SeriesID, w.Position = series, position
+	return nil
+}
+
+// Validate validate work, only works in a series have a position
+func (w *Work) Validate() error {
+	if strings.TrimSpace(w.Title) == "" {
+		return ErrInvalidWorkEntity
+	}
+	if w.SeriesID.IsNil() != (w.Position == 0) || w.Position < 0 {
+		return ErrInvalidWorkEntity
+	}
+	return nil
+}
+
+// Series of works read in order, like the volumes of a saga
+type Series struct {
+	ID        ID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewSeries creates a new series
+func NewSeries(name string) (*Series, error) {
+	s := &Series{
+		ID:        xid.New(),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+	err := s.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate validate series
+func (s *Series) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return ErrInvalidSeriesEntity
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWork(t *testing.T) {
+	w, err := entity.NewWork(" One Hundred Years of Solitude ")
+	assert.Nil(t, err)
+	assert.Equal(t, "One Hundred Years of Solitude", w.Title)
+	assert.True(t, w.SeriesID.IsNil())
+
+	_, err = entity.NewWork("")
+	assert.Equal(t, entity.ErrInvalidWorkEntity, err)
+}
+
+func TestWork_SetSeries(t *testing.T) {
+	w, _ := entity.NewWork("Dune Messiah")
+	s, _ := entity.NewSeries("Dune")
+	assert.Equal(t, entity.ErrInvalidWorkEntity, w.SetSeries(s.ID, 0))
+	assert.Nil(t, w.SetSeries(s.ID, 2))
+	assert.Equal(t, 2, w.Position)
+	assert.Nil(t, w.Validate())
+
+	assert.Nil(t, w.SetSeries(entity.ID{}, 5))
+	assert.Equal(t, 0, w.Position)
+	assert.Nil(t, w.Validate())
+
+	_, err := entity.NewSeries(" ")
+	assert.Equal(t, entity.ErrInvalidSeriesEntity, err)
+}
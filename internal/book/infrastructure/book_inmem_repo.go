@@ -1,22 +1,28 @@
 package infrastructure
 
 import (
+	"html"
+	"sort"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 type bookInMemRepo struct {
-	mtx sync.RWMutex
-	m   map[entity.ID]*entity.Book
+	mtx    sync.RWMutex
+	m      map[entity.ID]*entity.Book
+	copies CopyReader
 }
 
-// NewInMemRepo create book in memory repository
-func NewInMemRepo() BookRepo {
+// NewInMemRepo create book in memory repository, copies tell which books are on the shelf
+func NewInMemRepo(copies CopyReader) BookRepo {
 	var m = map[entity.ID]*entity.Book{}
 	return &bookInMemRepo{
-		m: m,
+		m:      m,
+		copies: copies,
 	}
 }
 
@@ -24,10 +30,36 @@ func NewInMemRepo() BookRepo {
 func (r *bookInMemRepo) Create(e *entity.Book) (entity.ID, error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	if r.isbnTaken(e) {
+		return e.ID, entity.ErrISBNTaken
+	}
 	r.m[e.ID] = e
 	return e.ID, nil
 }
 
+// GetByISBN finds a book by its ISBN-13
+func (r *bookInMemRepo) GetByISBN(isbn string) (*entity.Book, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	for _, j := range r.m {
+		if j != nil && isbn != "" && j.ISBN == isbn {
+			b := *j
+			return &b, nil
+		}
+	}
+	return nil, entity.ErrBookNotFound
+}
+
+// isbnTaken tells if another book has the ISBN of e
+func (r *bookInMemRepo) isbnTaken(e *entity.Book) bool {
+	for _, j := range r.m {
+		if j != nil && e.ISBN != "" && j.ISBN == e.ISBN && j.ID != e.ID {
+			return true
+		}
+	}
+	return false
+}
+
 //Get a book
 func (r *bookInMemRepo) Get(id entity.ID) (*entity.Book, error) {
 	r.mtx.Lock()
@@ -35,42 +67,231 @@ func (r *bookInMemRepo) Get(id entity.ID) (*entity.Book, error) {
 	if r.m[id] == nil {
 		return nil, entity.ErrBookNotFound
 	}
-	return r.m[id], nil
+	b := *r.m[id]
+	return &b, nil
 }
 
-//Update a book
+//Update a book, unless its version moved on
 func (r *bookInMemRepo) Update(e *entity.Book) error {
-	_, err := r.Get(e.ID)
-	if err != nil {
-		return err
-	}
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
-	r.m[e.ID] = e
+	current := r.m[e.ID]
+	if current == nil {
+		return entity.ErrBookNotFound
+	}
+	if current.Version != e.Version {
+		return entity.ErrBookVersionConflict
+	}
+	if r.isbnTaken(e) {
+		return entity.ErrISBNTaken
+	}
+	e.Version++
+	b := *e
+	r.m[e.ID] = &b
 	return nil
 }
 
-//Search books
-func (r *bookInMemRepo) Search(query string) ([]*entity.Book, error) {
-	var d []*entity.Book
-	r.mtx.Lock()
-	defer r.mtx.Unlock()
+//Search books by title and author, approximating the postgres full text search
+func (r *bookInMemRepo) Search(q entity.Query, c entity.Criteria, opts repository.ListOptions) ([]*entity.BookHit, *repository.Page, error) {
+	if _, ok := bookSorts[opts.Sort]; !ok && !searchSort(opts) {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var hits []*entity.BookHit
+	var rows []repository.Row
 	for _, j := range r.m {
-		if strings.Contains(strings.ToLower(j.Title), query) {
-			d = append(d, j)
+		if j == nil {
+			continue
+		}
+		ok, err := r.meets(c, j)
+		if err != nil {
+			return nil, nil, err
 		}
+		if !ok {
+			continue
+		}
+		h, ok := match(q, j)
+		if !ok {
+			continue
+		}
+		hits = append(hits, h)
+		row := repository.Row{ID: j.ID.String(), Key: bookSortKey(j, opts.Sort)}
+		if searchSort(opts) {
+			row.Key = -h.Rank
+		}
+		rows = append(rows, row)
 	}
-	return d, nil
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.BookHit
+	for _, i := range idx {
+		d = append(d, hits[i])
+	}
+	return d, page, nil
 }
 
-//List books
-func (r *bookInMemRepo) List() ([]*entity.Book, error) {
+//List books meeting the criteria
+func (r *bookInMemRepo) List(c entity.Criteria, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	if _, ok := bookSorts[opts.Sort]; !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var books []*entity.Book
+	var rows []repository.Row
+	for _, j := range r.m {
+		if j == nil {
+			continue
+		}
+		ok, err := r.meets(c, j)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			continue
+		}
+		books = append(books, j)
+		rows = append(rows, repository.Row{ID: j.ID.String(), Key: bookSortKey(j, opts.Sort)})
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.Book
+	for _, i := range idx {
+		b := *books[i]
+		d = append(d, &b)
+	}
+	return d, page, nil
+}
+
+// meets tells if a book meets the criteria, looking for a copy on the shelf only when asked
+func (r *bookInMemRepo) meets(c entity.Criteria, b *entity.Book) (bool, error) {
+	available := false
+	if c.Available != nil {
+		copies, err := r.copies.ListByBook(b.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, cp := range copies {
+			if cp.Available() {
+				available = true
+				break
+			}
+		}
+	}
+	return c.Match(b, available), nil
+}
+
+// title words weigh more than author words, as in the search column of postgres
+var fieldWeights = []float64{1, 0.4}
+
+type token struct {
+	word       string
+	start, end int
+}
+
+// tokens splits a text in lower case words, remembering where they are
+func tokens(s string, offset int) []token {
+	var t []token
+	start := -1
+	for i, c := range s + " " {
+		word := unicode.IsLetter(c) || unicode.IsDigit(c)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			t = append(t, token{word: strings.ToLower(s[start:i]), start: offset + start, end: offset + i})
+			start = -1
+		}
+	}
+	return t
+}
+
+// match tells if a book matches every term of q, ranks it and highlights the words matched
+func match(q entity.Query, b *entity.Book) (*entity.BookHit, bool) {
+	if len(q) == 0 {
+		return nil, false
+	}
+	text := b.Title + " — " + b.Author
+	fields := [][]token{tokens(b.Title, 0), tokens(b.Author, len(b.Title)+len(" — "))}
+	matched := map[int]bool{}
+	h := &entity.BookHit{Book: *b}
+	for _, t := range q {
+		found := false
+		for f, field := range fields {
+			for i := 0; i+len(t.Words) <= len(field); i++ {
+				if !matchAt(t, field[i:]) {
+					continue
+				}
+				found = true
+				h.Rank += fieldWeights[f]
+				for n := range t.Words {
+					matched[field[i+n].start] = true
+				}
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	var snippet strings.Builder
+	last := 0
+	for _, field := range fields {
+		for _, tk := range field {
+			if !matched[tk.start] {
+				continue
+			}
+			snippet.WriteString(html.EscapeString(text[last:tk.start]) + "<b>" + html.EscapeString(text[tk.start:tk.end]) + "</b>")
+			last = tk.end
+		}
+	}
+	snippet.WriteString(html.EscapeString(text[last:]))
+	h.Snippet = snippet.String()
+	return h, true
+}
+
+// matchAt tells if the words of a term start the tokens
+func matchAt(t entity.Term, tokens []token) bool {
+	for n, w := range t.Words {
+		if t.Prefix && n == len(t.Words)-1 {
+			if !strings.HasPrefix(tokens[n].word, w) {
+				return false
+			}
+			continue
+		}
+		if tokens[n].word != w {
+			return false
+		}
+	}
+	return true
+}
+
+// ListByWork lists the editions of a work, the oldest first
+func (r *bookInMemRepo) ListByWork(workID entity.ID) ([]*entity.Book, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
 	var d []*entity.Book
-	r.mtx.Lock()
-	defer r.mtx.Unlock()
 	for _, j := range r.m {
-		d = append(d, j)
+		if j != nil && j.WorkID == workID {
+			b := *j
+			d = append(d, &b)
+		}
 	}
+	sort.Slice(d, func(i, j int) bool {
+		a, b := d[i].PublishedAt, d[j].PublishedAt
+		switch {
+		case a.IsZero() != b.IsZero():
+			// the books of unknown date go last
+			return b.IsZero()
+		case !a.Equal(b):
+			return a.Before(b)
+		}
+		return d[i].ID.String() < d[j].ID.String()
+	})
 	return d, nil
 }
 
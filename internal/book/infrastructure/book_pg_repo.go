@@ -1,8 +1,14 @@
 package infrastructure
 
 import (
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
 	"github.com/sgraham785/gocleanarch-example/pkg/repository"
@@ -11,35 +17,51 @@ import (
 
 // bookPgRepo pg database repo
 type bookPgRepo struct {
-	db  *repository.Repository
+	db  repository.Querier
 	log *logger.Logger
 }
 
 // NewPgRepo create new book postgres repo
 func NewPgRepo(s *server.Server) BookRepo {
 	return &bookPgRepo{
-		db:  s.DB,
+		db:  s.DB.Pg,
 		log: s.Log,
 	}
 }
 
+// NewTxPgRepo create a book postgres repo writing through tx
+func NewTxPgRepo(tx *sqlx.Tx, l *logger.Logger) BookRepo {
+	return &bookPgRepo{
+		db:  tx,
+		log: l,
+	}
+}
+
 // Create a book
 func (r *bookPgRepo) Create(e *entity.Book) (entity.ID, error) {
-	sql := `insert into book (id, title, author, pages, quantity, created_at) 
-	values($1,$2,$3,$4,$5,$6)`
+	query := `insert into book (id, isbn, title, author, pages, version, language, published_at, tags, created_at, work_id) 
+	values($1,nullif($2, ''),$3,$4,$5,$6,$7,$8,$9,$10,$11)`
 
-	stmt, err := r.db.Pg.Prepare(sql)
+	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return e.ID, err
 	}
 	_, err = stmt.Exec(
 		e.ID,
+		e.ISBN,
 		e.Title,
 		e.Author,
 		e.Pages,
-		e.Quantity,
+		e.Version,
+		e.Language,
+		publishedAt(e),
+		pq.Array(tagsOf(e)),
 		time.Now().Format("2006-01-02"),
+		e.WorkID,
 	)
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+		return e.ID, entity.ErrISBNTaken
+	}
 	if err != nil {
 		return e.ID, err
 	}
@@ -52,60 +74,273 @@ func (r *bookPgRepo) Create(e *entity.Book) (entity.ID, error) {
 
 // Get a book
 func (r *bookPgRepo) Get(id entity.ID) (*entity.Book, error) {
-	sql := `select id, title, author, pages, quantity, created_at from book where id = $1`
+	query := `select ` + bookColumns + ` from book where id = $1`
 	var book entity.Book
-	err := r.db.Pg.QueryRowx(sql, id).StructScan(&book)
+	err := scanBook(r.db.QueryRowx(query, id), &book)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrBookNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &book, nil
 }
 
-// Update a book
+// GetByISBN finds a book by its ISBN-13
+func (r *bookPgRepo) GetByISBN(isbn string) (*entity.Book, error) {
+	query := `select ` + bookColumns + ` from book where isbn = $1`
+	var book entity.Book
+	err := scanBook(r.db.QueryRowx(query, isbn), &book)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// Update a book, compare and swap on its version
 func (r *bookPgRepo) Update(e *entity.Book) error {
-	sql := `update book set title = $1, author = $2, pages = $3, quantity = $4, updated_at = $5 where id = $6`
+	query := `update book set title = $1, author = $2, pages = $3, language = $4, published_at = $5, tags = $6,
+	isbn = nullif($7, ''), work_id = $8, updated_at = $9, version = version + 1 where id = $10 and version = $11`
 	e.UpdatedAt = time.Now()
-	err := r.db.Pg.QueryRowx(sql, e.Title, e.Author, e.Pages, e.Quantity, e.UpdatedAt.Format("2006-01-02"), e.ID).Err()
+	res, err := r.db.Exec(query, e.Title, e.Author, e.Pages, e.Language, publishedAt(e), pq.Array(tagsOf(e)), e.ISBN, e.WorkID, e.UpdatedAt, e.ID, e.Version)
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+		return entity.ErrISBNTaken
+	}
 	if err != nil {
 		return err
 	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		_, err = r.Get(e.ID)
+		if err != nil {
+			return err
+		}
+		return entity.ErrBookVersionConflict
+	}
+	e.Version++
 	return nil
 }
 
-// Search books
-func (r *bookPgRepo) Search(query string) ([]*entity.Book, error) {
-	sql := `select id, title, author, pages, quantity, created_at from book where title like $1`
-	var books []*entity.Book
-	err := r.db.Pg.QueryRowx(sql, query).StructScan(&books)
+// Search books meeting the criteria by title and author on their search column,
+// ranked with title matches above author matches
+func (r *bookPgRepo) Search(q entity.Query, c entity.Criteria, opts repository.ListOptions) ([]*entity.BookHit, *repository.Page, error) {
+	column, ok := bookSorts[opts.Sort]
+	if searchSort(opts) {
+		column, ok = `-ts_rank(search, to_tsquery('simple', $1))`, true
+	}
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	filter, args := where(c, []interface{}{tsquery(q)})
+	filter = `search @@ to_tsquery('simple', $1) and ` + filter
+	page, err := r.count(filter, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses(column, args, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	query := `select ` + bookColumns + `,
+	ts_rank(search, to_tsquery('simple', $1)),
+	ts_headline('simple', coalesce(title, '') || ' — ' || coalesce(author, ''), to_tsquery('simple', $1),
+		'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', HighlightAll=true')
+	from book where ` + filter + clauses
+	rows, err := r.db.Queryx(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var hits []*entity.BookHit
+	for rows.Next() {
+		var h entity.BookHit
+		err = scanBook(rows, &h.Book, &h.Rank, &h.Snippet)
+		if err != nil {
+			return nil, nil, err
+		}
+		h.Snippet = highlight(h.Snippet)
+		hits = append(hits, &h)
 	}
-	// for _, b := range books {
-	// 	books = append(books, b)
-	// }
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if repository.HasNext(len(hits), opts) {
+		hits = hits[:opts.Limit]
+		last := hits[len(hits)-1]
+		var key interface{} = -last.Rank
+		if !searchSort(opts) {
+			key = bookSortKey(&last.Book, opts.Sort)
+		}
+		page.Next = repository.Cursor(last.ID.String(), key)
+	}
+	return hits, page, nil
+}
 
-	return books, nil
+// highlight escapes a ts_headline for HTML and turns its \x02 and \x03 markers into <b> and </b>
+func highlight(headline string) string {
+	return strings.NewReplacer("\x02", "<b>", "\x03", "</b>").Replace(html.EscapeString(headline))
 }
 
-// List books
-func (r *bookPgRepo) List() ([]*entity.Book, error) {
-	sql := `select id, title, author, pages, quantity, created_at from book`
+// tsquery renders q for to_tsquery, query words are only letters and digits so they need no quoting
+func tsquery(q entity.Query) string {
+	var terms []string
+	for _, t := range q {
+		term := strings.Join(t.Words, " <-> ")
+		if t.Prefix {
+			term += ":*"
+		}
+		if t.IsPhrase() {
+			term = "(" + term + ")"
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(terms, " & ")
+}
+
+// List books meeting the criteria, with their total count
+func (r *bookPgRepo) List(c entity.Criteria, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	column, ok := bookSorts[opts.Sort]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	filter, args := where(c, nil)
+	page, err := r.count(filter, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses(column, args, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := r.db.Queryx(`select `+bookColumns+` from book where `+filter+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
 	var books []*entity.Book
-	err := r.db.Pg.QueryRowx(sql).StructScan(books)
+	for rows.Next() {
+		var b entity.Book
+		err = scanBook(rows, &b)
+		if err != nil {
+			return nil, nil, err
+		}
+		books = append(books, &b)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if repository.HasNext(len(books), opts) {
+		books = books[:opts.Limit]
+		last := books[len(books)-1]
+		page.Next = repository.Cursor(last.ID.String(), bookSortKey(last, opts.Sort))
+	}
+	return books, page, nil
+}
+
+// where renders the criteria as conditions on book, their values are appended to args
+// and only ever reach the query as parameters
+func where(c entity.Criteria, args []interface{}) (string, []interface{}) {
+	var b strings.Builder
+	b.WriteString("true")
+	if c.Author != "" {
+		args = append(args, "%"+escapeLike(strings.ToLower(c.Author))+"%")
+		fmt.Fprintf(&b, " and lower(author) like $%d", len(args))
+	}
+	if !c.PublishedAfter.IsZero() {
+		args = append(args, c.PublishedAfter.Format("2006-01-02"))
+		fmt.Fprintf(&b, " and published_at > $%d", len(args))
+	}
+	if c.Available != nil {
+		if !*c.Available {
+			b.WriteString(" and not")
+		} else {
+			b.WriteString(" and")
+		}
+		b.WriteString(" exists (select 1 from copy where copy.book_id = book.id and copy.status = 'available')")
+	}
+	if tags := entity.NormalizeTags(c.Tags); len(tags) > 0 {
+		args = append(args, pq.Array(tags))
+		fmt.Fprintf(&b, " and tags @> $%d", len(args))
+	}
+	if c.Language != "" {
+		args = append(args, strings.ToLower(c.Language))
+		fmt.Fprintf(&b, " and language = $%d", len(args))
+	}
+	return b.String(), args
+}
+
+// escapeLike makes the wildcards of s match themselves in a like pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ListByWork lists the editions of a work, the oldest first
+func (r *bookPgRepo) ListByWork(workID entity.ID) ([]*entity.Book, error) {
+	rows, err := r.db.Queryx(`select `+bookColumns+` from book where work_id = $1 order by published_at nulls last, id`, workID)
 	if err != nil {
 		return nil, err
 	}
-	// for _, b := range books {
-	// 	books = append(books, b)
-	// }
+	defer rows.Close()
+	var books []*entity.Book
+	for rows.Next() {
+		var b entity.Book
+		err = scanBook(rows, &b)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, &b)
+	}
+	return books, rows.Err()
+}
+
+// bookColumns are the columns scanBook reads
+const bookColumns = `id, coalesce(isbn, ''), title, author, pages, version, language, published_at, tags, created_at, work_id`
+
+// scanBook reads the bookColumns of a row into b, then the extra columns
+func scanBook(row interface{ Scan(...interface{}) error }, b *entity.Book, extra ...interface{}) error {
+	var published sql.NullTime
+	dest := append([]interface{}{&b.ID, &b.ISBN, &b.Title, &b.Author, &b.Pages, &b.Version, &b.Language, &published, pq.Array(&b.Tags), &b.CreatedAt, &b.WorkID}, extra...)
+	err := row.Scan(dest...)
+	if err != nil {
+		return err
+	}
+	b.PublishedAt = published.Time
+	return nil
+}
+
+// publishedAt is null for the books of unknown date
+func publishedAt(b *entity.Book) sql.NullTime {
+	return sql.NullTime{Time: b.PublishedAt, Valid: !b.PublishedAt.IsZero()}
+}
 
-	return books, nil
+// tagsOf never gives nil, tags is not null
+func tagsOf(b *entity.Book) []string {
+	if b.Tags == nil {
+		return []string{}
+	}
+	return b.Tags
+}
+
+// count the books matching filter
+func (r *bookPgRepo) count(filter string, args []interface{}) (*repository.Page, error) {
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from book where `+filter, args...).Scan(&page.Total)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
 // Delete a book
 func (r *bookPgRepo) Delete(id entity.ID) error {
 	sql := `delete from book where id = $1`
-	_, err := r.db.Pg.Exec(sql, id)
+	_, err := r.db.Exec(sql, id)
 	if err != nil {
 		return err
 	}
@@ -2,18 +2,22 @@ package infrastructure
 
 import (
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 //go:generate mockgen -destination=../mock/book_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure Reader,Writer,BookRepo
 
-// Reader interface
+// Reader interface, lists come a page at a time
 type Reader interface {
 	Get(id entity.ID) (*entity.Book, error)
-	Search(query string) ([]*entity.Book, error)
-	List() ([]*entity.Book, error)
+	GetByISBN(isbn string) (*entity.Book, error)
+	Search(q entity.Query, c entity.Criteria, opts repository.ListOptions) ([]*entity.BookHit, *repository.Page, error)
+	List(c entity.Criteria, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error)
+	ListByWork(workID entity.ID) ([]*entity.Book, error)
 }
 
-// Writer interface
+// Writer interface, Update only applies to the version of the book it is given
+// and moves it to the next one. No two books share an ISBN.
 type Writer interface {
 	Create(e *entity.Book) (entity.ID, error)
 	Update(e *entity.Book) error
@@ -25,3 +29,32 @@ type BookRepo interface {
 	Reader
 	Writer
 }
+
+// bookSorts maps the fields books are sorted on to their column
+var bookSorts = map[string]string{
+	"":           "id",
+	"title":      "title",
+	"author":     "author",
+	"pages":      "pages",
+	"created_at": "created_at",
+}
+
+// searchSort tells if a search sorts its hits on their rank, the most relevant first
+func searchSort(opts repository.ListOptions) bool {
+	return opts.Sort == "" || opts.Sort == "rank"
+}
+
+// bookSortKey is the value of a book for the sort field
+func bookSortKey(b *entity.Book, field string) interface{} {
+	switch field {
+	case "title":
+		return b.Title
+	case "author":
+		return b.Author
+	case "pages":
+		return b.Pages
+	case "created_at":
+		return b.CreatedAt
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+// copyInMemRepo keeps copies by value so callers cannot change the stored status behind UpdateStatus
+type copyInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[string]entity.Copy
+}
+
+// NewCopyInMemRepo create copy in memory repository
+func NewCopyInMemRepo() CopyRepo {
+	var m = map[string]entity.Copy{}
+	return &copyInMemRepo{
+		m: m,
+	}
+}
+
+// Create a copy
+func (r *copyInMemRepo) Create(e *entity.Copy) (string, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.m[e.Barcode]; ok {
+		return e.Barcode, entity.ErrBarcodeTaken
+	}
+	r.m[e.Barcode] = *e
+	return e.Barcode, nil
+}
+
+// Get a copy
+func (r *copyInMemRepo) Get(barcode string) (*entity.Copy, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	c, ok := r.m[barcode]
+	if !ok {
+		return nil, entity.ErrCopyNotFound
+	}
+	return &c, nil
+}
+
+// ListByBook lists the copies of a book by barcode
+func (r *copyInMemRepo) ListByBook(bookID entity.ID) ([]*entity.Copy, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var d []*entity.Copy
+	for _, c := range r.m {
+		if c.BookID == bookID {
+			c := c
+			d = append(d, &c)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool {
+		return d[i].Barcode < d[j].Barcode
+	})
+	return d, nil
+}
+
+// LockByBook lists the copies of a book, the in memory unit of work already runs alone
+func (r *copyInMemRepo) LockByBook(bookID entity.ID) ([]*entity.Copy, error) {
+	return r.ListByBook(bookID)
+}
+
+// CountAvailable counts the copies on the shelf of each of the books
+func (r *copyInMemRepo) CountAvailable(bookIDs []entity.ID) (map[entity.ID]int, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	want := map[entity.ID]bool{}
+	for _, id := range bookIDs {
+		want[id] = true
+	}
+	d := map[entity.ID]int{}
+	for _, c := range r.m {
+		if want[c.BookID] && c.Available() {
+			d[c.BookID]++
+		}
+	}
+	return d, nil
+}
+
+// Update a copy
+func (r *copyInMemRepo) Update(e *entity.Copy) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.m[e.Barcode]; !ok {
+		return entity.ErrCopyNotFound
+	}
+	e.UpdatedAt = time.Now()
+	r.m[e.Barcode] = *e
+	return nil
+}
+
+// UpdateStatus writes the status of e only if the stored one is still from
+func (r *copyInMemRepo) UpdateStatus(e *entity.Copy, from entity.CopyStatus) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	c, ok := r.m[e.Barcode]
+	if !ok {
+		return entity.ErrCopyNotFound
+	}
+	if c.Status != from {
+		return entity.ErrCopyStatusChanged
+	}
+	e.UpdatedAt = time.Now()
+	c.Status = e.Status
+	c.Condition = e.Condition
+	c.UpdatedAt = e.UpdatedAt
+	r.m[e.Barcode] = c
+	return nil
+}
+
+// DeleteByBook removes all the copies of a book
+func (r *copyInMemRepo) DeleteByBook(bookID entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for barcode, c := range r.m {
+		if c.BookID == bookID {
+			delete(r.m, barcode)
+		}
+	}
+	return nil
+}
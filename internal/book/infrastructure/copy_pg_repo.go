@@ -0,0 +1,165 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+const copyColumns = `barcode, book_id, condition, location, status, created_at, updated_at`
+
+// copyPgRepo pg database repo
+type copyPgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewCopyPgRepo create new copy postgres repo
+func NewCopyPgRepo(s *server.Server) CopyRepo {
+	return &copyPgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// NewCopyTxPgRepo create a copy postgres repo writing through tx
+func NewCopyTxPgRepo(tx *sqlx.Tx, l *logger.Logger) CopyRepo {
+	return &copyPgRepo{
+		db:  tx,
+		log: l,
+	}
+}
+
+// Create a copy
+func (r *copyPgRepo) Create(e *entity.Copy) (string, error) {
+	query := `insert into copy (` + copyColumns + `) values($1,$2,$3,$4,$5,$6,$7)`
+	_, err := r.db.Exec(query,
+		e.Barcode,
+		e.BookID,
+		e.Condition,
+		e.Location,
+		e.Status,
+		e.CreatedAt,
+		e.CreatedAt,
+	)
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+		return e.Barcode, entity.ErrBarcodeTaken
+	}
+	if err != nil {
+		return e.Barcode, err
+	}
+	return e.Barcode, nil
+}
+
+// Get a copy
+func (r *copyPgRepo) Get(barcode string) (*entity.Copy, error) {
+	copies, err := r.query(`select `+copyColumns+` from copy where barcode = $1`, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if len(copies) == 0 {
+		return nil, entity.ErrCopyNotFound
+	}
+	return copies[0], nil
+}
+
+// ListByBook lists the copies of a book by barcode
+func (r *copyPgRepo) ListByBook(bookID entity.ID) ([]*entity.Copy, error) {
+	return r.query(`select `+copyColumns+` from copy where book_id = $1 order by barcode`, bookID)
+}
+
+// LockByBook lists the copies of a book and locks them until the transaction ends
+func (r *copyPgRepo) LockByBook(bookID entity.ID) ([]*entity.Copy, error) {
+	return r.query(`select `+copyColumns+` from copy where book_id = $1 order by barcode for update`, bookID)
+}
+
+// CountAvailable counts the copies on the shelf of each of the books in one query
+func (r *copyPgRepo) CountAvailable(bookIDs []entity.ID) (map[entity.ID]int, error) {
+	ids := make([]string, len(bookIDs))
+	for i, id := range bookIDs {
+		ids[i] = id.String()
+	}
+	rows, err := r.db.Query(`select book_id, count(*) from copy
+		where book_id = any($1::varchar[]) and status = $2 group by book_id`, pq.Array(ids), entity.CopyAvailable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	d := map[entity.ID]int{}
+	for rows.Next() {
+		var id entity.ID
+		var n int
+		err = rows.Scan(&id, &n)
+		if err != nil {
+			return nil, err
+		}
+		d[id] = n
+	}
+	return d, rows.Err()
+}
+
+// Update a copy
+func (r *copyPgRepo) Update(e *entity.Copy) error {
+	e.UpdatedAt = time.Now()
+	query := `update copy set condition = $1, location = $2, status = $3, updated_at = $4 where barcode = $5`
+	res, err := r.db.Exec(query, e.Condition, e.Location, e.Status, e.UpdatedAt, e.Barcode)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrCopyNotFound
+	}
+	return nil
+}
+
+// UpdateStatus writes the status of e only if the stored one is still from,
+// a concurrent transaction that moved the copy first makes it fail
+func (r *copyPgRepo) UpdateStatus(e *entity.Copy, from entity.CopyStatus) error {
+	e.UpdatedAt = time.Now()
+	query := `update copy set status = $1, condition = $2, updated_at = $3 where barcode = $4 and status = $5`
+	res, err := r.db.Exec(query, e.Status, e.Condition, e.UpdatedAt, e.Barcode, from)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrCopyStatusChanged
+	}
+	return nil
+}
+
+// DeleteByBook removes all the copies of a book
+func (r *copyPgRepo) DeleteByBook(bookID entity.ID) error {
+	_, err := r.db.Exec(`delete from copy where book_id = $1`, bookID)
+	return err
+}
+
+func (r *copyPgRepo) query(query string, args ...interface{}) ([]*entity.Copy, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var copies []*entity.Copy
+	for rows.Next() {
+		var c entity.Copy
+		err = rows.Scan(&c.Barcode, &c.BookID, &c.Condition, &c.Location, &c.Status, &c.CreatedAt, &c.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, &c)
+	}
+	return copies, rows.Err()
+}
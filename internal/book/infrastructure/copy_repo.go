@@ -0,0 +1,32 @@
+package infrastructure
+
+import (
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+//go:generate mockgen -destination=../mock/copy_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure CopyReader,CopyWriter,CopyRepo
+
+// CopyReader interface
+type CopyReader interface {
+	Get(barcode string) (*entity.Copy, error)
+	ListByBook(bookID entity.ID) ([]*entity.Copy, error)
+	// LockByBook is ListByBook keeping the copies from other units of work until this one ends
+	LockByBook(bookID entity.ID) ([]*entity.Copy, error)
+	// CountAvailable counts the copies on the shelf of each of the books, a book with none is left out
+	CountAvailable(bookIDs []entity.ID) (map[entity.ID]int, error)
+}
+
+// CopyWriter interface
+type CopyWriter interface {
+	Create(e *entity.Copy) (string, error)
+	Update(e *entity.Copy) error
+	// UpdateStatus writes the status of e only if the stored one is still from
+	UpdateStatus(e *entity.Copy, from entity.CopyStatus) error
+	DeleteByBook(bookID entity.ID) error
+}
+
+// CopyRepo interface
+type CopyRepo interface {
+	CopyReader
+	CopyWriter
+}
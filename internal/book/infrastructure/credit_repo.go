@@ -0,0 +1,14 @@
+package infrastructure
+
+import (
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+//go:generate mockgen -destination=../mock/credit_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure CreditWriter
+
+// CreditWriter credits a book to the author records of the names, in order, in place
+// of its former authors. The authors no record goes by yet are created.
+// The author module keeps the records.
+type CreditWriter interface {
+	CreditBook(bookID entity.ID, names []string) error
+}
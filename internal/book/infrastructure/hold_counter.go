@@ -0,0 +1,14 @@
+package infrastructure
+
+import (
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+//go:generate mockgen -destination=../mock/hold_counter_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure HoldCounter
+
+// HoldCounter counts the waiting and ready holds placed on a work or a book,
+// the borrow module keeps them
+type HoldCounter interface {
+	CountOpenByWork(workID entity.ID) (int, error)
+	CountOpenByBook(bookID entity.ID) (int, error)
+}
@@ -0,0 +1,222 @@
+package infrastructure
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+type subjectInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[entity.ID]*entity.Subject
+	// books holds the subjects of every book
+	books map[entity.ID][]entity.ID
+}
+
+// NewSubjectInMemRepo create subject in memory repository
+func NewSubjectInMemRepo() SubjectRepo {
+	return &subjectInMemRepo{
+		m:     map[entity.ID]*entity.Subject{},
+		books: map[entity.ID][]entity.ID{},
+	}
+}
+
+// Create a subject
+func (r *subjectInMemRepo) Create(e *entity.Subject) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if !e.Parent.IsNil() && r.m[e.Parent] == nil {
+		return e.ID, entity.ErrSubjectNotFound
+	}
+	s := *e
+	r.m[e.ID] = &s
+	return e.ID, nil
+}
+
+// Get a subject
+func (r *subjectInMemRepo) Get(id entity.ID) (*entity.Subject, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrSubjectNotFound
+	}
+	s := *r.m[id]
+	return &s, nil
+}
+
+// Update a subject
+func (r *subjectInMemRepo) Update(e *entity.Subject) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[e.ID] == nil || !e.Parent.IsNil() && r.m[e.Parent] == nil {
+		return entity.ErrSubjectNotFound
+	}
+	for _, d := range r.descendants(e.ID) {
+		if d == e.Parent {
+			return entity.ErrSubjectCycle
+		}
+	}
+	e.UpdatedAt = time.Now()
+	s := *e
+	r.m[e.ID] = &s
+	return nil
+}
+
+// List subjects
+func (r *subjectInMemRepo) List(opts repository.ListOptions) ([]*entity.Subject, *repository.Page, error) {
+	if _, ok := subjectSorts[opts.Sort]; !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var subjects []*entity.Subject
+	var rows []repository.Row
+	for _, s := range r.m {
+		subjects = append(subjects, s)
+		rows = append(rows, repository.Row{ID: s.ID.String(), Key: subjectSortKey(s, opts.Sort)})
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.Subject
+	for _, i := range idx {
+		s := *subjects[i]
+		d = append(d, &s)
+	}
+	return d, page, nil
+}
+
+// Descendants lists the subject and all the subjects below it, each just once
+func (r *subjectInMemRepo) Descendants(id entity.ID) ([]entity.ID, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrSubjectNotFound
+	}
+	return r.descendants(id), nil
+}
+
+// descendants walks the tree a level after the other, the caller holds the lock
+func (r *subjectInMemRepo) descendants(id entity.ID) []entity.ID {
+	d := []entity.ID{id}
+	seen := map[entity.ID]bool{id: true}
+	for i := 0; i < len(d); i++ {
+		for _, s := range r.m {
+			if s.Parent == d[i] && !seen[s.ID] {
+				seen[s.ID] = true
+				d = append(d, s.ID)
+			}
+		}
+	}
+	return d
+}
+
+// ListByBook lists the subjects of a book by name
+func (r *subjectInMemRepo) ListByBook(bookID entity.ID) ([]*entity.Subject, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var d []*entity.Subject
+	for _, id := range r.books[bookID] {
+		s := *r.m[id]
+		d = append(d, &s)
+	}
+	sort.Slice(d, func(i, j int) bool {
+		return strings.ToLower(d[i].Name) < strings.ToLower(d[j].Name)
+	})
+	return d, nil
+}
+
+// ListByBooks lists the subjects of each of the books by name
+func (r *subjectInMemRepo) ListByBooks(bookIDs []entity.ID) (map[entity.ID][]*entity.Subject, error) {
+	d := map[entity.ID][]*entity.Subject{}
+	for _, id := range bookIDs {
+		subjects, err := r.ListByBook(id)
+		if err != nil {
+			return nil, err
+		}
+		if len(subjects) > 0 {
+			d[id] = subjects
+		}
+	}
+	return d, nil
+}
+
+// ListBooks lists the books of any of the subjects
+func (r *subjectInMemRepo) ListBooks(subjects []entity.ID, opts repository.ListOptions) ([]entity.ID, *repository.Page, error) {
+	if opts.Sort != "" {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	wanted := map[entity.ID]bool{}
+	for _, s := range subjects {
+		wanted[s] = true
+	}
+	var books []entity.ID
+	var rows []repository.Row
+	for bookID, ids := range r.books {
+		for _, s := range ids {
+			if wanted[s] {
+				books = append(books, bookID)
+				rows = append(rows, repository.Row{ID: bookID.String()})
+				break
+			}
+		}
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []entity.ID
+	for _, i := range idx {
+		d = append(d, books[i])
+	}
+	return d, page, nil
+}
+
+// SetBookSubjects replaces the subjects of a book
+func (r *subjectInMemRepo) SetBookSubjects(bookID entity.ID, subjects []entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for _, s := range subjects {
+		if r.m[s] == nil {
+			return entity.ErrSubjectNotFound
+		}
+	}
+	if len(subjects) == 0 {
+		delete(r.books, bookID)
+		return nil
+	}
+	r.books[bookID] = append([]entity.ID(nil), subjects...)
+	return nil
+}
+
+// Delete a subject, no book is filed under it any longer
+func (r *subjectInMemRepo) Delete(id entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[id] == nil {
+		return entity.ErrSubjectNotFound
+	}
+	for _, s := range r.m {
+		if s.Parent == id {
+			return entity.ErrSubjectCannotBeDeleted
+		}
+	}
+	delete(r.m, id)
+	for bookID, subjects := range r.books {
+		var kept []entity.ID
+		for _, s := range subjects {
+			if s != id {
+				kept = append(kept, s)
+			}
+		}
+		r.books[bookID] = kept
+	}
+	return nil
+}
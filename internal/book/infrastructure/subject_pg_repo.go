@@ -0,0 +1,277 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+type subjectPgRepo struct {
+	db       repository.Querier
+	transact func(fn func(tx *sqlx.Tx) error) error
+	log      *logger.Logger
+}
+
+// NewSubjectPgRepo create new subject postgres repo
+func NewSubjectPgRepo(s *server.Server) SubjectRepo {
+	return &subjectPgRepo{
+		db:       s.DB.Pg,
+		transact: s.DB.Transact,
+		log:      s.Log,
+	}
+}
+
+// NewSubjectTxPgRepo create a subject postgres repo writing through tx
+func NewSubjectTxPgRepo(tx *sqlx.Tx, l *logger.Logger) SubjectRepo {
+	return &subjectPgRepo{
+		db:       tx,
+		transact: repository.Within(tx),
+		log:      l,
+	}
+}
+
+// subjectColumns are selected in the order subjectRow scans them
+const subjectColumns = `id, name, parent_id, created_at, updated_at`
+
+type subjectRow struct {
+	ID        entity.ID `db:"id"`
+	Name      string    `db:"name"`
+	Parent    entity.ID `db:"parent_id"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (s subjectRow) subject() *entity.Subject {
+	return &entity.Subject{ID: s.ID, Name: s.Name, Parent: s.Parent, CreatedAt: s.CreatedAt, UpdatedAt: s.UpdatedAt}
+}
+
+// subjectError maps a missing parent or subject to ErrSubjectNotFound
+func subjectError(err error) error {
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "foreign_key_violation" {
+		return entity.ErrSubjectNotFound
+	}
+	return err
+}
+
+// Create a subject, a nil Parent is stored as null
+func (r *subjectPgRepo) Create(e *entity.Subject) (entity.ID, error) {
+	_, err := r.db.Exec(`insert into subject (id, name, parent_id, created_at, updated_at) values($1,$2,$3,$4,$4)`,
+		e.ID, e.Name, e.Parent, e.CreatedAt)
+	if err != nil {
+		return e.ID, subjectError(err)
+	}
+	return e.ID, nil
+}
+
+// Get a subject
+func (r *subjectPgRepo) Get(id entity.ID) (*entity.Subject, error) {
+	var rows []subjectRow
+	err := r.db.Select(&rows, `select `+subjectColumns+` from subject where id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, entity.ErrSubjectNotFound
+	}
+	return rows[0].subject(), nil
+}
+
+// Update a subject
+func (r *subjectPgRepo) Update(e *entity.Subject) error {
+	e.UpdatedAt = time.Now()
+	return r.transact(func(tx *sqlx.Tx) error {
+		// moves take turns so two of them cannot close a loop between them, reads go on
+		_, err := tx.Exec(`lock table subject in share row exclusive mode`)
+		if err != nil {
+			return err
+		}
+		if !e.Parent.IsNil() {
+			var cycle bool
+			err = tx.QueryRowx(`with recursive a (id) as (
+					select id from subject where id = $1
+					union
+					select s.parent_id from subject s join a on s.id = a.id where s.parent_id is not null
+				) select exists (select 1 from a where id = $2)`, e.Parent, e.ID).Scan(&cycle)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return entity.ErrSubjectCycle
+			}
+		}
+		res, err := tx.Exec(`update subject set name = $1, parent_id = $2, updated_at = $3 where id = $4`,
+			e.Name, e.Parent, e.UpdatedAt, e.ID)
+		if err != nil {
+			return subjectError(err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return entity.ErrSubjectNotFound
+		}
+		return nil
+	})
+}
+
+// List subjects
+func (r *subjectPgRepo) List(opts repository.ListOptions) ([]*entity.Subject, *repository.Page, error) {
+	column, ok := subjectSorts[opts.Sort]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from subject`).Scan(&page.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses(column, nil, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []subjectRow
+	err = r.db.Select(&rows, `select `+subjectColumns+` from subject where true`+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var subjects []*entity.Subject
+	for _, s := range rows {
+		subjects = append(subjects, s.subject())
+	}
+	if repository.HasNext(len(subjects), opts) {
+		subjects = subjects[:opts.Limit]
+		last := subjects[len(subjects)-1]
+		page.Next = repository.Cursor(last.ID.String(), subjectSortKey(last, opts.Sort))
+	}
+	return subjects, page, nil
+}
+
+// Descendants lists the subject and all the subjects below it, each just once
+func (r *subjectPgRepo) Descendants(id entity.ID) ([]entity.ID, error) {
+	var ids []entity.ID
+	err := r.db.Select(&ids, `with recursive d (id) as (
+			select id from subject where id = $1
+			union
+			select s.id from subject s join d on s.parent_id = d.id
+		) select id from d`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, entity.ErrSubjectNotFound
+	}
+	return ids, nil
+}
+
+// ListByBook lists the subjects of a book by name
+func (r *subjectPgRepo) ListByBook(bookID entity.ID) ([]*entity.Subject, error) {
+	var rows []subjectRow
+	err := r.db.Select(&rows, `select s.id, s.name, s.parent_id, s.created_at, s.updated_at
+		from subject s join book_subject bs on bs.subject_id = s.id
+		where bs.book_id = $1 order by lower(s.name), s.id`, bookID)
+	if err != nil {
+		return nil, err
+	}
+	var subjects []*entity.Subject
+	for _, s := range rows {
+		subjects = append(subjects, s.subject())
+	}
+	return subjects, nil
+}
+
+// ListByBooks lists the subjects of each of the books by name in one query
+func (r *subjectPgRepo) ListByBooks(bookIDs []entity.ID) (map[entity.ID][]*entity.Subject, error) {
+	ids := make([]string, len(bookIDs))
+	for i, id := range bookIDs {
+		ids[i] = id.String()
+	}
+	var rows []struct {
+		BookID entity.ID `db:"book_id"`
+		subjectRow
+	}
+	err := r.db.Select(&rows, `select bs.book_id, s.id, s.name, s.parent_id, s.created_at, s.updated_at
+		from subject s join book_subject bs on bs.subject_id = s.id
+		where bs.book_id = any($1::varchar[]) order by lower(s.name), s.id`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	d := map[entity.ID][]*entity.Subject{}
+	for _, s := range rows {
+		d[s.BookID] = append(d[s.BookID], s.subject())
+	}
+	return d, nil
+}
+
+// ListBooks lists the books of any of the subjects
+func (r *subjectPgRepo) ListBooks(subjects []entity.ID, opts repository.ListOptions) ([]entity.ID, *repository.Page, error) {
+	if opts.Sort != "" {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	ids := make([]string, len(subjects))
+	for i, s := range subjects {
+		ids[i] = s.String()
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(distinct book_id) from book_subject where subject_id = any($1::varchar[])`,
+		pq.Array(ids)).Scan(&page.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses("id", []interface{}{pq.Array(ids)}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var books []entity.ID
+	err = r.db.Select(&books, `select id from (select distinct book_id as id from book_subject
+		where subject_id = any($1::varchar[])) b where true`+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if repository.HasNext(len(books), opts) {
+		books = books[:opts.Limit]
+		page.Next = repository.Cursor(books[len(books)-1].String(), nil)
+	}
+	return books, page, nil
+}
+
+// SetBookSubjects replaces the subjects of a book
+func (r *subjectPgRepo) SetBookSubjects(bookID entity.ID, subjects []entity.ID) error {
+	ids := make([]string, len(subjects))
+	for i, s := range subjects {
+		ids[i] = s.String()
+	}
+	return r.transact(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(`delete from book_subject where book_id = $1`, bookID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`insert into book_subject (book_id, subject_id)
+			select $1, s.id from unnest($2::varchar[]) as s(id)`, bookID, pq.Array(ids))
+		return subjectError(err)
+	})
+}
+
+// Delete a subject, book_subject rows go with it. The subjects under it hold it back
+func (r *subjectPgRepo) Delete(id entity.ID) error {
+	res, err := r.db.Exec(`delete from subject where id = $1`, id)
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "foreign_key_violation" {
+		return entity.ErrSubjectCannotBeDeleted
+	}
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrSubjectNotFound
+	}
+	return nil
+}
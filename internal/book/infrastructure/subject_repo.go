@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+//go:generate mockgen -destination=../mock/subject_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure SubjectReader,SubjectWriter,SubjectRepo
+
+// SubjectReader interface, lists come a page at a time. Descendants holds the
+// subject itself, the books of subjects are listed by id and each just once
+type SubjectReader interface {
+	Get(id entity.ID) (*entity.Subject, error)
+	List(opts repository.ListOptions) ([]*entity.Subject, *repository.Page, error)
+	Descendants(id entity.ID) ([]entity.ID, error)
+	ListByBook(bookID entity.ID) ([]*entity.Subject, error)
+	ListByBooks(bookIDs []entity.ID) (map[entity.ID][]*entity.Subject, error)
+	ListBooks(subjects []entity.ID, opts repository.ListOptions) ([]entity.ID, *repository.Page, error)
+}
+
+// SubjectWriter interface, a subject is deleted only once no other is under it and
+// Update never moves a subject under one of the subjects below it
+type SubjectWriter interface {
+	Create(e *entity.Subject) (entity.ID, error)
+	Update(e *entity.Subject) error
+	Delete(id entity.ID) error
+	SetBookSubjects(bookID entity.ID, subjects []entity.ID) error
+}
+
+// SubjectRepo interface
+type SubjectRepo interface {
+	SubjectReader
+	SubjectWriter
+}
+
+// subjectSorts maps the fields subjects are sorted on to their column
+var subjectSorts = map[string]string{
+	"":           "id",
+	"name":       "lower(name)",
+	"created_at": "created_at",
+}
+
+// subjectSortKey is the value of a subject for the sort field
+func subjectSortKey(s *entity.Subject, field string) interface{} {
+	switch field {
+	case "name":
+		return strings.ToLower(s.Name)
+	case "created_at":
+		return s.CreatedAt
+	}
+	return nil
+}
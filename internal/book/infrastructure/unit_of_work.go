@@ -0,0 +1,16 @@
+package infrastructure
+
+//go:generate mockgen -destination=../mock/unit_of_work_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure UnitOfWork
+
+// Repos are the repositories a unit of work reads and writes through
+type Repos struct {
+	Books    BookRepo
+	Copies   CopyRepo
+	Subjects SubjectRepo
+	Credits  CreditWriter
+}
+
+// UnitOfWork runs fn so that its writes are applied all together or not at all
+type UnitOfWork interface {
+	Do(fn func(r *Repos) error) error
+}
@@ -0,0 +1,25 @@
+package infrastructure
+
+import (
+	"sync"
+)
+
+type inMemUnitOfWork struct {
+	mtx   sync.Mutex
+	repos *Repos
+}
+
+// NewInMemUnitOfWork create in memory unit of work, units run one at a time
+// but the writes of a failed unit are not rolled back
+func NewInMemUnitOfWork(r *Repos) UnitOfWork {
+	return &inMemUnitOfWork{
+		repos: r,
+	}
+}
+
+// Do run fn holding the lock
+func (u *inMemUnitOfWork) Do(fn func(r *Repos) error) error {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	return fn(u.repos)
+}
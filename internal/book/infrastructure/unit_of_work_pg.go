@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"github.com/jmoiron/sqlx"
+	authorInfra "github.com/sgraham785/gocleanarch-example/internal/author/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+type pgUnitOfWork struct {
+	db  *repository.Repository
+	log *logger.Logger
+}
+
+// NewPgUnitOfWork create new postgres unit of work
+func NewPgUnitOfWork(s *server.Server) UnitOfWork {
+	return &pgUnitOfWork{
+		db:  s.DB,
+		log: s.Log,
+	}
+}
+
+// Do run fn in a transaction, rolled back if fn fails
+func (u *pgUnitOfWork) Do(fn func(r *Repos) error) error {
+	return u.db.Transact(func(tx *sqlx.Tx) error {
+		return fn(&Repos{
+			Books:    NewTxPgRepo(tx, u.log),
+			Copies:   NewCopyTxPgRepo(tx, u.log),
+			Subjects: NewSubjectTxPgRepo(tx, u.log),
+			Credits:  authorInfra.NewTxPgRepo(tx, u.log),
+		})
+	})
+}
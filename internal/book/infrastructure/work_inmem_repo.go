@@ -0,0 +1,192 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+type workInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[entity.ID]*entity.Work
+}
+
+// NewWorkInMemRepo create work in memory repository
+func NewWorkInMemRepo() WorkRepo {
+	return &workInMemRepo{
+		m: map[entity.ID]*entity.Work{},
+	}
+}
+
+// Create a work
+func (r *workInMemRepo) Create(e *entity.Work) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	w := *e
+	r.m[e.ID] = &w
+	return e.ID, nil
+}
+
+// Get a work
+func (r *workInMemRepo) Get(id entity.ID) (*entity.Work, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrWorkNotFound
+	}
+	w := *r.m[id]
+	return &w, nil
+}
+
+// Update a work
+func (r *workInMemRepo) Update(e *entity.Work) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[e.ID] == nil {
+		return entity.ErrWorkNotFound
+	}
+	e.UpdatedAt = time.Now()
+	w := *e
+	r.m[e.ID] = &w
+	return nil
+}
+
+// List works
+func (r *workInMemRepo) List(opts repository.ListOptions) ([]*entity.Work, *repository.Page, error) {
+	if _, ok := workSorts[opts.Sort]; !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var works []*entity.Work
+	var rows []repository.Row
+	for _, w := range r.m {
+		works = append(works, w)
+		rows = append(rows, repository.Row{ID: w.ID.String(), Key: workSortKey(w, opts.Sort)})
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.Work
+	for _, i := range idx {
+		w := *works[i]
+		d = append(d, &w)
+	}
+	return d, page, nil
+}
+
+// ListBySeries lists the works of a series by position
+func (r *workInMemRepo) ListBySeries(seriesID entity.ID) ([]*entity.Work, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var d []*entity.Work
+	for _, w := range r.m {
+		if w.SeriesID == seriesID {
+			w := *w
+			d = append(d, &w)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool {
+		if d[i].Position != d[j].Position {
+			return d[i].Position < d[j].Position
+		}
+		return d[i].ID.String() < d[j].ID.String()
+	})
+	return d, nil
+}
+
+// Delete a work
+func (r *workInMemRepo) Delete(id entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[id] == nil {
+		return entity.ErrWorkNotFound
+	}
+	delete(r.m, id)
+	return nil
+}
+
+type seriesInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[entity.ID]*entity.Series
+}
+
+// NewSeriesInMemRepo create series in memory repository
+func NewSeriesInMemRepo() SeriesRepo {
+	return &seriesInMemRepo{
+		m: map[entity.ID]*entity.Series{},
+	}
+}
+
+// Create a series
+func (r *seriesInMemRepo) Create(e *entity.Series) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	s := *e
+	r.m[e.ID] = &s
+	return e.ID, nil
+}
+
+// Get a series
+func (r *seriesInMemRepo) Get(id entity.ID) (*entity.Series, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrSeriesNotFound
+	}
+	s := *r.m[id]
+	return &s, nil
+}
+
+// Update a series
+func (r *seriesInMemRepo) Update(e *entity.Series) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[e.ID] == nil {
+		return entity.ErrSeriesNotFound
+	}
+	e.UpdatedAt = time.Now()
+	s := *e
+	r.m[e.ID] = &s
+	return nil
+}
+
+// List series
+func (r *seriesInMemRepo) List(opts repository.ListOptions) ([]*entity.Series, *repository.Page, error) {
+	if _, ok := seriesSorts[opts.Sort]; !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var series []*entity.Series
+	var rows []repository.Row
+	for _, s := range r.m {
+		series = append(series, s)
+		rows = append(rows, repository.Row{ID: s.ID.String(), Key: seriesSortKey(s, opts.Sort)})
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.Series
+	for _, i := range idx {
+		s := *series[i]
+		d = append(d, &s)
+	}
+	return d, page, nil
+}
+
+// Delete a series
+func (r *seriesInMemRepo) Delete(id entity.ID) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.m[id] == nil {
+		return entity.ErrSeriesNotFound
+	}
+	delete(r.m, id)
+	return nil
+}
@@ -0,0 +1,273 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+// foreignKeyViolation tells if err is a reference to a missing row, or to a row still referenced
+func foreignKeyViolation(err error) bool {
+	e, ok := err.(*pq.Error)
+	return ok && e.Code.Name() == "foreign_key_violation"
+}
+
+type workPgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewWorkPgRepo create new work postgres repo
+func NewWorkPgRepo(s *server.Server) WorkRepo {
+	return &workPgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// workColumns are selected in the order workRow scans them
+const workColumns = `id, title, series_id, series_position, created_at, updated_at`
+
+type workRow struct {
+	ID        entity.ID `db:"id"`
+	Title     string    `db:"title"`
+	SeriesID  entity.ID `db:"series_id"`
+	Position  int       `db:"series_position"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (w workRow) work() *entity.Work {
+	return &entity.Work{ID: w.ID, Title: w.Title, SeriesID: w.SeriesID, Position: w.Position, CreatedAt: w.CreatedAt, UpdatedAt: w.UpdatedAt}
+}
+
+// Create a work, a nil SeriesID is stored as null
+func (r *workPgRepo) Create(e *entity.Work) (entity.ID, error) {
+	_, err := r.db.Exec(`insert into work (id, title, series_id, series_position, created_at, updated_at) values($1,$2,$3,$4,$5,$5)`,
+		e.ID, e.Title, e.SeriesID, e.Position, e.CreatedAt)
+	if foreignKeyViolation(err) {
+		return e.ID, entity.ErrSeriesNotFound
+	}
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get a work
+func (r *workPgRepo) Get(id entity.ID) (*entity.Work, error) {
+	var rows []workRow
+	err := r.db.Select(&rows, `select `+workColumns+` from work where id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, entity.ErrWorkNotFound
+	}
+	return rows[0].work(), nil
+}
+
+// Update a work
+func (r *workPgRepo) Update(e *entity.Work) error {
+	e.UpdatedAt = time.Now()
+	res, err := r.db.Exec(`update work set title = $1, series_id = $2, series_position = $3, updated_at = $4 where id = $5`,
+		e.Title, e.SeriesID, e.Position, e.UpdatedAt, e.ID)
+	if foreignKeyViolation(err) {
+		return entity.ErrSeriesNotFound
+	}
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrWorkNotFound
+	}
+	return nil
+}
+
+// List works
+func (r *workPgRepo) List(opts repository.ListOptions) ([]*entity.Work, *repository.Page, error) {
+	column, ok := workSorts[opts.Sort]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from work`).Scan(&page.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses(column, nil, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []workRow
+	err = r.db.Select(&rows, `select `+workColumns+` from work where true`+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var works []*entity.Work
+	for _, w := range rows {
+		works = append(works, w.work())
+	}
+	if repository.HasNext(len(works), opts) {
+		works = works[:opts.Limit]
+		last := works[len(works)-1]
+		page.Next = repository.Cursor(last.ID.String(), workSortKey(last, opts.Sort))
+	}
+	return works, page, nil
+}
+
+// ListBySeries lists the works of a series by position
+func (r *workPgRepo) ListBySeries(seriesID entity.ID) ([]*entity.Work, error) {
+	var rows []workRow
+	err := r.db.Select(&rows, `select `+workColumns+` from work where series_id = $1 order by series_position, id`, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	var works []*entity.Work
+	for _, w := range rows {
+		works = append(works, w.work())
+	}
+	return works, nil
+}
+
+// Delete a work, not while books are editions of it
+func (r *workPgRepo) Delete(id entity.ID) error {
+	res, err := r.db.Exec(`delete from work where id = $1`, id)
+	if foreignKeyViolation(err) {
+		return entity.ErrWorkCannotBeDeleted
+	}
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrWorkNotFound
+	}
+	return nil
+}
+
+type seriesPgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewSeriesPgRepo create new series postgres repo
+func NewSeriesPgRepo(s *server.Server) SeriesRepo {
+	return &seriesPgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// seriesColumns are selected in the order seriesRow scans them
+const seriesColumns = `id, name, created_at, updated_at`
+
+type seriesRow struct {
+	ID        entity.ID `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (s seriesRow) series() *entity.Series {
+	return &entity.Series{ID: s.ID, Name: s.Name, CreatedAt: s.CreatedAt, UpdatedAt: s.UpdatedAt}
+}
+
+// Create a series
+func (r *seriesPgRepo) Create(e *entity.Series) (entity.ID, error) {
+	_, err := r.db.Exec(`insert into series (id, name, created_at, updated_at) values($1,$2,$3,$3)`,
+		e.ID, e.Name, e.CreatedAt)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get a series
+func (r *seriesPgRepo) Get(id entity.ID) (*entity.Series, error) {
+	var rows []seriesRow
+	err := r.db.Select(&rows, `select `+seriesColumns+` from series where id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, entity.ErrSeriesNotFound
+	}
+	return rows[0].series(), nil
+}
+
+// Update a series
+func (r *seriesPgRepo) Update(e *entity.Series) error {
+	e.UpdatedAt = time.Now()
+	res, err := r.db.Exec(`update series set name = $1, updated_at = $2 where id = $3`, e.Name, e.UpdatedAt, e.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrSeriesNotFound
+	}
+	return nil
+}
+
+// List series
+func (r *seriesPgRepo) List(opts repository.ListOptions) ([]*entity.Series, *repository.Page, error) {
+	column, ok := seriesSorts[opts.Sort]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from series`).Scan(&page.Total)
+	if err != nil {
+		return nil, nil, err
+	}
+	clauses, args, err := repository.Clauses(column, nil, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []seriesRow
+	err = r.db.Select(&rows, `select `+seriesColumns+` from series where true`+clauses, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var series []*entity.Series
+	for _, s := range rows {
+		series = append(series, s.series())
+	}
+	if repository.HasNext(len(series), opts) {
+		series = series[:opts.Limit]
+		last := series[len(series)-1]
+		page.Next = repository.Cursor(last.ID.String(), seriesSortKey(last, opts.Sort))
+	}
+	return series, page, nil
+}
+
+// Delete a series, once its works are out of it
+func (r *seriesPgRepo) Delete(id entity.ID) error {
+	res, err := r.db.Exec(`delete from series where id = $1`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrSeriesNotFound
+	}
+	return nil
+}
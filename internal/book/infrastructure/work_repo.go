@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"strings"
+
+	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+//go:generate mockgen -destination=../mock/work_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/book/infrastructure WorkReader,WorkWriter,WorkRepo,SeriesReader,SeriesWriter,SeriesRepo
+
+// WorkReader interface, lists come a page at a time. The works of a series come in its order
+type WorkReader interface {
+	Get(id entity.ID) (*entity.Work, error)
+	List(opts repository.ListOptions) ([]*entity.Work, *repository.Page, error)
+	ListBySeries(seriesID entity.ID) ([]*entity.Work, error)
+}
+
+// WorkWriter interface
+type WorkWriter interface {
+	Create(e *entity.Work) (entity.ID, error)
+	Update(e *entity.Work) error
+	Delete(id entity.ID) error
+}
+
+// WorkRepo interface
+type WorkRepo interface {
+	WorkReader
+	WorkWriter
+}
+
+// SeriesReader interface, lists come a page at a time
+type SeriesReader interface {
+	Get(id entity.ID) (*entity.Series, error)
+	List(opts repository.ListOptions) ([]*entity.Series, *repository.Page, error)
+}
+
+// SeriesWriter interface, a series is deleted once no work is in it
+type SeriesWriter interface {
+	Create(e *entity.Series) (entity.ID, error)
+	Update(e *entity.Series) error
+	Delete(id entity.ID) error
+}
+
+// SeriesRepo interface
+type SeriesRepo interface {
+	SeriesReader
+	SeriesWriter
+}
+
+// workSorts maps the fields works are sorted on to their column
+var workSorts = map[string]string{
+	"":           "id",
+	"title":      "lower(title)",
+	"created_at": "created_at",
+}
+
+// workSortKey is the value of a work for the sort field
+func workSortKey(w *entity.Work, field string) interface{} {
+	switch field {
+	case "title":
+		return strings.ToLower(w.Title)
+	case "created_at":
+		return w.CreatedAt
+	}
+	return nil
+}
+
+// seriesSorts maps the fields series are sorted on to their column
+var seriesSorts = map[string]string{
+	"":           "id",
+	"name":       "lower(name)",
+	"created_at": "created_at",
+}
+
+// seriesSortKey is the value of a series for the sort field
+func seriesSortKey(s *entity.Series, field string) interface{} {
+	switch field {
+	case "name":
+		return strings.ToLower(s.Name)
+	case "created_at":
+		return s.CreatedAt
+	}
+	return nil
+}
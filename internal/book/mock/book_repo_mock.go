@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	xid "github.com/rs/xid"
 	entity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 // MockReader is a mock of Reader interface.
@@ -50,34 +51,66 @@ func (mr *MockReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), arg0)
 }
 
-// List mocks base method.
-func (m *MockReader) List() ([]*entity.Book, error) {
+// GetByISBN mocks base method.
+func (m *MockReader) GetByISBN(arg0 string) (*entity.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
-	ret0, _ := ret[0].([]*entity.Book)
+	ret := m.ctrl.Call(m, "GetByISBN", arg0)
+	ret0, _ := ret[0].(*entity.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetByISBN indicates an expected call of GetByISBN.
+func (mr *MockReaderMockRecorder) GetByISBN(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByISBN", reflect.TypeOf((*MockReader)(nil).GetByISBN), arg0)
+}
+
+// List mocks base method.
+func (m *MockReader) List(arg0 entity.Criteria, arg1 repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // List indicates an expected call of List.
-func (mr *MockReaderMockRecorder) List() *gomock.Call {
+func (mr *MockReaderMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), arg0, arg1)
 }
 
-// Search mocks base method.
-func (m *MockReader) Search(arg0 string) ([]*entity.Book, error) {
+// ListByWork mocks base method.
+func (m *MockReader) ListByWork(arg0 xid.ID) ([]*entity.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", arg0)
+	ret := m.ctrl.Call(m, "ListByWork", arg0)
 	ret0, _ := ret[0].([]*entity.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListByWork indicates an expected call of ListByWork.
+func (mr *MockReaderMockRecorder) ListByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWork", reflect.TypeOf((*MockReader)(nil).ListByWork), arg0)
+}
+
+// Search mocks base method.
+func (m *MockReader) Search(arg0 entity.Query, arg1 entity.Criteria, arg2 repository.ListOptions) ([]*entity.BookHit, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.BookHit)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Search indicates an expected call of Search.
-func (mr *MockReaderMockRecorder) Search(arg0 interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockReader)(nil).Search), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockReader)(nil).Search), arg0, arg1, arg2)
 }
 
 // MockWriter is a mock of Writer interface.
@@ -213,34 +246,66 @@ func (mr *MockBookRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBookRepo)(nil).Get), arg0)
 }
 
-// List mocks base method.
-func (m *MockBookRepo) List() ([]*entity.Book, error) {
+// GetByISBN mocks base method.
+func (m *MockBookRepo) GetByISBN(arg0 string) (*entity.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
-	ret0, _ := ret[0].([]*entity.Book)
+	ret := m.ctrl.Call(m, "GetByISBN", arg0)
+	ret0, _ := ret[0].(*entity.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetByISBN indicates an expected call of GetByISBN.
+func (mr *MockBookRepoMockRecorder) GetByISBN(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByISBN", reflect.TypeOf((*MockBookRepo)(nil).GetByISBN), arg0)
+}
+
+// List mocks base method.
+func (m *MockBookRepo) List(arg0 entity.Criteria, arg1 repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // List indicates an expected call of List.
-func (mr *MockBookRepoMockRecorder) List() *gomock.Call {
+func (mr *MockBookRepoMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookRepo)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookRepo)(nil).List), arg0, arg1)
 }
 
-// Search mocks base method.
-func (m *MockBookRepo) Search(arg0 string) ([]*entity.Book, error) {
+// ListByWork mocks base method.
+func (m *MockBookRepo) ListByWork(arg0 xid.ID) ([]*entity.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", arg0)
+	ret := m.ctrl.Call(m, "ListByWork", arg0)
 	ret0, _ := ret[0].([]*entity.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListByWork indicates an expected call of ListByWork.
+func (mr *MockBookRepoMockRecorder) ListByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByWork", reflect.TypeOf((*MockBookRepo)(nil).ListByWork), arg0)
+}
+
+// Search mocks base method.
+func (m *MockBookRepo) Search(arg0 entity.Query, arg1 entity.Criteria, arg2 repository.ListOptions) ([]*entity.BookHit, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.BookHit)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Search indicates an expected call of Search.
-func (mr *MockBookRepoMockRecorder) Search(arg0 interface{}) *gomock.Call {
+func (mr *MockBookRepoMockRecorder) Search(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookRepo)(nil).Search), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookRepo)(nil).Search), arg0, arg1, arg2)
 }
 
 // Update mocks base method.
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	xid "github.com/rs/xid"
 	entity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 // MockBookUseCase is a mock of BookUseCase interface.
@@ -35,19 +36,80 @@ func (m *MockBookUseCase) EXPECT() *MockBookUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AddCopy mocks base method.
+func (m *MockBookUseCase) AddCopy(arg0, arg1 string, arg2 entity.CopyCondition, arg3 string) (*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCopy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCopy indicates an expected call of AddCopy.
+func (mr *MockBookUseCaseMockRecorder) AddCopy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCopy", reflect.TypeOf((*MockBookUseCase)(nil).AddCopy), arg0, arg1, arg2, arg3)
+}
+
 // CreateBook mocks base method.
-func (m *MockBookUseCase) CreateBook(arg0, arg1 string, arg2, arg3 int) (xid.ID, error) {
+func (m *MockBookUseCase) CreateBook(arg0, arg1 string, arg2, arg3 int, arg4 string) (xid.ID, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBook", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateBook indicates an expected call of CreateBook.
+func (mr *MockBookUseCaseMockRecorder) CreateBook(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookUseCase)(nil).CreateBook), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateSeries mocks base method.
+func (m *MockBookUseCase) CreateSeries(arg0 string) (xid.ID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBook", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "CreateSeries", arg0)
 	ret0, _ := ret[0].(xid.ID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateBook indicates an expected call of CreateBook.
-func (mr *MockBookUseCaseMockRecorder) CreateBook(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockBookUseCaseMockRecorder) CreateSeries(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookUseCase)(nil).CreateBook), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockBookUseCase)(nil).CreateSeries), arg0)
+}
+
+// CreateSubject mocks base method.
+func (m *MockBookUseCase) CreateSubject(arg0, arg1 string) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubject", arg0, arg1)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubject indicates an expected call of CreateSubject.
+func (mr *MockBookUseCaseMockRecorder) CreateSubject(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubject", reflect.TypeOf((*MockBookUseCase)(nil).CreateSubject), arg0, arg1)
+}
+
+// CreateWork mocks base method.
+func (m *MockBookUseCase) CreateWork(arg0 string) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWork", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWork indicates an expected call of CreateWork.
+func (mr *MockBookUseCaseMockRecorder) CreateWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWork", reflect.TypeOf((*MockBookUseCase)(nil).CreateWork), arg0)
 }
 
 // DeleteBook mocks base method.
@@ -64,6 +126,77 @@ func (mr *MockBookUseCaseMockRecorder) DeleteBook(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookUseCase)(nil).DeleteBook), arg0)
 }
 
+// DeleteSeries mocks base method.
+func (m *MockBookUseCase) DeleteSeries(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockBookUseCaseMockRecorder) DeleteSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockBookUseCase)(nil).DeleteSeries), arg0)
+}
+
+// DeleteSubject mocks base method.
+func (m *MockBookUseCase) DeleteSubject(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubject", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubject indicates an expected call of DeleteSubject.
+func (mr *MockBookUseCaseMockRecorder) DeleteSubject(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubject", reflect.TypeOf((*MockBookUseCase)(nil).DeleteSubject), arg0)
+}
+
+// DeleteWork mocks base method.
+func (m *MockBookUseCase) DeleteWork(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWork", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWork indicates an expected call of DeleteWork.
+func (mr *MockBookUseCaseMockRecorder) DeleteWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWork", reflect.TypeOf((*MockBookUseCase)(nil).DeleteWork), arg0)
+}
+
+// EditBook mocks base method.
+func (m *MockBookUseCase) EditBook(arg0 string, arg1 int, arg2 *entity.BookUpdate) (*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditBook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditBook indicates an expected call of EditBook.
+func (mr *MockBookUseCaseMockRecorder) EditBook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditBook", reflect.TypeOf((*MockBookUseCase)(nil).EditBook), arg0, arg1, arg2)
+}
+
+// ExportBooks mocks base method.
+func (m *MockBookUseCase) ExportBooks(arg0 entity.Criteria, arg1 func(*entity.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBooks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportBooks indicates an expected call of ExportBooks.
+func (mr *MockBookUseCaseMockRecorder) ExportBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBooks", reflect.TypeOf((*MockBookUseCase)(nil).ExportBooks), arg0, arg1)
+}
+
 // GetBook mocks base method.
 func (m *MockBookUseCase) GetBook(arg0 string) (*entity.Book, error) {
 	m.ctrl.T.Helper()
@@ -79,34 +212,295 @@ func (mr *MockBookUseCaseMockRecorder) GetBook(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBook", reflect.TypeOf((*MockBookUseCase)(nil).GetBook), arg0)
 }
 
+// GetBookByISBN mocks base method.
+func (m *MockBookUseCase) GetBookByISBN(arg0 string) (*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookByISBN", arg0)
+	ret0, _ := ret[0].(*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookByISBN indicates an expected call of GetBookByISBN.
+func (mr *MockBookUseCaseMockRecorder) GetBookByISBN(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByISBN", reflect.TypeOf((*MockBookUseCase)(nil).GetBookByISBN), arg0)
+}
+
+// GetCopy mocks base method.
+func (m *MockBookUseCase) GetCopy(arg0 string) (*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCopy", arg0)
+	ret0, _ := ret[0].(*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCopy indicates an expected call of GetCopy.
+func (mr *MockBookUseCaseMockRecorder) GetCopy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCopy", reflect.TypeOf((*MockBookUseCase)(nil).GetCopy), arg0)
+}
+
+// GetSeries mocks base method.
+func (m *MockBookUseCase) GetSeries(arg0 string) (*entity.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries", arg0)
+	ret0, _ := ret[0].(*entity.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockBookUseCaseMockRecorder) GetSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockBookUseCase)(nil).GetSeries), arg0)
+}
+
+// GetSubject mocks base method.
+func (m *MockBookUseCase) GetSubject(arg0 string) (*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubject", arg0)
+	ret0, _ := ret[0].(*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubject indicates an expected call of GetSubject.
+func (mr *MockBookUseCaseMockRecorder) GetSubject(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubject", reflect.TypeOf((*MockBookUseCase)(nil).GetSubject), arg0)
+}
+
+// GetWork mocks base method.
+func (m *MockBookUseCase) GetWork(arg0 string) (*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWork", arg0)
+	ret0, _ := ret[0].(*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWork indicates an expected call of GetWork.
+func (mr *MockBookUseCaseMockRecorder) GetWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWork", reflect.TypeOf((*MockBookUseCase)(nil).GetWork), arg0)
+}
+
+// ImportBooks mocks base method.
+func (m *MockBookUseCase) ImportBooks(arg0 entity.RecordReader, arg1 bool) (*entity.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBooks", arg0, arg1)
+	ret0, _ := ret[0].(*entity.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBooks indicates an expected call of ImportBooks.
+func (mr *MockBookUseCaseMockRecorder) ImportBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBooks", reflect.TypeOf((*MockBookUseCase)(nil).ImportBooks), arg0, arg1)
+}
+
 // ListBooks mocks base method.
-func (m *MockBookUseCase) ListBooks() ([]*entity.Book, error) {
+func (m *MockBookUseCase) ListBooks(arg0 entity.Criteria, arg1 repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBooks")
+	ret := m.ctrl.Call(m, "ListBooks", arg0, arg1)
 	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBooks indicates an expected call of ListBooks.
+func (mr *MockBookUseCaseMockRecorder) ListBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookUseCase)(nil).ListBooks), arg0, arg1)
+}
+
+// ListCopies mocks base method.
+func (m *MockBookUseCase) ListCopies(arg0 string) ([]*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCopies", arg0)
+	ret0, _ := ret[0].([]*entity.Copy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListBooks indicates an expected call of ListBooks.
-func (mr *MockBookUseCaseMockRecorder) ListBooks() *gomock.Call {
+// ListCopies indicates an expected call of ListCopies.
+func (mr *MockBookUseCaseMockRecorder) ListCopies(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookUseCase)(nil).ListBooks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCopies", reflect.TypeOf((*MockBookUseCase)(nil).ListCopies), arg0)
 }
 
-// SearchBooks mocks base method.
-func (m *MockBookUseCase) SearchBooks(arg0 string) ([]*entity.Book, error) {
+// ListEditions mocks base method.
+func (m *MockBookUseCase) ListEditions(arg0 string) ([]*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEditions", arg0)
+	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEditions indicates an expected call of ListEditions.
+func (mr *MockBookUseCaseMockRecorder) ListEditions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEditions", reflect.TypeOf((*MockBookUseCase)(nil).ListEditions), arg0)
+}
+
+// ListSeries mocks base method.
+func (m *MockBookUseCase) ListSeries(arg0 repository.ListOptions) ([]*entity.Series, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", arg0)
+	ret0, _ := ret[0].([]*entity.Series)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockBookUseCaseMockRecorder) ListSeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockBookUseCase)(nil).ListSeries), arg0)
+}
+
+// ListSeriesWorks mocks base method.
+func (m *MockBookUseCase) ListSeriesWorks(arg0 string) ([]*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeriesWorks", arg0)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeriesWorks indicates an expected call of ListSeriesWorks.
+func (mr *MockBookUseCaseMockRecorder) ListSeriesWorks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeriesWorks", reflect.TypeOf((*MockBookUseCase)(nil).ListSeriesWorks), arg0)
+}
+
+// ListSubjectBooks mocks base method.
+func (m *MockBookUseCase) ListSubjectBooks(arg0 string, arg1 repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubjectBooks", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSubjectBooks indicates an expected call of ListSubjectBooks.
+func (mr *MockBookUseCaseMockRecorder) ListSubjectBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubjectBooks", reflect.TypeOf((*MockBookUseCase)(nil).ListSubjectBooks), arg0, arg1)
+}
+
+// ListSubjects mocks base method.
+func (m *MockBookUseCase) ListSubjects(arg0 repository.ListOptions) ([]*entity.Subject, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubjects", arg0)
+	ret0, _ := ret[0].([]*entity.Subject)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListSubjects indicates an expected call of ListSubjects.
+func (mr *MockBookUseCaseMockRecorder) ListSubjects(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubjects", reflect.TypeOf((*MockBookUseCase)(nil).ListSubjects), arg0)
+}
+
+// ListWorkEditions mocks base method.
+func (m *MockBookUseCase) ListWorkEditions(arg0 string) ([]*entity.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchBooks", arg0)
+	ret := m.ctrl.Call(m, "ListWorkEditions", arg0)
 	ret0, _ := ret[0].([]*entity.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// ListWorkEditions indicates an expected call of ListWorkEditions.
+func (mr *MockBookUseCaseMockRecorder) ListWorkEditions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkEditions", reflect.TypeOf((*MockBookUseCase)(nil).ListWorkEditions), arg0)
+}
+
+// ListWorks mocks base method.
+func (m *MockBookUseCase) ListWorks(arg0 repository.ListOptions) ([]*entity.Work, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorks", arg0)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWorks indicates an expected call of ListWorks.
+func (mr *MockBookUseCaseMockRecorder) ListWorks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorks", reflect.TypeOf((*MockBookUseCase)(nil).ListWorks), arg0)
+}
+
+// SearchBooks mocks base method.
+func (m *MockBookUseCase) SearchBooks(arg0 string, arg1 entity.Criteria, arg2 repository.ListOptions) ([]*entity.BookHit, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBooks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.BookHit)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // SearchBooks indicates an expected call of SearchBooks.
-func (mr *MockBookUseCaseMockRecorder) SearchBooks(arg0 interface{}) *gomock.Call {
+func (mr *MockBookUseCaseMockRecorder) SearchBooks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBookUseCase)(nil).SearchBooks), arg0, arg1, arg2)
+}
+
+// SetAuthorNames mocks base method.
+func (m *MockBookUseCase) SetAuthorNames(arg0 string, arg1 []string) (*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAuthorNames", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAuthorNames indicates an expected call of SetAuthorNames.
+func (mr *MockBookUseCaseMockRecorder) SetAuthorNames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuthorNames", reflect.TypeOf((*MockBookUseCase)(nil).SetAuthorNames), arg0, arg1)
+}
+
+// SetBookSubjects mocks base method.
+func (m *MockBookUseCase) SetBookSubjects(arg0 string, arg1 []string) ([]*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookSubjects", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookSubjects indicates an expected call of SetBookSubjects.
+func (mr *MockBookUseCaseMockRecorder) SetBookSubjects(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBookUseCase)(nil).SearchBooks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookSubjects", reflect.TypeOf((*MockBookUseCase)(nil).SetBookSubjects), arg0, arg1)
+}
+
+// SetBookWork mocks base method.
+func (m *MockBookUseCase) SetBookWork(arg0, arg1 string) (*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookWork", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookWork indicates an expected call of SetBookWork.
+func (mr *MockBookUseCaseMockRecorder) SetBookWork(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookWork", reflect.TypeOf((*MockBookUseCase)(nil).SetBookWork), arg0, arg1)
 }
 
 // UpdateBook mocks base method.
@@ -122,3 +516,63 @@ func (mr *MockBookUseCaseMockRecorder) UpdateBook(arg0 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBookUseCase)(nil).UpdateBook), arg0)
 }
+
+// UpdateCopy mocks base method.
+func (m *MockBookUseCase) UpdateCopy(arg0 string, arg1 entity.CopyCondition, arg2 string, arg3 entity.CopyStatus) (*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCopy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCopy indicates an expected call of UpdateCopy.
+func (mr *MockBookUseCaseMockRecorder) UpdateCopy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCopy", reflect.TypeOf((*MockBookUseCase)(nil).UpdateCopy), arg0, arg1, arg2, arg3)
+}
+
+// UpdateSeries mocks base method.
+func (m *MockBookUseCase) UpdateSeries(arg0, arg1 string) (*entity.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeries", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSeries indicates an expected call of UpdateSeries.
+func (mr *MockBookUseCaseMockRecorder) UpdateSeries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockBookUseCase)(nil).UpdateSeries), arg0, arg1)
+}
+
+// UpdateSubject mocks base method.
+func (m *MockBookUseCase) UpdateSubject(arg0, arg1, arg2 string) (*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubject", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubject indicates an expected call of UpdateSubject.
+func (mr *MockBookUseCaseMockRecorder) UpdateSubject(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubject", reflect.TypeOf((*MockBookUseCase)(nil).UpdateSubject), arg0, arg1, arg2)
+}
+
+// UpdateWork mocks base method.
+func (m *MockBookUseCase) UpdateWork(arg0, arg1, arg2 string, arg3 int) (*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWork", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWork indicates an expected call of UpdateWork.
+func (mr *MockBookUseCaseMockRecorder) UpdateWork(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWork", reflect.TypeOf((*MockBookUseCase)(nil).UpdateWork), arg0, arg1, arg2, arg3)
+}
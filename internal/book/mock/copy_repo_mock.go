@@ -0,0 +1,316 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/book/infrastructure (interfaces: CopyReader,CopyWriter,CopyRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+)
+
+// MockCopyReader is a mock of CopyReader interface.
+type MockCopyReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyReaderMockRecorder
+}
+
+// MockCopyReaderMockRecorder is the mock recorder for MockCopyReader.
+type MockCopyReaderMockRecorder struct {
+	mock *MockCopyReader
+}
+
+// NewMockCopyReader creates a new mock instance.
+func NewMockCopyReader(ctrl *gomock.Controller) *MockCopyReader {
+	mock := &MockCopyReader{ctrl: ctrl}
+	mock.recorder = &MockCopyReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyReader) EXPECT() *MockCopyReaderMockRecorder {
+	return m.recorder
+}
+
+// CountAvailable mocks base method.
+func (m *MockCopyReader) CountAvailable(arg0 []xid.ID) (map[xid.ID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvailable", arg0)
+	ret0, _ := ret[0].(map[xid.ID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvailable indicates an expected call of CountAvailable.
+func (mr *MockCopyReaderMockRecorder) CountAvailable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvailable", reflect.TypeOf((*MockCopyReader)(nil).CountAvailable), arg0)
+}
+
+// Get mocks base method.
+func (m *MockCopyReader) Get(arg0 string) (*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCopyReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCopyReader)(nil).Get), arg0)
+}
+
+// ListByBook mocks base method.
+func (m *MockCopyReader) ListByBook(arg0 xid.ID) ([]*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockCopyReaderMockRecorder) ListByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockCopyReader)(nil).ListByBook), arg0)
+}
+
+// LockByBook mocks base method.
+func (m *MockCopyReader) LockByBook(arg0 xid.ID) ([]*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByBook indicates an expected call of LockByBook.
+func (mr *MockCopyReaderMockRecorder) LockByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByBook", reflect.TypeOf((*MockCopyReader)(nil).LockByBook), arg0)
+}
+
+// MockCopyWriter is a mock of CopyWriter interface.
+type MockCopyWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyWriterMockRecorder
+}
+
+// MockCopyWriterMockRecorder is the mock recorder for MockCopyWriter.
+type MockCopyWriterMockRecorder struct {
+	mock *MockCopyWriter
+}
+
+// NewMockCopyWriter creates a new mock instance.
+func NewMockCopyWriter(ctrl *gomock.Controller) *MockCopyWriter {
+	mock := &MockCopyWriter{ctrl: ctrl}
+	mock.recorder = &MockCopyWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyWriter) EXPECT() *MockCopyWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCopyWriter) Create(arg0 *entity.Copy) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCopyWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCopyWriter)(nil).Create), arg0)
+}
+
+// DeleteByBook mocks base method.
+func (m *MockCopyWriter) DeleteByBook(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBook indicates an expected call of DeleteByBook.
+func (mr *MockCopyWriterMockRecorder) DeleteByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBook", reflect.TypeOf((*MockCopyWriter)(nil).DeleteByBook), arg0)
+}
+
+// Update mocks base method.
+func (m *MockCopyWriter) Update(arg0 *entity.Copy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCopyWriterMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCopyWriter)(nil).Update), arg0)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCopyWriter) UpdateStatus(arg0 *entity.Copy, arg1 entity.CopyStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCopyWriterMockRecorder) UpdateStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCopyWriter)(nil).UpdateStatus), arg0, arg1)
+}
+
+// MockCopyRepo is a mock of CopyRepo interface.
+type MockCopyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCopyRepoMockRecorder
+}
+
+// MockCopyRepoMockRecorder is the mock recorder for MockCopyRepo.
+type MockCopyRepoMockRecorder struct {
+	mock *MockCopyRepo
+}
+
+// NewMockCopyRepo creates a new mock instance.
+func NewMockCopyRepo(ctrl *gomock.Controller) *MockCopyRepo {
+	mock := &MockCopyRepo{ctrl: ctrl}
+	mock.recorder = &MockCopyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCopyRepo) EXPECT() *MockCopyRepoMockRecorder {
+	return m.recorder
+}
+
+// CountAvailable mocks base method.
+func (m *MockCopyRepo) CountAvailable(arg0 []xid.ID) (map[xid.ID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvailable", arg0)
+	ret0, _ := ret[0].(map[xid.ID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvailable indicates an expected call of CountAvailable.
+func (mr *MockCopyRepoMockRecorder) CountAvailable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvailable", reflect.TypeOf((*MockCopyRepo)(nil).CountAvailable), arg0)
+}
+
+// Create mocks base method.
+func (m *MockCopyRepo) Create(arg0 *entity.Copy) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCopyRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCopyRepo)(nil).Create), arg0)
+}
+
+// DeleteByBook mocks base method.
+func (m *MockCopyRepo) DeleteByBook(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByBook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByBook indicates an expected call of DeleteByBook.
+func (mr *MockCopyRepoMockRecorder) DeleteByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByBook", reflect.TypeOf((*MockCopyRepo)(nil).DeleteByBook), arg0)
+}
+
+// Get mocks base method.
+func (m *MockCopyRepo) Get(arg0 string) (*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCopyRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCopyRepo)(nil).Get), arg0)
+}
+
+// ListByBook mocks base method.
+func (m *MockCopyRepo) ListByBook(arg0 xid.ID) ([]*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockCopyRepoMockRecorder) ListByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockCopyRepo)(nil).ListByBook), arg0)
+}
+
+// LockByBook mocks base method.
+func (m *MockCopyRepo) LockByBook(arg0 xid.ID) ([]*entity.Copy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Copy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByBook indicates an expected call of LockByBook.
+func (mr *MockCopyRepoMockRecorder) LockByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByBook", reflect.TypeOf((*MockCopyRepo)(nil).LockByBook), arg0)
+}
+
+// Update mocks base method.
+func (m *MockCopyRepo) Update(arg0 *entity.Copy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCopyRepoMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCopyRepo)(nil).Update), arg0)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockCopyRepo) UpdateStatus(arg0 *entity.Copy, arg1 entity.CopyStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockCopyRepoMockRecorder) UpdateStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockCopyRepo)(nil).UpdateStatus), arg0, arg1)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/book/infrastructure (interfaces: CreditWriter)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+)
+
+// MockCreditWriter is a mock of CreditWriter interface.
+type MockCreditWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditWriterMockRecorder
+}
+
+// MockCreditWriterMockRecorder is the mock recorder for MockCreditWriter.
+type MockCreditWriterMockRecorder struct {
+	mock *MockCreditWriter
+}
+
+// NewMockCreditWriter creates a new mock instance.
+func NewMockCreditWriter(ctrl *gomock.Controller) *MockCreditWriter {
+	mock := &MockCreditWriter{ctrl: ctrl}
+	mock.recorder = &MockCreditWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditWriter) EXPECT() *MockCreditWriterMockRecorder {
+	return m.recorder
+}
+
+// CreditBook mocks base method.
+func (m *MockCreditWriter) CreditBook(arg0 xid.ID, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditBook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditBook indicates an expected call of CreditBook.
+func (mr *MockCreditWriterMockRecorder) CreditBook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditBook", reflect.TypeOf((*MockCreditWriter)(nil).CreditBook), arg0, arg1)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/book/infrastructure (interfaces: HoldCounter)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+)
+
+// MockHoldCounter is a mock of HoldCounter interface.
+type MockHoldCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldCounterMockRecorder
+}
+
+// MockHoldCounterMockRecorder is the mock recorder for MockHoldCounter.
+type MockHoldCounterMockRecorder struct {
+	mock *MockHoldCounter
+}
+
+// NewMockHoldCounter creates a new mock instance.
+func NewMockHoldCounter(ctrl *gomock.Controller) *MockHoldCounter {
+	mock := &MockHoldCounter{ctrl: ctrl}
+	mock.recorder = &MockHoldCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldCounter) EXPECT() *MockHoldCounterMockRecorder {
+	return m.recorder
+}
+
+// CountOpenByBook mocks base method.
+func (m *MockHoldCounter) CountOpenByBook(arg0 xid.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByBook", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByBook indicates an expected call of CountOpenByBook.
+func (mr *MockHoldCounterMockRecorder) CountOpenByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByBook", reflect.TypeOf((*MockHoldCounter)(nil).CountOpenByBook), arg0)
+}
+
+// CountOpenByWork mocks base method.
+func (m *MockHoldCounter) CountOpenByWork(arg0 xid.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByWork", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByWork indicates an expected call of CountOpenByWork.
+func (mr *MockHoldCounterMockRecorder) CountOpenByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByWork", reflect.TypeOf((*MockHoldCounter)(nil).CountOpenByWork), arg0)
+}
@@ -0,0 +1,381 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/book/infrastructure (interfaces: SubjectReader,SubjectWriter,SubjectRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+// MockSubjectReader is a mock of SubjectReader interface.
+type MockSubjectReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectReaderMockRecorder
+}
+
+// MockSubjectReaderMockRecorder is the mock recorder for MockSubjectReader.
+type MockSubjectReaderMockRecorder struct {
+	mock *MockSubjectReader
+}
+
+// NewMockSubjectReader creates a new mock instance.
+func NewMockSubjectReader(ctrl *gomock.Controller) *MockSubjectReader {
+	mock := &MockSubjectReader{ctrl: ctrl}
+	mock.recorder = &MockSubjectReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubjectReader) EXPECT() *MockSubjectReaderMockRecorder {
+	return m.recorder
+}
+
+// Descendants mocks base method.
+func (m *MockSubjectReader) Descendants(arg0 xid.ID) ([]xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Descendants", arg0)
+	ret0, _ := ret[0].([]xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Descendants indicates an expected call of Descendants.
+func (mr *MockSubjectReaderMockRecorder) Descendants(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Descendants", reflect.TypeOf((*MockSubjectReader)(nil).Descendants), arg0)
+}
+
+// Get mocks base method.
+func (m *MockSubjectReader) Get(arg0 xid.ID) (*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSubjectReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubjectReader)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockSubjectReader) List(arg0 repository.ListOptions) ([]*entity.Subject, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Subject)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSubjectReaderMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSubjectReader)(nil).List), arg0)
+}
+
+// ListBooks mocks base method.
+func (m *MockSubjectReader) ListBooks(arg0 []xid.ID, arg1 repository.ListOptions) ([]xid.ID, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooks", arg0, arg1)
+	ret0, _ := ret[0].([]xid.ID)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBooks indicates an expected call of ListBooks.
+func (mr *MockSubjectReaderMockRecorder) ListBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockSubjectReader)(nil).ListBooks), arg0, arg1)
+}
+
+// ListByBook mocks base method.
+func (m *MockSubjectReader) ListByBook(arg0 xid.ID) ([]*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockSubjectReaderMockRecorder) ListByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockSubjectReader)(nil).ListByBook), arg0)
+}
+
+// ListByBooks mocks base method.
+func (m *MockSubjectReader) ListByBooks(arg0 []xid.ID) (map[xid.ID][]*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBooks", arg0)
+	ret0, _ := ret[0].(map[xid.ID][]*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBooks indicates an expected call of ListByBooks.
+func (mr *MockSubjectReaderMockRecorder) ListByBooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBooks", reflect.TypeOf((*MockSubjectReader)(nil).ListByBooks), arg0)
+}
+
+// MockSubjectWriter is a mock of SubjectWriter interface.
+type MockSubjectWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectWriterMockRecorder
+}
+
+// MockSubjectWriterMockRecorder is the mock recorder for MockSubjectWriter.
+type MockSubjectWriterMockRecorder struct {
+	mock *MockSubjectWriter
+}
+
+// NewMockSubjectWriter creates a new mock instance.
+func NewMockSubjectWriter(ctrl *gomock.Controller) *MockSubjectWriter {
+	mock := &MockSubjectWriter{ctrl: ctrl}
+	mock.recorder = &MockSubjectWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubjectWriter) EXPECT() *MockSubjectWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubjectWriter) Create(arg0 *entity.Subject) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubjectWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubjectWriter)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockSubjectWriter) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubjectWriterMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubjectWriter)(nil).Delete), arg0)
+}
+
+// SetBookSubjects mocks base method.
+func (m *MockSubjectWriter) SetBookSubjects(arg0 xid.ID, arg1 []xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookSubjects", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookSubjects indicates an expected call of SetBookSubjects.
+func (mr *MockSubjectWriterMockRecorder) SetBookSubjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookSubjects", reflect.TypeOf((*MockSubjectWriter)(nil).SetBookSubjects), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockSubjectWriter) Update(arg0 *entity.Subject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubjectWriterMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubjectWriter)(nil).Update), arg0)
+}
+
+// MockSubjectRepo is a mock of SubjectRepo interface.
+type MockSubjectRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubjectRepoMockRecorder
+}
+
+// MockSubjectRepoMockRecorder is the mock recorder for MockSubjectRepo.
+type MockSubjectRepoMockRecorder struct {
+	mock *MockSubjectRepo
+}
+
+// NewMockSubjectRepo creates a new mock instance.
+func NewMockSubjectRepo(ctrl *gomock.Controller) *MockSubjectRepo {
+	mock := &MockSubjectRepo{ctrl: ctrl}
+	mock.recorder = &MockSubjectRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubjectRepo) EXPECT() *MockSubjectRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSubjectRepo) Create(arg0 *entity.Subject) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSubjectRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubjectRepo)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockSubjectRepo) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSubjectRepoMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubjectRepo)(nil).Delete), arg0)
+}
+
+// Descendants mocks base method.
+func (m *MockSubjectRepo) Descendants(arg0 xid.ID) ([]xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Descendants", arg0)
+	ret0, _ := ret[0].([]xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Descendants indicates an expected call of Descendants.
+func (mr *MockSubjectRepoMockRecorder) Descendants(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Descendants", reflect.TypeOf((*MockSubjectRepo)(nil).Descendants), arg0)
+}
+
+// Get mocks base method.
+func (m *MockSubjectRepo) Get(arg0 xid.ID) (*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSubjectRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSubjectRepo)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockSubjectRepo) List(arg0 repository.ListOptions) ([]*entity.Subject, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Subject)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSubjectRepoMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSubjectRepo)(nil).List), arg0)
+}
+
+// ListBooks mocks base method.
+func (m *MockSubjectRepo) ListBooks(arg0 []xid.ID, arg1 repository.ListOptions) ([]xid.ID, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooks", arg0, arg1)
+	ret0, _ := ret[0].([]xid.ID)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListBooks indicates an expected call of ListBooks.
+func (mr *MockSubjectRepoMockRecorder) ListBooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockSubjectRepo)(nil).ListBooks), arg0, arg1)
+}
+
+// ListByBook mocks base method.
+func (m *MockSubjectRepo) ListByBook(arg0 xid.ID) ([]*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockSubjectRepoMockRecorder) ListByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockSubjectRepo)(nil).ListByBook), arg0)
+}
+
+// ListByBooks mocks base method.
+func (m *MockSubjectRepo) ListByBooks(arg0 []xid.ID) (map[xid.ID][]*entity.Subject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBooks", arg0)
+	ret0, _ := ret[0].(map[xid.ID][]*entity.Subject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBooks indicates an expected call of ListByBooks.
+func (mr *MockSubjectRepoMockRecorder) ListByBooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBooks", reflect.TypeOf((*MockSubjectRepo)(nil).ListByBooks), arg0)
+}
+
+// SetBookSubjects mocks base method.
+func (m *MockSubjectRepo) SetBookSubjects(arg0 xid.ID, arg1 []xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookSubjects", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBookSubjects indicates an expected call of SetBookSubjects.
+func (mr *MockSubjectRepoMockRecorder) SetBookSubjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookSubjects", reflect.TypeOf((*MockSubjectRepo)(nil).SetBookSubjects), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockSubjectRepo) Update(arg0 *entity.Subject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSubjectRepoMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubjectRepo)(nil).Update), arg0)
+}
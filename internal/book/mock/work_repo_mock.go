@@ -0,0 +1,478 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/book/infrastructure (interfaces: WorkReader,WorkWriter,WorkRepo,SeriesReader,SeriesWriter,SeriesRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+// MockWorkReader is a mock of WorkReader interface.
+type MockWorkReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkReaderMockRecorder
+}
+
+// MockWorkReaderMockRecorder is the mock recorder for MockWorkReader.
+type MockWorkReaderMockRecorder struct {
+	mock *MockWorkReader
+}
+
+// NewMockWorkReader creates a new mock instance.
+func NewMockWorkReader(ctrl *gomock.Controller) *MockWorkReader {
+	mock := &MockWorkReader{ctrl: ctrl}
+	mock.recorder = &MockWorkReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkReader) EXPECT() *MockWorkReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockWorkReader) Get(arg0 xid.ID) (*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWorkReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWorkReader)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockWorkReader) List(arg0 repository.ListOptions) ([]*entity.Work, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockWorkReaderMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWorkReader)(nil).List), arg0)
+}
+
+// ListBySeries mocks base method.
+func (m *MockWorkReader) ListBySeries(arg0 xid.ID) ([]*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySeries", arg0)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySeries indicates an expected call of ListBySeries.
+func (mr *MockWorkReaderMockRecorder) ListBySeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySeries", reflect.TypeOf((*MockWorkReader)(nil).ListBySeries), arg0)
+}
+
+// MockWorkWriter is a mock of WorkWriter interface.
+type MockWorkWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkWriterMockRecorder
+}
+
+// MockWorkWriterMockRecorder is the mock recorder for MockWorkWriter.
+type MockWorkWriterMockRecorder struct {
+	mock *MockWorkWriter
+}
+
+// NewMockWorkWriter creates a new mock instance.
+func NewMockWorkWriter(ctrl *gomock.Controller) *MockWorkWriter {
+	mock := &MockWorkWriter{ctrl: ctrl}
+	mock.recorder = &MockWorkWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkWriter) EXPECT() *MockWorkWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWorkWriter) Create(arg0 *entity.Work) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkWriter)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockWorkWriter) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkWriterMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkWriter)(nil).Delete), arg0)
+}
+
+// Update mocks base method.
+func (m *MockWorkWriter) Update(arg0 *entity.Work) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWorkWriterMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWorkWriter)(nil).Update), arg0)
+}
+
+// MockWorkRepo is a mock of WorkRepo interface.
+type MockWorkRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkRepoMockRecorder
+}
+
+// MockWorkRepoMockRecorder is the mock recorder for MockWorkRepo.
+type MockWorkRepoMockRecorder struct {
+	mock *MockWorkRepo
+}
+
+// NewMockWorkRepo creates a new mock instance.
+func NewMockWorkRepo(ctrl *gomock.Controller) *MockWorkRepo {
+	mock := &MockWorkRepo{ctrl: ctrl}
+	mock.recorder = &MockWorkRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkRepo) EXPECT() *MockWorkRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWorkRepo) Create(arg0 *entity.Work) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkRepo)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockWorkRepo) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWorkRepoMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWorkRepo)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockWorkRepo) Get(arg0 xid.ID) (*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockWorkRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWorkRepo)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockWorkRepo) List(arg0 repository.ListOptions) ([]*entity.Work, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockWorkRepoMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWorkRepo)(nil).List), arg0)
+}
+
+// ListBySeries mocks base method.
+func (m *MockWorkRepo) ListBySeries(arg0 xid.ID) ([]*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBySeries", arg0)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBySeries indicates an expected call of ListBySeries.
+func (mr *MockWorkRepoMockRecorder) ListBySeries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySeries", reflect.TypeOf((*MockWorkRepo)(nil).ListBySeries), arg0)
+}
+
+// Update mocks base method.
+func (m *MockWorkRepo) Update(arg0 *entity.Work) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWorkRepoMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWorkRepo)(nil).Update), arg0)
+}
+
+// MockSeriesReader is a mock of SeriesReader interface.
+type MockSeriesReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesReaderMockRecorder
+}
+
+// MockSeriesReaderMockRecorder is the mock recorder for MockSeriesReader.
+type MockSeriesReaderMockRecorder struct {
+	mock *MockSeriesReader
+}
+
+// NewMockSeriesReader creates a new mock instance.
+func NewMockSeriesReader(ctrl *gomock.Controller) *MockSeriesReader {
+	mock := &MockSeriesReader{ctrl: ctrl}
+	mock.recorder = &MockSeriesReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesReader) EXPECT() *MockSeriesReaderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSeriesReader) Get(arg0 xid.ID) (*entity.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSeriesReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSeriesReader)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockSeriesReader) List(arg0 repository.ListOptions) ([]*entity.Series, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Series)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSeriesReaderMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSeriesReader)(nil).List), arg0)
+}
+
+// MockSeriesWriter is a mock of SeriesWriter interface.
+type MockSeriesWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesWriterMockRecorder
+}
+
+// MockSeriesWriterMockRecorder is the mock recorder for MockSeriesWriter.
+type MockSeriesWriterMockRecorder struct {
+	mock *MockSeriesWriter
+}
+
+// NewMockSeriesWriter creates a new mock instance.
+func NewMockSeriesWriter(ctrl *gomock.Controller) *MockSeriesWriter {
+	mock := &MockSeriesWriter{ctrl: ctrl}
+	mock.recorder = &MockSeriesWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesWriter) EXPECT() *MockSeriesWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSeriesWriter) Create(arg0 *entity.Series) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesWriter)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockSeriesWriter) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesWriterMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeriesWriter)(nil).Delete), arg0)
+}
+
+// Update mocks base method.
+func (m *MockSeriesWriter) Update(arg0 *entity.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesWriterMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesWriter)(nil).Update), arg0)
+}
+
+// MockSeriesRepo is a mock of SeriesRepo interface.
+type MockSeriesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesRepoMockRecorder
+}
+
+// MockSeriesRepoMockRecorder is the mock recorder for MockSeriesRepo.
+type MockSeriesRepoMockRecorder struct {
+	mock *MockSeriesRepo
+}
+
+// NewMockSeriesRepo creates a new mock instance.
+func NewMockSeriesRepo(ctrl *gomock.Controller) *MockSeriesRepo {
+	mock := &MockSeriesRepo{ctrl: ctrl}
+	mock.recorder = &MockSeriesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesRepo) EXPECT() *MockSeriesRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSeriesRepo) Create(arg0 *entity.Series) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesRepo)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockSeriesRepo) Delete(arg0 xid.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesRepoMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeriesRepo)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockSeriesRepo) Get(arg0 xid.ID) (*entity.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSeriesRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSeriesRepo)(nil).Get), arg0)
+}
+
+// List mocks base method.
+func (m *MockSeriesRepo) List(arg0 repository.ListOptions) ([]*entity.Series, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.Series)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSeriesRepoMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSeriesRepo)(nil).List), arg0)
+}
+
+// Update mocks base method.
+func (m *MockSeriesRepo) Update(arg0 *entity.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesRepoMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesRepo)(nil).Update), arg0)
+}
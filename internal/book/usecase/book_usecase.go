@@ -1,12 +1,16 @@
 package usecase
 
 import (
+	"fmt"
+	"io"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 )
 
@@ -15,33 +19,246 @@ import (
 // BookUseCase is the interface that provides the methods.
 type BookUseCase interface {
 	GetBook(id string) (*entity.Book, error)
-	SearchBooks(query string) ([]*entity.Book, error)
-	ListBooks() ([]*entity.Book, error)
-	CreateBook(title string, author string, pages int, quantity int) (entity.ID, error)
+	GetBookByISBN(isbn string) (*entity.Book, error)
+	SearchBooks(query string, c entity.Criteria, opts repository.ListOptions) ([]*entity.BookHit, *repository.Page, error)
+	ListBooks(c entity.Criteria, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error)
+	CreateBook(title string, author string, pages int, quantity int, isbn string) (id entity.ID, merged bool, err error)
+	ImportBooks(src entity.RecordReader, dryRun bool) (*entity.ImportReport, error)
+	ExportBooks(c entity.Criteria, each func(b *entity.Book) error) error
 	UpdateBook(e *entity.Book) error
+	EditBook(id string, version int, p *entity.BookUpdate) (*entity.Book, error)
 	DeleteBook(id string) error
+	SetAuthorNames(bookID string, names []string) (*entity.Book, error)
+	AddCopy(bookID string, barcode string, condition entity.CopyCondition, location string) (*entity.Copy, error)
+	GetCopy(barcode string) (*entity.Copy, error)
+	ListCopies(bookID string) ([]*entity.Copy, error)
+	UpdateCopy(barcode string, condition entity.CopyCondition, location string, status entity.CopyStatus) (*entity.Copy, error)
+	GetSubject(id string) (*entity.Subject, error)
+	ListSubjects(opts repository.ListOptions) ([]*entity.Subject, *repository.Page, error)
+	CreateSubject(name string, parent string) (entity.ID, error)
+	UpdateSubject(id string, name string, parent string) (*entity.Subject, error)
+	DeleteSubject(id string) error
+	ListSubjectBooks(id string, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error)
+	SetBookSubjects(bookID string, subjectIDs []string) ([]*entity.Subject, error)
+	GetWork(id string) (*entity.Work, error)
+	ListWorks(opts repository.ListOptions) ([]*entity.Work, *repository.Page, error)
+	CreateWork(title string) (entity.ID, error)
+	UpdateWork(id string, title string, seriesID string, position int) (*entity.Work, error)
+	DeleteWork(id string) error
+	ListWorkEditions(id string) ([]*entity.Book, error)
+	ListEditions(bookID string) ([]*entity.Book, error)
+	SetBookWork(bookID string, workID string) (*entity.Book, error)
+	GetSeries(id string) (*entity.Series, error)
+	ListSeries(opts repository.ListOptions) ([]*entity.Series, *repository.Page, error)
+	CreateSeries(name string) (entity.ID, error)
+	UpdateSeries(id string, name string) (*entity.Series, error)
+	DeleteSeries(id string) error
+	ListSeriesWorks(id string) ([]*entity.Work, error)
 }
 
 type bookUseCase struct {
-	repo infrastructure.BookRepo
-	log  *logger.Logger
+	repo        infrastructure.BookRepo
+	copyRepo    infrastructure.CopyRepo
+	subjectRepo infrastructure.SubjectRepo
+	workRepo    infrastructure.WorkRepo
+	seriesRepo  infrastructure.SeriesRepo
+	holds       infrastructure.HoldCounter
+	uow         infrastructure.UnitOfWork
+	log         *logger.Logger
 }
 
-// New create new book usecase
-func New(s *server.Server, r infrastructure.BookRepo) BookUseCase {
+// New create new book usecase, hc tells the works and books patrons are waiting for.
+// A book is written with its copies and its credits to its authors in uow
+func New(s *server.Server, r infrastructure.BookRepo, c infrastructure.CopyRepo, sr infrastructure.SubjectRepo,
+	w infrastructure.WorkRepo, se infrastructure.SeriesRepo, hc infrastructure.HoldCounter, uow infrastructure.UnitOfWork) BookUseCase {
 	return &bookUseCase{
-		repo: r,
-		log:  s.Log,
+		repo:        r,
+		copyRepo:    c,
+		subjectRepo: sr,
+		workRepo:    w,
+		seriesRepo:  se,
+		holds:       hc,
+		uow:         uow,
+		log:         s.Log,
 	}
 }
 
-// CreateBook create a book
-func (u *bookUseCase) CreateBook(title string, author string, pages int, quantity int) (entity.ID, error) {
+// CreateBook create a book with quantity new copies. A book whose ISBN is
+// already catalogued gets the new copies instead, its id is returned and merged is true
+func (u *bookUseCase) CreateBook(title string, author string, pages int, quantity int, isbn string) (entity.ID, bool, error) {
 	b, err := entity.New(title, author, pages, quantity)
 	if err != nil {
-		return b.ID, err
+		return entity.ID{}, false, err
 	}
-	return u.repo.Create(b)
+	if b.SetISBN(isbn) != nil {
+		return entity.ID{}, false, entity.ErrInvalidBookEntity
+	}
+	var id entity.ID
+	merged := false
+	err = u.uow.Do(func(r *infrastructure.Repos) error {
+		if b.ISBN != "" {
+			existing, err := r.Books.GetByISBN(b.ISBN)
+			switch err {
+			case nil:
+				id, merged = existing.ID, true
+				return addCopies(r, id, quantity)
+			case entity.ErrBookNotFound:
+			default:
+				return err
+			}
+		}
+		id, err = r.Books.Create(b)
+		if err != nil {
+			return err
+		}
+		err = r.Credits.CreditBook(id, b.AuthorNames())
+		if err != nil {
+			return err
+		}
+		return addCopies(r, id, quantity)
+	})
+	if err == entity.ErrISBNTaken {
+		// another cataloguer scanned the same ISBN meanwhile
+		err = u.uow.Do(func(r *infrastructure.Repos) error {
+			existing, err := r.Books.GetByISBN(b.ISBN)
+			if err != nil {
+				return err
+			}
+			id, merged = existing.ID, true
+			return addCopies(r, id, quantity)
+		})
+	}
+	if err != nil {
+		return entity.ID{}, false, err
+	}
+	return id, merged, nil
+}
+
+// ImportBooks upserts the books a catalog file holds and reports what became of every record.
+// A record whose ISBN is catalogued updates the metadata of that book, or is skipped when
+// they already agree, and never adds copies. A dry run validates the records and tells
+// what the import would do without writing anything.
+func (u *bookUseCase) ImportBooks(src entity.RecordReader, dryRun bool) (*entity.ImportReport, error) {
+	report := &entity.ImportReport{DryRun: dryRun}
+	seen := map[string]int{}
+	for {
+		rec, err := src.Read()
+		if err == io.EOF {
+			return report, nil
+		}
+		if err != nil {
+			return report, err
+		}
+		res, err := u.importRecord(rec, seen, dryRun)
+		if err != nil {
+			return report, err
+		}
+		report.Add(res)
+	}
+}
+
+// importRecord upserts the book of a record, seen holds the row of every ISBN met so far
+func (u *bookUseCase) importRecord(rec *entity.ImportRecord, seen map[string]int, dryRun bool) (entity.ImportResult, error) {
+	res := entity.ImportResult{Row: rec.Row, Status: entity.ImportInvalid}
+	if rec.Err != nil {
+		res.Error = rec.Err.Error()
+		return res, nil
+	}
+	res.ISBN, res.Title = rec.Book.ISBN, rec.Book.Title
+	b, err := entity.New(rec.Book.Title, rec.Book.Author, rec.Book.Pages, rec.Book.Quantity)
+	if err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	if err = b.SetISBN(rec.Book.ISBN); err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	b.Language = rec.Book.Language
+	b.PublishedAt = rec.Book.PublishedAt
+	b.Tags = entity.NormalizeTags(rec.Book.Tags)
+	if err = b.Validate(); err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	res.ISBN = b.ISBN
+	if b.ISBN != "" {
+		if row, ok := seen[b.ISBN]; ok {
+			res.Status = entity.ImportSkipped
+			res.Error = fmt.Sprintf("ISBN already on row %d", row)
+			return res, nil
+		}
+		seen[b.ISBN] = rec.Row
+		existing, err := u.repo.GetByISBN(b.ISBN)
+		switch err {
+		case nil:
+			return u.importUpdate(res, existing, b, dryRun)
+		case entity.ErrBookNotFound:
+		default:
+			return res, err
+		}
+	}
+	res.Status = entity.ImportCreated
+	res.BookID = b.ID
+	if dryRun {
+		return res, nil
+	}
+	err = u.uow.Do(func(r *infrastructure.Repos) error {
+		_, err := r.Books.Create(b)
+		if err != nil {
+			return err
+		}
+		err = r.Credits.CreditBook(b.ID, b.AuthorNames())
+		if err != nil {
+			return err
+		}
+		return addCopies(r, b.ID, b.Quantity)
+	})
+	return res, err
+}
+
+// importUpdate brings the metadata of a catalogued book in line with the record,
+// the publication date and tags the record leaves out are kept
+func (u *bookUseCase) importUpdate(res entity.ImportResult, existing *entity.Book, b *entity.Book, dryRun bool) (entity.ImportResult, error) {
+	res.BookID = existing.ID
+	p := &entity.BookUpdate{Title: &b.Title, Author: &b.Author, Pages: &b.Pages}
+	if b.Language != "" {
+		p.Language = &b.Language
+	}
+	if !b.PublishedAt.IsZero() {
+		p.PublishedAt = &b.PublishedAt
+	}
+	if len(b.Tags) > 0 {
+		p.Tags = &b.Tags
+	}
+	updated := *existing
+	p.Apply(&updated)
+	if updated.Title == existing.Title && updated.Author == existing.Author && updated.Pages == existing.Pages &&
+		updated.Language == existing.Language && updated.PublishedAt.Equal(existing.PublishedAt) &&
+		reflect.DeepEqual(updated.Tags, existing.Tags) {
+		res.Status = entity.ImportSkipped
+		return res, nil
+	}
+	res.Status = entity.ImportUpdated
+	if dryRun {
+		return res, nil
+	}
+	return res, u.UpdateBook(&updated)
+}
+
+// addCopies puts quantity new copies of a book on the shelf
+func addCopies(r *infrastructure.Repos, id entity.ID, quantity int) error {
+	for i := 0; i < quantity; i++ {
+		c, err := entity.NewCopy(id, "", entity.ConditionNew, "")
+		if err != nil {
+			return err
+		}
+		_, err = r.Copies.Create(c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetBook get a book
@@ -55,50 +272,630 @@ func (u *bookUseCase) GetBook(id string) (*entity.Book, error) {
 		return nil, err
 	}
 
+	err = u.withDetails(b)
+	if err != nil {
+		return nil, err
+	}
 	return b, nil
 }
 
-// SearchBooks search books
-func (u *bookUseCase) SearchBooks(query string) ([]*entity.Book, error) {
-	books, err := u.repo.Search(strings.ToLower(query))
+// GetBookByISBN get a book by its ISBN-10 or ISBN-13
+func (u *bookUseCase) GetBookByISBN(isbn string) (*entity.Book, error) {
+	n, err := entity.NormalizeISBN(isbn)
 	if err != nil {
 		return nil, err
 	}
-	if len(books) == 0 {
-		return nil, entity.ErrBookNotFound
+	b, err := u.repo.GetByISBN(n)
+	if err != nil {
+		return nil, err
 	}
-	return books, nil
+	err = u.withDetails(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
 }
 
-// ListBooks list books
-func (u *bookUseCase) ListBooks() ([]*entity.Book, error) {
-	books, err := u.repo.List()
+// SearchBooks search a page of the books meeting the criteria by title and author,
+// the best matches first. The query takes words, "quoted phrases" and prefixes like ozz*
+func (u *bookUseCase) SearchBooks(query string, c entity.Criteria, opts repository.ListOptions) ([]*entity.BookHit, *repository.Page, error) {
+	q := entity.ParseQuery(query)
+	if len(q) == 0 {
+		return nil, &repository.Page{}, entity.ErrBookNotFound
+	}
+	hits, page, err := u.repo.Search(q, c, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if len(hits) == 0 {
+		return nil, page, entity.ErrBookNotFound
 	}
+	for _, h := range hits {
+		err = u.withDetails(&h.Book)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return hits, page, nil
+}
+
+// ListBooks list a page of the books meeting the criteria
+func (u *bookUseCase) ListBooks(c entity.Criteria, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	books, page, err := u.repo.List(c, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return u.withAllDetails(books, page)
+}
+
+// exportPageSize is how many books an export reads at a time
+const exportPageSize = 500
+
+// ExportBooks hands every book meeting the criteria to each, by order of id,
+// reading the catalog a page at a time. It stops at the first error each returns.
+func (u *bookUseCase) ExportBooks(c entity.Criteria, each func(b *entity.Book) error) error {
+	return repository.EachPage(exportPageSize, func(opts repository.ListOptions) (*repository.Page, error) {
+		books, page, err := u.repo.List(c, opts)
+		if err != nil {
+			return nil, err
+		}
+		err = u.withPageDetails(books)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range books {
+			err = each(b)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return page, nil
+	})
+}
+
+// withAllDetails counts the copies available of a page of books and reads their subjects
+func (u *bookUseCase) withAllDetails(books []*entity.Book, page *repository.Page) ([]*entity.Book, *repository.Page, error) {
 	if len(books) == 0 {
-		return nil, entity.ErrBookNotFound
+		return nil, page, entity.ErrBookNotFound
+	}
+	err := u.withPageDetails(books)
+	if err != nil {
+		return nil, nil, err
 	}
-	return books, nil
+	return books, page, nil
 }
 
-// DeleteBook Delete a book
-func (u *bookUseCase) DeleteBook(id string) error {
-	_, err := u.GetBook(id)
+// withPageDetails does what withDetails does for a whole page of books, one query for the
+// copies and one for the subjects
+func (u *bookUseCase) withPageDetails(books []*entity.Book) error {
+	ids := make([]entity.ID, len(books))
+	for i, b := range books {
+		ids[i] = b.ID
+	}
+	available, err := u.copyRepo.CountAvailable(ids)
 	if err != nil {
 		return err
 	}
+	subjects, err := u.subjectRepo.ListByBooks(ids)
+	if err != nil {
+		return err
+	}
+	for _, b := range books {
+		b.Quantity = available[b.ID]
+		b.Subjects = subjects[b.ID]
+	}
+	return nil
+}
 
-	bID, _ := entity.IDFromString(id)
-	return u.repo.Delete(bID)
+// DeleteBook Delete a book with its copies, subjects and credits, all in one unit. Not while
+// a copy is lent, the copies are locked so none goes out meanwhile, nor while a hold waits for it
+func (u *bookUseCase) DeleteBook(id string) error {
+	b, err := u.GetBook(id)
+	if err != nil {
+		return err
+	}
+	return u.uow.Do(func(r *infrastructure.Repos) error {
+		copies, err := r.Copies.LockByBook(b.ID)
+		if err != nil {
+			return err
+		}
+		for _, c := range copies {
+			if c.Status == entity.CopyOnLoan {
+				return entity.ErrBookCannotBeDeleted
+			}
+		}
+		holds, err := u.holds.CountOpenByBook(b.ID)
+		if err != nil {
+			return err
+		}
+		if holds > 0 {
+			return entity.ErrBookCannotBeDeleted
+		}
+		err = r.Copies.DeleteByBook(b.ID)
+		if err != nil {
+			return err
+		}
+		err = r.Subjects.SetBookSubjects(b.ID, nil)
+		if err != nil {
+			return err
+		}
+		err = r.Credits.CreditBook(b.ID, nil)
+		if err != nil {
+			return err
+		}
+		return r.Books.Delete(b.ID)
+	})
 }
 
-// UpdateBook Update a book
+// UpdateBook Update a book, a new author credits it to the authors it names
 func (u *bookUseCase) UpdateBook(e *entity.Book) error {
 	err := e.Validate()
 	if err != nil {
 		return err
 	}
-	e.UpdatedAt = time.Now()
-	return u.repo.Update(e)
+	return u.uow.Do(func(r *infrastructure.Repos) error {
+		stored, err := r.Books.Get(e.ID)
+		if err != nil {
+			return err
+		}
+		e.UpdatedAt = time.Now()
+		err = r.Books.Update(e)
+		if err != nil || stored.Author == e.Author {
+			return err
+		}
+		return r.Credits.CreditBook(e.ID, e.AuthorNames())
+	})
+}
+
+// SetAuthorNames writes the names of the authors a book is credited to as its author,
+// once the author module changed them. The credits stay as they are.
+func (u *bookUseCase) SetAuthorNames(bookID string, names []string) (*entity.Book, error) {
+	b, err := u.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	b.Author = entity.JoinAuthors(names)
+	err = b.Validate()
+	if err != nil {
+		return nil, err
+	}
+	b.UpdatedAt = time.Now()
+	err = u.repo.Update(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// EditBook corrects the metadata of a book at version, a zero version edits whatever is current
+func (u *bookUseCase) EditBook(id string, version int, p *entity.BookUpdate) (*entity.Book, error) {
+	b, err := u.GetBook(id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && version != b.Version {
+		return nil, entity.ErrBookVersionConflict
+	}
+	p.Apply(b)
+	err = u.UpdateBook(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// AddCopy shelves a new copy of a book, a barcode is generated when empty
+func (u *bookUseCase) AddCopy(bookID string, barcode string, condition entity.CopyCondition, location string) (*entity.Copy, error) {
+	b, err := u.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	c, err := entity.NewCopy(b.ID, barcode, condition, location)
+	if err != nil {
+		return nil, err
+	}
+	_, err = u.copyRepo.Create(c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetCopy get a copy by barcode
+func (u *bookUseCase) GetCopy(barcode string) (*entity.Copy, error) {
+	return u.copyRepo.Get(barcode)
+}
+
+// ListCopies list the copies of a book
+func (u *bookUseCase) ListCopies(bookID string) ([]*entity.Copy, error) {
+	b, err := u.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	return u.copyRepo.ListByBook(b.ID)
+}
+
+// UpdateCopy records the condition, location or status of a copy, empty values are left as they are
+func (u *bookUseCase) UpdateCopy(barcode string, condition entity.CopyCondition, location string, status entity.CopyStatus) (*entity.Copy, error) {
+	c, err := u.copyRepo.Get(barcode)
+	if err != nil {
+		return nil, err
+	}
+	if condition != "" {
+		err = c.SetCondition(condition)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if status != "" && status != c.Status {
+		err = c.SetStatus(status)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if location != "" {
+		c.Location = location
+	}
+	err = u.copyRepo.Update(c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetSubject get a subject
+func (u *bookUseCase) GetSubject(id string) (*entity.Subject, error) {
+	sID, err := entity.IDFromString(id)
+	if err != nil {
+		return nil, entity.ErrSubjectNotFound
+	}
+	return u.subjectRepo.Get(sID)
+}
+
+// ListSubjects lists a page of subjects, each tells the one above it
+func (u *bookUseCase) ListSubjects(opts repository.ListOptions) ([]*entity.Subject, *repository.Page, error) {
+	return u.subjectRepo.List(opts)
+}
+
+// CreateSubject create a subject under parent, at the top when parent is empty
+func (u *bookUseCase) CreateSubject(name string, parent string) (entity.ID, error) {
+	pID, err := u.subjectParent(parent)
+	if err != nil {
+		return entity.ID{}, err
+	}
+	s, err := entity.NewSubject(name, pID)
+	if err != nil {
+		return entity.ID{}, err
+	}
+	return u.subjectRepo.Create(s)
+}
+
+// UpdateSubject renames a subject and moves it under parent, never under a subject below it
+func (u *bookUseCase) UpdateSubject(id string, name string, parent string) (*entity.Subject, error) {
+	s, err := u.GetSubject(id)
+	if err != nil {
+		return nil, err
+	}
+	pID, err := u.subjectParent(parent)
+	if err != nil {
+		return nil, err
+	}
+	s.Name, s.Parent = strings.TrimSpace(name), pID
+	err = s.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = u.subjectRepo.Update(s)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSubject Delete a subject once no other is under it, its books stay in the catalog
+func (u *bookUseCase) DeleteSubject(id string) error {
+	s, err := u.GetSubject(id)
+	if err != nil {
+		return err
+	}
+	return u.subjectRepo.Delete(s.ID)
+}
+
+// ListSubjectBooks lists a page of the books of a subject and of all the subjects below it
+func (u *bookUseCase) ListSubjectBooks(id string, opts repository.ListOptions) ([]*entity.Book, *repository.Page, error) {
+	s, err := u.GetSubject(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	subjects, err := u.subjectRepo.Descendants(s.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids, page, err := u.subjectRepo.ListBooks(subjects, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var books []*entity.Book
+	for _, bookID := range ids {
+		b, err := u.GetBook(bookID.String())
+		if err == entity.ErrBookNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		books = append(books, b)
+	}
+	return books, page, nil
+}
+
+// SetBookSubjects files a book under subjects in place of its former ones
+func (u *bookUseCase) SetBookSubjects(bookID string, subjectIDs []string) ([]*entity.Subject, error) {
+	b, err := u.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []entity.ID
+	seen := map[entity.ID]bool{}
+	for _, id := range subjectIDs {
+		s, err := u.GetSubject(id)
+		if err != nil {
+			return nil, err
+		}
+		if seen[s.ID] {
+			continue
+		}
+		seen[s.ID] = true
+		ids = append(ids, s.ID)
+	}
+	err = u.subjectRepo.SetBookSubjects(b.ID, ids)
+	if err != nil {
+		return nil, err
+	}
+	return u.subjectRepo.ListByBook(b.ID)
+}
+
+// subjectParent reads the id of the parent of a subject, the nil ID when empty.
+// A parent that cannot be found makes the subject invalid
+func (u *bookUseCase) subjectParent(parent string) (entity.ID, error) {
+	if parent == "" {
+		return entity.ID{}, nil
+	}
+	p, err := u.GetSubject(parent)
+	if err == entity.ErrSubjectNotFound {
+		return entity.ID{}, entity.ErrInvalidSubjectEntity
+	}
+	if err != nil {
+		return entity.ID{}, err
+	}
+	return p.ID, nil
+}
+
+// GetWork get a work
+func (u *bookUseCase) GetWork(id string) (*entity.Work, error) {
+	wID, err := entity.IDFromString(id)
+	if err != nil {
+		return nil, entity.ErrWorkNotFound
+	}
+	return u.workRepo.Get(wID)
+}
+
+// ListWorks lists a page of works
+func (u *bookUseCase) ListWorks(opts repository.ListOptions) ([]*entity.Work, *repository.Page, error) {
+	return u.workRepo.List(opts)
+}
+
+// CreateWork create a work, in no series
+func (u *bookUseCase) CreateWork(title string) (entity.ID, error) {
+	w, err := entity.NewWork(title)
+	if err != nil {
+		return entity.ID{}, err
+	}
+	return u.workRepo.Create(w)
+}
+
+// UpdateWork retitles a work and places it in a series at position, an empty seriesID takes it out
+func (u *bookUseCase) UpdateWork(id string, title string, seriesID string, position int) (*entity.Work, error) {
+	w, err := u.GetWork(id)
+	if err != nil {
+		return nil, err
+	}
+	var sID entity.ID
+	if seriesID != "" {
+		s, err := u.GetSeries(seriesID)
+		if err != nil {
+			return nil, err
+		}
+		sID = s.ID
+	}
+	w.Title = strings.TrimSpace(title)
+	err = w.SetSeries(sID, position)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = u.workRepo.Update(w)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// DeleteWork Delete a work once no book is an edition of it and no patron waits for it
+func (u *bookUseCase) DeleteWork(id string) error {
+	w, err := u.GetWork(id)
+	if err != nil {
+		return err
+	}
+	editions, err := u.repo.ListByWork(w.ID)
+	if err != nil {
+		return err
+	}
+	if len(editions) > 0 {
+		return entity.ErrWorkCannotBeDeleted
+	}
+	holds, err := u.holds.CountOpenByWork(w.ID)
+	if err != nil {
+		return err
+	}
+	if holds > 0 {
+		return entity.ErrWorkCannotBeDeleted
+	}
+	return u.workRepo.Delete(w.ID)
+}
+
+// ListWorkEditions lists the editions of a work, the oldest first
+func (u *bookUseCase) ListWorkEditions(id string) ([]*entity.Book, error) {
+	w, err := u.GetWork(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.editions(w.ID, entity.ID{})
+}
+
+// ListEditions lists the other editions of the work a book is an edition of,
+// none when the book is not grouped under a work
+func (u *bookUseCase) ListEditions(bookID string) ([]*entity.Book, error) {
+	b, err := u.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if b.WorkID.IsNil() {
+		return nil, nil
+	}
+	return u.editions(b.WorkID, b.ID)
+}
+
+// editions lists the editions of a work but the book skip, with their quantities
+func (u *bookUseCase) editions(workID entity.ID, skip entity.ID) ([]*entity.Book, error) {
+	books, err := u.repo.ListByWork(workID)
+	if err != nil {
+		return nil, err
+	}
+	var d []*entity.Book
+	for _, b := range books {
+		if b.ID == skip {
+			continue
+		}
+		err = u.withDetails(b)
+		if err != nil {
+			return nil, err
+		}
+		d = append(d, b)
+	}
+	return d, nil
+}
+
+// SetBookWork makes a book an edition of a work, an empty workID ungroups it
+func (u *bookUseCase) SetBookWork(bookID string, workID string) (*entity.Book, error) {
+	b, err := u.GetBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+	var wID entity.ID
+	if workID != "" {
+		w, err := u.GetWork(workID)
+		if err != nil {
+			return nil, err
+		}
+		wID = w.ID
+	}
+	b.WorkID = wID
+	err = u.UpdateBook(b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetSeries get a series
+func (u *bookUseCase) GetSeries(id string) (*entity.Series, error) {
+	sID, err := entity.IDFromString(id)
+	if err != nil {
+		return nil, entity.ErrSeriesNotFound
+	}
+	return u.seriesRepo.Get(sID)
+}
+
+// ListSeries lists a page of series
+func (u *bookUseCase) ListSeries(opts repository.ListOptions) ([]*entity.Series, *repository.Page, error) {
+	return u.seriesRepo.List(opts)
+}
+
+// CreateSeries create a series
+func (u *bookUseCase) CreateSeries(name string) (entity.ID, error) {
+	s, err := entity.NewSeries(name)
+	if err != nil {
+		return entity.ID{}, err
+	}
+	return u.seriesRepo.Create(s)
+}
+
+// UpdateSeries renames a series
+func (u *bookUseCase) UpdateSeries(id string, name string) (*entity.Series, error) {
+	s, err := u.GetSeries(id)
+	if err != nil {
+		return nil, err
+	}
+	s.Name = strings.TrimSpace(name)
+	err = s.Validate()
+	if err != nil {
+		return nil, err
+	}
+	err = u.seriesRepo.Update(s)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSeries Delete a series, its works stay in the catalog out of any series
+func (u *bookUseCase) DeleteSeries(id string) error {
+	s, err := u.GetSeries(id)
+	if err != nil {
+		return err
+	}
+	works, err := u.workRepo.ListBySeries(s.ID)
+	if err != nil {
+		return err
+	}
+	for _, w := range works {
+		err = w.SetSeries(entity.ID{}, 0)
+		if err != nil {
+			return err
+		}
+		err = u.workRepo.Update(w)
+		if err != nil {
+			return err
+		}
+	}
+	return u.seriesRepo.Delete(s.ID)
+}
+
+// ListSeriesWorks lists the works of a series in order
+func (u *bookUseCase) ListSeriesWorks(id string) ([]*entity.Work, error) {
+	s, err := u.GetSeries(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.workRepo.ListBySeries(s.ID)
+}
+
+// withDetails counts the copies of the book on the shelf and reads its subjects
+func (u *bookUseCase) withDetails(b *entity.Book) error {
+	copies, err := u.copyRepo.ListByBook(b.ID)
+	if err != nil {
+		return err
+	}
+	b.Quantity = 0
+	for _, c := range copies {
+		if c.Available() {
+			b.Quantity++
+		}
+	}
+	b.Subjects, err = u.subjectRepo.ListByBook(b.ID)
+	return err
 }
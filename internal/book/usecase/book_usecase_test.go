@@ -1,17 +1,31 @@
 package usecase_test
 
 import (
+	"fmt"
+	"io"
 	"testing"
 	"time"
 
+	authorInfra "github.com/sgraham785/gocleanarch-example/internal/author/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/internal/book/usecase"
+	borrowEntity "github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	borrowInfra "github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 	"github.com/stretchr/testify/assert"
 )
 
+// newBookUseCase wires the use case over the in memory repos, its unit of work writes through the same ones
+func newBookUseCase(s *server.Server, r infrastructure.BookRepo, copies infrastructure.CopyRepo, subjects infrastructure.SubjectRepo,
+	works infrastructure.WorkRepo, series infrastructure.SeriesRepo, credits infrastructure.CreditWriter, holds infrastructure.HoldCounter) usecase.BookUseCase {
+	uow := infrastructure.NewInMemUnitOfWork(&infrastructure.Repos{Books: r, Copies: copies, Subjects: subjects, Credits: credits})
+	return usecase.New(s, r, copies, subjects, works, series, holds, uow)
+}
+
 func newFixtureBook() *entity.Book {
 	return &entity.Book{
 		Title:     "I Am Ozzy",
@@ -23,48 +37,54 @@ func newFixtureBook() *entity.Book {
 }
 
 func Test_bookUseCase_CreateBook(t *testing.T) {
-	r := infrastructure.NewInMemRepo()
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
 	logger := logger.New()
 	defer logger.Zap.Sync()
 	s := &server.Server{
 		Log: logger,
 	}
-	m := usecase.New(s, r)
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
 	u := newFixtureBook()
-	_, err := m.CreateBook(u.Title, u.Author, u.Pages, u.Quantity)
+	_, _, err := m.CreateBook(u.Title, u.Author, u.Pages, u.Quantity, "")
 	assert.Nil(t, err)
 	assert.False(t, u.CreatedAt.IsZero())
 }
 
 func Test_bookUseCase_SearchBooks(t *testing.T) {
-	r := infrastructure.NewInMemRepo()
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
 	logger := logger.New()
 	defer logger.Zap.Sync()
 	s := &server.Server{
 		Log: logger,
 	}
-	m := usecase.New(s, r)
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
 	u1 := newFixtureBook()
 	u2 := newFixtureBook()
 	u2.Title = "Lemmy: Biography"
+	u2.Author = "Lemmy Kilmister"
 
-	uID, _ := m.CreateBook(u1.Title, u1.Author, u1.Pages, u1.Quantity)
-	_, _ = m.CreateBook(u2.Title, u2.Author, u2.Pages, u2.Quantity)
+	uID, _, _ := m.CreateBook(u1.Title, u1.Author, u1.Pages, u1.Quantity, "")
+	_, _, _ = m.CreateBook(u2.Title, u2.Author, u2.Pages, u2.Quantity, "")
 
 	t.Run("search", func(t *testing.T) {
-		c, err := m.SearchBooks("ozzy")
+		c, page, err := m.SearchBooks("ozzy", entity.Criteria{}, repository.ListOptions{})
 		assert.Nil(t, err)
+		assert.Equal(t, 1, page.Total)
 		assert.Equal(t, 1, len(c))
 		assert.Equal(t, "I Am Ozzy", c[0].Title)
 
-		c, err = m.SearchBooks("dio")
+		c, _, err = m.SearchBooks("dio", entity.Criteria{}, repository.ListOptions{})
 		assert.Equal(t, entity.ErrBookNotFound, err)
 		assert.Nil(t, c)
 	})
 	t.Run("list all", func(t *testing.T) {
-		all, err := m.ListBooks()
+		all, page, err := m.ListBooks(entity.Criteria{}, repository.ListOptions{})
 		assert.Nil(t, err)
 		assert.Equal(t, 2, len(all))
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, "", page.Next)
 	})
 
 	t.Run("get", func(t *testing.T) {
@@ -75,15 +95,16 @@ func Test_bookUseCase_SearchBooks(t *testing.T) {
 }
 
 func Test_bookUseCase_UpdateBook(t *testing.T) {
-	r := infrastructure.NewInMemRepo()
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
 	logger := logger.New()
 	defer logger.Zap.Sync()
 	s := &server.Server{
 		Log: logger,
 	}
-	m := usecase.New(s, r)
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
 	u := newFixtureBook()
-	id, err := m.CreateBook(u.Title, u.Author, u.Pages, u.Quantity)
+	id, _, err := m.CreateBook(u.Title, u.Author, u.Pages, u.Quantity, "")
 	assert.Nil(t, err)
 	saved, _ := m.GetBook(id.String())
 	saved.Title = "Lemmy: Biography"
@@ -94,22 +115,634 @@ func Test_bookUseCase_UpdateBook(t *testing.T) {
 }
 
 func Test_bookUseCase_DeleteBook(t *testing.T) {
-	r := infrastructure.NewInMemRepo()
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
 	logger := logger.New()
 	defer logger.Zap.Sync()
 	s := &server.Server{
 		Log: logger,
 	}
-	m := usecase.New(s, r)
+	holds := borrowInfra.NewHoldInMemRepo()
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), holds)
 	u1 := newFixtureBook()
 	u2 := newFixtureBook()
-	u2ID, _ := m.CreateBook(u2.Title, u2.Author, u2.Pages, u2.Quantity)
+	u2ID, _, _ := m.CreateBook(u2.Title, u2.Author, u2.Pages, u2.Quantity, "")
 
 	err := m.DeleteBook(u1.ID.String())
 	assert.Equal(t, entity.ErrBookNotFound, err)
 
+	h, _ := borrowEntity.NewHold(userEntity.NewID(), u2ID)
+	_, _ = holds.Create(h)
+	err = m.DeleteBook(u2ID.String())
+	assert.Equal(t, entity.ErrBookCannotBeDeleted, err)
+	_ = h.Fulfill()
+	_ = holds.Update(h)
+
 	err = m.DeleteBook(u2ID.String())
 	assert.Nil(t, err)
 	_, err = m.GetBook(u2ID.String())
 	assert.Equal(t, entity.ErrBookNotFound, err)
 }
+
+func Test_bookUseCase_Copies(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	b := newFixtureBook()
+	id, _, _ := m.CreateBook(b.Title, b.Author, b.Pages, 2, "")
+
+	t.Run("created with the book", func(t *testing.T) {
+		copies, err := m.ListCopies(id.String())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(copies))
+		saved, _ := m.GetBook(id.String())
+		assert.Equal(t, 2, saved.Quantity)
+	})
+	t.Run("add", func(t *testing.T) {
+		c, err := m.AddCopy(id.String(), "LIB-0001", entity.ConditionGood, "Music A-12")
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyAvailable, c.Status)
+		_, err = m.AddCopy(id.String(), "LIB-0001", entity.ConditionGood, "")
+		assert.Equal(t, entity.ErrBarcodeTaken, err)
+		_, err = m.AddCopy(entity.NewID().String(), "LIB-0002", entity.ConditionGood, "")
+		assert.Equal(t, entity.ErrBookNotFound, err)
+		saved, _ := m.GetBook(id.String())
+		assert.Equal(t, 3, saved.Quantity)
+	})
+	t.Run("damaged copies are not counted", func(t *testing.T) {
+		c, err := m.UpdateCopy("LIB-0001", entity.ConditionDamaged, "Repair desk", "")
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyInRepair, c.Status)
+		assert.Equal(t, "Repair desk", c.Location)
+		saved, _ := m.GetBook(id.String())
+		assert.Equal(t, 2, saved.Quantity)
+
+		_, err = m.UpdateCopy("LIB-0001", "", "", entity.CopyAvailable)
+		assert.Equal(t, entity.ErrCopyNotAvailable, err)
+		c, err = m.UpdateCopy("LIB-0001", entity.ConditionGood, "Music A-12", entity.CopyAvailable)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyAvailable, c.Status)
+	})
+	t.Run("cannot delete a book with a copy out", func(t *testing.T) {
+		c, _ := m.GetCopy("LIB-0001")
+		_ = c.CheckOut()
+		_ = copies.UpdateStatus(c, entity.CopyAvailable)
+		err := m.DeleteBook(id.String())
+		assert.Equal(t, entity.ErrBookCannotBeDeleted, err)
+		_, err = m.UpdateCopy("LIB-0001", "", "", entity.CopyLost)
+		assert.Equal(t, entity.ErrCopyOnLoan, err)
+	})
+}
+
+func Test_bookUseCase_SearchBooks_FullText(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	_, _, _ = m.CreateBook("I Am Ozzy", "Ozzy Osbourne", 294, 1, "")
+	_, _, _ = m.CreateBook("Diary of a Madman", "Ozzy Osbourne", 320, 1, "")
+	_, _, _ = m.CreateBook("White Line Fever", "Lemmy Kilmister", 304, 1, "")
+	titles := func(hits []*entity.BookHit) []string {
+		var t []string
+		for _, h := range hits {
+			t = append(t, h.Title)
+		}
+		return t
+	}
+
+	t.Run("title and author, title first", func(t *testing.T) {
+		hits, page, err := m.SearchBooks("ozzy", entity.Criteria{}, repository.ListOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, []string{"I Am Ozzy", "Diary of a Madman"}, titles(hits))
+		assert.True(t, hits[0].Rank > hits[1].Rank)
+		assert.Equal(t, "I Am <b>Ozzy</b> — <b>Ozzy</b> Osbourne", hits[0].Snippet)
+		assert.Equal(t, 1, hits[0].Quantity)
+	})
+	t.Run("every word", func(t *testing.T) {
+		hits, _, err := m.SearchBooks("madman osbourne", entity.Criteria{}, repository.ListOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Diary of a Madman"}, titles(hits))
+	})
+	t.Run("phrase", func(t *testing.T) {
+		hits, _, err := m.SearchBooks(`"line fever"`, entity.Criteria{}, repository.ListOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"White Line Fever"}, titles(hits))
+		_, _, err = m.SearchBooks(`"fever line"`, entity.Criteria{}, repository.ListOptions{})
+		assert.Equal(t, entity.ErrBookNotFound, err)
+	})
+	t.Run("prefix", func(t *testing.T) {
+		hits, _, err := m.SearchBooks("kilm*", entity.Criteria{}, repository.ListOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"White Line Fever"}, titles(hits))
+		_, _, err = m.SearchBooks("kilm", entity.Criteria{}, repository.ListOptions{})
+		assert.Equal(t, entity.ErrBookNotFound, err)
+	})
+	t.Run("paged by rank", func(t *testing.T) {
+		opts := repository.ListOptions{Limit: 1}
+		hits, page, err := m.SearchBooks("ozzy", entity.Criteria{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"I Am Ozzy"}, titles(hits))
+		opts.Cursor = page.Next
+		hits, page, err = m.SearchBooks("ozzy", entity.Criteria{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Diary of a Madman"}, titles(hits))
+		assert.Equal(t, "", page.Next)
+	})
+	t.Run("no words", func(t *testing.T) {
+		_, _, err := m.SearchBooks(`"*"`, entity.Criteria{}, repository.ListOptions{})
+		assert.Equal(t, entity.ErrBookNotFound, err)
+	})
+	t.Run("markup is escaped", func(t *testing.T) {
+		_, _, err := m.CreateBook("<script>alert(1)</script> Rocks", "Motörhead & Co", 100, 1, "")
+		assert.Nil(t, err)
+		hits, _, err := m.SearchBooks("rocks", entity.Criteria{}, repository.ListOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt; <b>Rocks</b> — Motörhead &amp; Co", hits[0].Snippet)
+	})
+}
+
+func Test_bookUseCase_ListBooks_Criteria(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	edit := func(id entity.ID, language string, published string, tags ...string) {
+		p, _ := time.Parse("2006-01-02", published)
+		_, err := m.EditBook(id.String(), 0, &entity.BookUpdate{Language: &language, PublishedAt: &p, Tags: &tags})
+		assert.Nil(t, err)
+	}
+	ozzy, _, _ := m.CreateBook("I Am Ozzy", "Ozzy Osbourne", 294, 1, "")
+	edit(ozzy, "en", "2010-01-25", "Memoir", "metal")
+	diary, _, _ := m.CreateBook("Diary of a Madman", "Ozzy Osbourne", 320, 1, "")
+	edit(diary, "en", "1981-11-07", "metal")
+	lemmy, _, _ := m.CreateBook("La Fièvre blanche", "Lemmy Kilmister", 304, 1, "")
+	edit(lemmy, "fr", "2003-05-01", "memoir", "metal")
+	lent, _ := m.ListCopies(diary.String())
+	_, _ = m.UpdateCopy(lent[0].Barcode, lent[0].Condition, lent[0].Location, entity.CopyLost)
+
+	yes, no := true, false
+	after2000, _ := time.Parse("2006-01-02", "2000-01-01")
+	titles := func(c entity.Criteria) []string {
+		books, _, _ := m.ListBooks(c, repository.ListOptions{Sort: "title"})
+		var t []string
+		for _, b := range books {
+			t = append(t, b.Title)
+		}
+		return t
+	}
+	assert.Equal(t, []string{"Diary of a Madman", "I Am Ozzy"}, titles(entity.Criteria{Author: "OSBOURNE"}))
+	assert.Equal(t, []string{"I Am Ozzy", "La Fièvre blanche"}, titles(entity.Criteria{PublishedAfter: after2000}))
+	assert.Equal(t, []string{"I Am Ozzy", "La Fièvre blanche"}, titles(entity.Criteria{Available: &yes}))
+	assert.Equal(t, []string{"Diary of a Madman"}, titles(entity.Criteria{Available: &no}))
+	assert.Equal(t, []string{"I Am Ozzy", "La Fièvre blanche"}, titles(entity.Criteria{Tags: []string{"metal", "memoir"}}))
+	assert.Equal(t, []string{"La Fièvre blanche"}, titles(entity.Criteria{Language: "FR"}))
+	assert.Equal(t, []string{"I Am Ozzy"}, titles(entity.Criteria{Author: "ozzy", Tags: []string{"memoir"}, Available: &yes}))
+	assert.Nil(t, titles(entity.Criteria{Author: "dio"}))
+
+	hits, _, err := m.SearchBooks("ozzy", entity.Criteria{Available: &yes}, repository.ListOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(hits))
+	assert.Equal(t, "I Am Ozzy", hits[0].Title)
+	assert.Equal(t, []string{"memoir", "metal"}, hits[0].Tags)
+
+	invalid := "english"
+	_, err = m.EditBook(ozzy.String(), 0, &entity.BookUpdate{Language: &invalid})
+	assert.Equal(t, entity.ErrInvalidBookEntity, err)
+}
+
+func Test_bookUseCase_ISBN(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	b := newFixtureBook()
+	id, _, err := m.CreateBook(b.Title, b.Author, b.Pages, 1, "0-306-40615-2")
+	assert.Nil(t, err)
+
+	t.Run("lookup by either ISBN", func(t *testing.T) {
+		for _, isbn := range []string{"0306406152", "978-0-306-40615-7"} {
+			saved, err := m.GetBookByISBN(isbn)
+			assert.Nil(t, err)
+			assert.Equal(t, id, saved.ID)
+			assert.Equal(t, "9780306406157", saved.ISBN)
+		}
+		_, err := m.GetBookByISBN("9791090636071")
+		assert.Equal(t, entity.ErrBookNotFound, err)
+		_, err = m.GetBookByISBN("0306406153")
+		assert.Equal(t, entity.ErrInvalidISBN, err)
+	})
+	t.Run("duplicate adds copies", func(t *testing.T) {
+		again, merged, err := m.CreateBook(b.Title, b.Author, b.Pages, 2, "9780306406157")
+		assert.Nil(t, err)
+		assert.True(t, merged)
+		assert.Equal(t, id, again)
+		saved, _ := m.GetBook(id.String())
+		assert.Equal(t, 3, saved.Quantity)
+		_, page, _ := m.ListBooks(entity.Criteria{}, repository.ListOptions{})
+		assert.Equal(t, 1, page.Total)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := m.CreateBook(b.Title, b.Author, b.Pages, 1, "0-306-40615-3")
+		assert.Equal(t, entity.ErrInvalidBookEntity, err)
+	})
+	t.Run("edit to a taken ISBN", func(t *testing.T) {
+		other, _, _ := m.CreateBook("Diary of a Madman", b.Author, 320, 1, "")
+		isbn := "0306406152"
+		_, err := m.EditBook(other.String(), 0, &entity.BookUpdate{ISBN: &isbn})
+		assert.Equal(t, entity.ErrISBNTaken, err)
+		isbn = "979-10-90636-07-1"
+		edited, err := m.EditBook(other.String(), 0, &entity.BookUpdate{ISBN: &isbn})
+		assert.Nil(t, err)
+		assert.Equal(t, "9791090636071", edited.ISBN)
+	})
+}
+
+func Test_bookUseCase_ListBooks_Pages(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	for _, pages := range []int{300, 100, 500, 200, 400} {
+		b := newFixtureBook()
+		_, _, _ = m.CreateBook(b.Title, b.Author, pages, b.Quantity, "")
+	}
+	pagesOf := func(books []*entity.Book) []int {
+		var p []int
+		for _, b := range books {
+			p = append(p, b.Pages)
+		}
+		return p
+	}
+
+	t.Run("cursor", func(t *testing.T) {
+		opts := repository.ListOptions{Limit: 2, Sort: "pages", Direction: repository.Desc}
+		books, page, err := m.ListBooks(entity.Criteria{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{500, 400}, pagesOf(books))
+		assert.Equal(t, 5, page.Total)
+		assert.NotEqual(t, "", page.Next)
+
+		opts.Cursor = page.Next
+		books, page, err = m.ListBooks(entity.Criteria{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{300, 200}, pagesOf(books))
+
+		opts.Cursor = page.Next
+		books, page, err = m.ListBooks(entity.Criteria{}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{100}, pagesOf(books))
+		assert.Equal(t, "", page.Next)
+	})
+	t.Run("offset", func(t *testing.T) {
+		books, page, err := m.ListBooks(entity.Criteria{}, repository.ListOptions{Limit: 2, Offset: 1, Sort: "pages"})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{200, 300}, pagesOf(books))
+		assert.Equal(t, 5, page.Total)
+
+		_, _, err = m.ListBooks(entity.Criteria{}, repository.ListOptions{Limit: 2, Offset: 5})
+		assert.Equal(t, entity.ErrBookNotFound, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := m.ListBooks(entity.Criteria{}, repository.ListOptions{Sort: "password"})
+		assert.Equal(t, repository.ErrInvalidSort, err)
+		_, _, err = m.ListBooks(entity.Criteria{}, repository.ListOptions{Cursor: "not a cursor"})
+		assert.Equal(t, repository.ErrInvalidCursor, err)
+	})
+}
+
+func Test_bookUseCase_EditBook(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	b := newFixtureBook()
+	id, _, _ := m.CreateBook(b.Title, b.Author, b.Pages, b.Quantity, "")
+	title := "I Am Ozzy: A Memoir"
+
+	t.Run("book not found", func(t *testing.T) {
+		_, err := m.EditBook(entity.NewID().String(), 1, &entity.BookUpdate{Title: &title})
+		assert.Equal(t, entity.ErrBookNotFound, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		pages := 0
+		_, err := m.EditBook(id.String(), 1, &entity.BookUpdate{Pages: &pages})
+		assert.Equal(t, entity.ErrInvalidBookEntity, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		updated, err := m.EditBook(id.String(), 1, &entity.BookUpdate{Title: &title})
+		assert.Nil(t, err)
+		assert.Equal(t, title, updated.Title)
+		assert.Equal(t, b.Author, updated.Author)
+		assert.Equal(t, 2, updated.Version)
+		assert.Equal(t, 1, updated.Quantity)
+	})
+	t.Run("stale version", func(t *testing.T) {
+		author := "John Osbourne"
+		_, err := m.EditBook(id.String(), 1, &entity.BookUpdate{Author: &author})
+		assert.Equal(t, entity.ErrBookVersionConflict, err)
+		saved, _ := m.GetBook(id.String())
+		assert.Equal(t, b.Author, saved.Author)
+	})
+	t.Run("concurrent update", func(t *testing.T) {
+		first, _ := m.GetBook(id.String())
+		second, _ := m.GetBook(id.String())
+		first.Pages = 300
+		second.Pages = 310
+		err := m.UpdateBook(first)
+		assert.Nil(t, err)
+		err = m.UpdateBook(second)
+		assert.Equal(t, entity.ErrBookVersionConflict, err)
+		saved, _ := m.GetBook(id.String())
+		assert.Equal(t, 300, saved.Pages)
+	})
+}
+
+// records is a catalog file already read
+type records []*entity.ImportRecord
+
+func (r *records) Read() (*entity.ImportRecord, error) {
+	if len(*r) == 0 {
+		return nil, io.EOF
+	}
+	rec := (*r)[0]
+	*r = (*r)[1:]
+	return rec, nil
+}
+
+func Test_bookUseCase_ImportBooks(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	id, _, err := m.CreateBook("I Am Ozzy", "Ozzy Osbourne", 294, 1, "9780446569897")
+	assert.Nil(t, err)
+
+	catalog := func() *records {
+		return &records{
+			{Row: 1, Book: &entity.Book{ISBN: "0-306-40615-2", Title: "Signals", Author: "Rush", Pages: 40, Quantity: 2, Tags: []string{"Rock"}}},
+			{Row: 2, Book: &entity.Book{ISBN: "978-0-446-56989-7", Title: "I Am Ozzy", Author: "Ozzy Osbourne", Pages: 294, Quantity: 1}},
+			{Row: 3, Book: &entity.Book{ISBN: "978-0-306-40615-7", Title: "Signals", Author: "Rush", Pages: 40, Quantity: 1}},
+			{Row: 4, Book: &entity.Book{ISBN: "978-0-306-40615-8", Title: "Bad", Author: "Nobody", Pages: 1, Quantity: 1}},
+			{Row: 5, Book: &entity.Book{Title: "No Pages", Author: "Nobody", Quantity: 1}},
+			{Row: 6, Err: entity.ErrInvalidCatalog},
+		}
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		report, err := m.ImportBooks(catalog(), true)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.Created)
+		assert.Equal(t, 2, report.Skipped)
+		assert.Equal(t, 3, report.Invalid)
+		assert.Equal(t, "ISBN already on row 1", report.Rows[2].Error)
+		_, err = m.GetBookByISBN("9780306406157")
+		assert.Equal(t, entity.ErrBookNotFound, err)
+	})
+	t.Run("import", func(t *testing.T) {
+		report, err := m.ImportBooks(catalog(), false)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.ImportCreated, report.Rows[0].Status)
+		b, err := m.GetBookByISBN("9780306406157")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, b.Quantity)
+		assert.Equal(t, []string{"rock"}, b.Tags)
+		assert.Equal(t, b.ID, report.Rows[0].BookID)
+		assert.Equal(t, entity.ImportSkipped, report.Rows[1].Status)
+		assert.Equal(t, id, report.Rows[1].BookID)
+	})
+	t.Run("update", func(t *testing.T) {
+		report, err := m.ImportBooks(&records{
+			{Row: 1, Book: &entity.Book{ISBN: "9780446569897", Title: "I Am Ozzy", Author: "Ozzy Osbourne", Pages: 400, Quantity: 3, Language: "en"}},
+		}, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, report.Updated)
+		b, err := m.GetBook(id.String())
+		assert.Nil(t, err)
+		assert.Equal(t, 400, b.Pages)
+		assert.Equal(t, "en", b.Language)
+		assert.Equal(t, 1, b.Quantity)
+	})
+}
+
+func Test_bookUseCase_ExportBooks(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	// more than a page
+	for i := 0; i < 501; i++ {
+		_, _, err := m.CreateBook(fmt.Sprintf("Book %d", i), "Ozzy Osbourne", 100, 1, "")
+		assert.Nil(t, err)
+	}
+	_, _, err := m.CreateBook("Signals", "Rush", 40, 2, "")
+	assert.Nil(t, err)
+
+	seen := map[entity.ID]bool{}
+	err = m.ExportBooks(entity.Criteria{}, func(b *entity.Book) error {
+		seen[b.ID] = true
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, seen, 502)
+
+	var rush []*entity.Book
+	err = m.ExportBooks(entity.Criteria{Author: "rush"}, func(b *entity.Book) error {
+		rush = append(rush, b)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rush, 1)
+	assert.Equal(t, 2, rush[0].Quantity)
+
+	err = m.ExportBooks(entity.Criteria{}, func(b *entity.Book) error {
+		return io.ErrShortWrite
+	})
+	assert.Equal(t, io.ErrShortWrite, err)
+}
+
+func Test_bookUseCase_Subjects(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), borrowInfra.NewHoldInMemRepo())
+	fiction, err := m.CreateSubject("Fiction", "")
+	assert.Nil(t, err)
+	sf, err := m.CreateSubject("Science fiction", fiction.String())
+	assert.Nil(t, err)
+	cyberpunk, err := m.CreateSubject("Cyberpunk", sf.String())
+	assert.Nil(t, err)
+	_, err = m.CreateSubject("Orphan", entity.NewID().String())
+	assert.Equal(t, entity.ErrInvalidSubjectEntity, err)
+
+	dune, _, _ := m.CreateBook("Dune", "Frank Herbert", 412, 1, "")
+	neuromancer, _, _ := m.CreateBook("Neuromancer", "William Gibson", 271, 1, "")
+	_, _, _ = m.CreateBook("I Am Ozzy", "Ozzy Osbourne", 294, 1, "")
+
+	t.Run("file books", func(t *testing.T) {
+		subjects, err := m.SetBookSubjects(dune.String(), []string{sf.String(), sf.String()})
+		assert.Nil(t, err)
+		assert.Len(t, subjects, 1)
+		_, err = m.SetBookSubjects(neuromancer.String(), []string{cyberpunk.String()})
+		assert.Nil(t, err)
+		_, err = m.SetBookSubjects(neuromancer.String(), []string{"unknown"})
+		assert.Equal(t, entity.ErrSubjectNotFound, err)
+
+		b, err := m.GetBook(dune.String())
+		assert.Nil(t, err)
+		assert.Equal(t, "Science fiction", b.Subjects[0].Name)
+	})
+
+	t.Run("books of descendants", func(t *testing.T) {
+		books, page, err := m.ListSubjectBooks(fiction.String(), repository.ListOptions{Limit: 10})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Len(t, books, 2)
+
+		books, _, err = m.ListSubjectBooks(cyberpunk.String(), repository.ListOptions{Limit: 10})
+		assert.Nil(t, err)
+		assert.Len(t, books, 1)
+		assert.Equal(t, "Neuromancer", books[0].Title)
+	})
+
+	t.Run("move", func(t *testing.T) {
+		_, err := m.UpdateSubject(fiction.String(), "Fiction", cyberpunk.String())
+		assert.Equal(t, entity.ErrSubjectCycle, err)
+		f, err := m.GetSubject(fiction.String())
+		assert.Nil(t, err)
+		assert.True(t, f.Parent.IsNil())
+		c, err := m.UpdateSubject(cyberpunk.String(), "Cyberpunk", "")
+		assert.Nil(t, err)
+		assert.True(t, c.Parent.IsNil())
+		books, _, err := m.ListSubjectBooks(fiction.String(), repository.ListOptions{Limit: 10})
+		assert.Nil(t, err)
+		assert.Len(t, books, 1)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert.Equal(t, entity.ErrSubjectCannotBeDeleted, m.DeleteSubject(fiction.String()))
+		assert.Nil(t, m.DeleteSubject(sf.String()))
+		b, err := m.GetBook(dune.String())
+		assert.Nil(t, err)
+		assert.Empty(t, b.Subjects)
+		assert.Nil(t, m.DeleteSubject(fiction.String()))
+	})
+}
+
+func Test_bookUseCase_Works(t *testing.T) {
+	copies := infrastructure.NewCopyInMemRepo()
+	r := infrastructure.NewInMemRepo(copies)
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	holds := borrowInfra.NewHoldInMemRepo()
+	m := newBookUseCase(s, r, copies, infrastructure.NewSubjectInMemRepo(), infrastructure.NewWorkInMemRepo(), infrastructure.NewSeriesInMemRepo(), authorInfra.NewInMemRepo(), holds)
+	dune, err := m.CreateWork("Dune")
+	assert.Nil(t, err)
+	first, _, _ := m.CreateBook("Dune", "Frank Herbert", 412, 1, "")
+	anniversary, _, _ := m.CreateBook("Dune (50th anniversary)", "Frank Herbert", 617, 2, "")
+	_, _, _ = m.CreateBook("Neuromancer", "William Gibson", 271, 1, "")
+
+	t.Run("editions", func(t *testing.T) {
+		editions, err := m.ListEditions(first.String())
+		assert.Nil(t, err)
+		assert.Empty(t, editions)
+		_, err = m.SetBookWork(first.String(), "unknown")
+		assert.Equal(t, entity.ErrWorkNotFound, err)
+		b, err := m.SetBookWork(first.String(), dune.String())
+		assert.Nil(t, err)
+		assert.Equal(t, dune, b.WorkID)
+		_, err = m.SetBookWork(anniversary.String(), dune.String())
+		assert.Nil(t, err)
+
+		editions, err = m.ListEditions(first.String())
+		assert.Nil(t, err)
+		assert.Len(t, editions, 1)
+		assert.Equal(t, anniversary, editions[0].ID)
+		assert.Equal(t, 2, editions[0].Quantity)
+		editions, err = m.ListWorkEditions(dune.String())
+		assert.Nil(t, err)
+		assert.Len(t, editions, 2)
+		assert.Equal(t, entity.ErrWorkCannotBeDeleted, m.DeleteWork(dune.String()))
+	})
+
+	t.Run("series", func(t *testing.T) {
+		chronicles, err := m.CreateSeries("Dune Chronicles")
+		assert.Nil(t, err)
+		messiah, _ := m.CreateWork("Dune Messiah")
+		_, err = m.UpdateWork(messiah.String(), "Dune Messiah", chronicles.String(), 2)
+		assert.Nil(t, err)
+		_, err = m.UpdateWork(dune.String(), "Dune", chronicles.String(), 0)
+		assert.Equal(t, entity.ErrInvalidWorkEntity, err)
+		_, err = m.UpdateWork(dune.String(), "Dune", entity.NewID().String(), 1)
+		assert.Equal(t, entity.ErrSeriesNotFound, err)
+		_, err = m.UpdateWork(dune.String(), "Dune", chronicles.String(), 1)
+		assert.Nil(t, err)
+
+		works, err := m.ListSeriesWorks(chronicles.String())
+		assert.Nil(t, err)
+		assert.Len(t, works, 2)
+		assert.Equal(t, "Dune", works[0].Title)
+		assert.Equal(t, "Dune Messiah", works[1].Title)
+
+		assert.Nil(t, m.DeleteSeries(chronicles.String()))
+		w, err := m.GetWork(messiah.String())
+		assert.Nil(t, err)
+		assert.True(t, w.SeriesID.IsNil())
+		assert.Equal(t, 0, w.Position)
+	})
+
+	t.Run("ungroup", func(t *testing.T) {
+		b, err := m.SetBookWork(first.String(), "")
+		assert.Nil(t, err)
+		assert.True(t, b.WorkID.IsNil())
+		_, err = m.SetBookWork(anniversary.String(), "")
+		assert.Nil(t, err)
+
+		h, _ := borrowEntity.NewWorkHold(userEntity.NewID(), dune)
+		_, _ = holds.Create(h)
+		assert.Equal(t, entity.ErrWorkCannotBeDeleted, m.DeleteWork(dune.String()))
+		_ = h.Fulfill()
+		_ = holds.Update(h)
+		assert.Nil(t, m.DeleteWork(dune.String()))
+	})
+}
@@ -1,20 +1,83 @@
 package adapter
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	auth "github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
 	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	bookUseCase "github.com/sgraham785/gocleanarch-example/internal/book/usecase"
 	"github.com/sgraham785/gocleanarch-example/internal/borrow/usecase"
 	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	userUseCase "github.com/sgraham785/gocleanarch-example/internal/user/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 
 	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
 )
 
+// LoanHTTP JSON data
+type LoanHTTP struct {
+	ID         entity.ID         `json:"id"`
+	UserID     userEntity.ID     `json:"user_id"`
+	BookID     bookEntity.ID     `json:"book_id"`
+	Barcode    string            `json:"barcode"`
+	Status     entity.LoanStatus `json:"status"`
+	BorrowedAt time.Time         `json:"borrowed_at"`
+	DueAt      time.Time         `json:"due_at"`
+	ReturnedAt *time.Time        `json:"returned_at,omitempty"`
+	Renewals   int               `json:"renewals"`
+}
+
+func newLoanHTTP(l *entity.Loan) *LoanHTTP {
+	toJ := &LoanHTTP{
+		ID:         l.ID,
+		UserID:     l.UserID,
+		BookID:     l.BookID,
+		Barcode:    l.Barcode,
+		Status:     l.Status,
+		BorrowedAt: l.BorrowedAt,
+		DueAt:      l.DueAt,
+		Renewals:   l.Renewals,
+	}
+	if !l.ReturnedAt.IsZero() {
+		toJ.ReturnedAt = &l.ReturnedAt
+	}
+	return toJ
+}
+
+// HoldHTTP JSON data
+type HoldHTTP struct {
+	ID        entity.ID         `json:"id"`
+	UserID    userEntity.ID     `json:"user_id"`
+	BookID    bookEntity.ID     `json:"book_id"`
+	WorkID    *bookEntity.ID    `json:"work_id,omitempty"`
+	Status    entity.HoldStatus `json:"status"`
+	PlacedAt  time.Time         `json:"placed_at"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+}
+
+func newHoldHTTP(h *entity.Hold) *HoldHTTP {
+	toJ := &HoldHTTP{
+		ID:       h.ID,
+		UserID:   h.UserID,
+		BookID:   h.BookID,
+		Status:   h.Status,
+		PlacedAt: h.PlacedAt,
+	}
+	if !h.WorkID.IsNil() {
+		toJ.WorkID = &h.WorkID
+	}
+	if !h.ExpiresAt.IsZero() {
+		toJ.ExpiresAt = &h.ExpiresAt
+	}
+	return toJ
+}
+
 // BorrowBookHTTP handler
 func BorrowBookHTTP(bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -55,7 +118,16 @@ func BorrowBookHTTP(bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase
 					w.Write([]byte(errorMessage))
 					return
 				}
-				err = borrowUseCase.Borrow(u, b)
+				l, err := borrowUseCase.Borrow(u, b)
+				if err == entity.ErrUserNotVerified {
+					router.Deny(w, http.StatusForbidden, err.Error())
+					return
+				}
+				if err == entity.ErrBookOnHold || err == entity.ErrOutstandingFines || err == entity.ErrBorrowLimitReached || err == bookEntity.ErrCopyNotAvailable {
+					w.WriteHeader(http.StatusConflict)
+					w.Write([]byte(err.Error()))
+					return
+				}
 				if err != nil {
 					fmt.Println(err)
 					w.WriteHeader(http.StatusInternalServerError)
@@ -63,49 +135,464 @@ func BorrowBookHTTP(bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase
 					return
 				}
 				w.WriteHeader(http.StatusCreated)
+				if err := json.NewEncoder(w).Encode(newLoanHTTP(l)); err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(errorMessage))
+					return
+				}
 			}
 		}
 	})
 }
 
 // ReturnBookHTTP handler
-func ReturnBookHTTP(bookUseCase bookUseCase.BookUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+func ReturnBookHTTP(bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error returning book"
-		if bookID := chi.URLParam(r, "bookID"); bookID != "" {
-			bID, err := bookEntity.IDFromString(bookID)
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(errorMessage))
-				return
-			}
-			b, err := bookUseCase.GetBook(bID.String())
-			if err != nil && err != bookEntity.ErrBookNotFound {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(errorMessage))
-				return
-			}
-			if b == nil {
-				w.WriteHeader(http.StatusNotFound)
-				w.Write([]byte(errorMessage))
-				return
-			}
-			err = borrowUseCase.Return(b)
-			if err != nil && err != entity.ErrBookNotBorrowed {
-				w.WriteHeader(http.StatusInternalServerError)
-				w.Write([]byte(errorMessage))
-				return
-			}
-			w.WriteHeader(http.StatusCreated)
+		b, err := bookUseCase.GetBook(chi.URLParam(r, "bookID"))
+		if err != nil && err != bookEntity.ErrBookNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if b == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		u, err := userUseCase.GetUser(chi.URLParam(r, "userID"))
+		if err != nil && err != userEntity.ErrUserNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if u == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		l, err := borrowUseCase.Return(u, b)
+		writeReturnedLoan(w, l, err, errorMessage)
+	})
+}
+
+// ReturnLoanHTTP handler
+func ReturnLoanHTTP(borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error returning loan"
+		l, err := borrowUseCase.ReturnLoan(chi.URLParam(r, "loanID"))
+		writeReturnedLoan(w, l, err, errorMessage)
+	})
+}
+
+func writeReturnedLoan(w http.ResponseWriter, l *entity.Loan, err error, errorMessage string) {
+	switch err {
+	case nil:
+	case entity.ErrLoanNotFound, bookEntity.ErrBookNotFound:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(errorMessage))
+		return
+	case entity.ErrBookNotBorrowed, entity.ErrLoanAlreadyReturned:
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(err.Error()))
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newLoanHTTP(l)); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+// BorrowCopyHTTP handler
+func BorrowCopyHTTP(userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error borrowing copy"
+		u, err := userUseCase.GetUser(chi.URLParam(r, "userID"))
+		if err != nil && err != userEntity.ErrUserNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if u == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		l, err := borrowUseCase.BorrowCopy(u, chi.URLParam(r, "barcode"))
+		switch err {
+		case nil:
+		case bookEntity.ErrCopyNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case bookEntity.ErrCopyNotAvailable, entity.ErrNotEnoughBooks, entity.ErrBookOnHold, entity.ErrOutstandingFines, entity.ErrBorrowLimitReached, entity.ErrBookAlreadyBorrowed:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrUserNotVerified:
+			router.Deny(w, http.StatusForbidden, err.Error())
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newLoanHTTP(l)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ReturnCopyHTTP handler, the body may record the condition the copy came back in
+func ReturnCopyHTTP(borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error returning copy"
+		var input struct {
+			Condition bookEntity.CopyCondition `json:"condition"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		l, err := borrowUseCase.ReturnCopy(chi.URLParam(r, "barcode"), input.Condition)
+		switch err {
+		case nil:
+		case bookEntity.ErrCopyNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrBookNotBorrowed:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		case bookEntity.ErrInvalidCopyEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newLoanHTTP(l)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// PlaceHoldHTTP handler
+func PlaceHoldHTTP(bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error placing hold"
+		var input struct {
+			UserID string `json:"user_id"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if !router.Can(r, auth.Staff, router.Is(input.UserID)) {
+			router.Deny(w, http.StatusForbidden, "Members can only place holds for themselves")
+			return
+		}
+		b, err := bookUseCase.GetBook(chi.URLParam(r, "bookID"))
+		if err != nil && err != bookEntity.ErrBookNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if b == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		u, err := userUseCase.GetUser(input.UserID)
+		if err != nil && err != userEntity.ErrUserNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if u == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		h, err := borrowUseCase.PlaceHold(u, b)
+		switch err {
+		case nil:
+		case entity.ErrBookAvailable, entity.ErrHoldAlreadyPlaced, entity.ErrBookAlreadyBorrowed:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newHoldHTTP(h)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// PlaceWorkHoldHTTP handler
+func PlaceWorkHoldHTTP(bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error placing hold"
+		var input struct {
+			UserID string `json:"user_id"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if !router.Can(r, auth.Staff, router.Is(input.UserID)) {
+			router.Deny(w, http.StatusForbidden, "Members can only place holds for themselves")
+			return
+		}
+		work, err := bookUseCase.GetWork(chi.URLParam(r, "workID"))
+		if err != nil && err != bookEntity.ErrWorkNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if work == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		u, err := userUseCase.GetUser(input.UserID)
+		if err != nil && err != userEntity.ErrUserNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if u == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		h, err := borrowUseCase.PlaceWorkHold(u, work)
+		switch err {
+		case nil:
+		case entity.ErrBookAvailable, entity.ErrHoldAlreadyPlaced, entity.ErrBookAlreadyBorrowed, entity.ErrWorkWithoutEditions:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newHoldHTTP(h)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// RenewLoanHTTP handler
+func RenewLoanHTTP(borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error renewing loan"
+		l, err := borrowUseCase.Renew(chi.URLParam(r, "loanID"))
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrLoanNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrRenewalLimitReached, entity.ErrLoanTooOverdue, entity.ErrBookOnHold, entity.ErrLoanAlreadyReturned:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if err := json.NewEncoder(w).Encode(newLoanHTTP(l)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// ListOverdueLoansHTTP handler
+func ListOverdueLoansHTTP(borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing overdue loans"
+		data, err := borrowUseCase.ListOverdueLoans()
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := []*LoanHTTP{}
+		for _, d := range data {
+			toJ = append(toJ, newLoanHTTP(d))
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
 		}
 	})
 }
 
-// HTTPRoutes make url handlers
+// loanFilter reads ?status=active|returned|overdue&from=&to= from the request,
+// dates are either 2006-01-02 or RFC 3339
+func loanFilter(r *http.Request) (entity.LoanFilter, error) {
+	q := r.URL.Query()
+	f := entity.LoanFilter{
+		Status: entity.LoanStatus(q.Get("status")),
+	}
+	var err error
+	f.From, err = parseDate(q.Get("from"))
+	if err != nil {
+		return f, entity.ErrInvalidLoanFilter
+	}
+	f.To, err = parseDate(q.Get("to"))
+	if err != nil {
+		return f, entity.ErrInvalidLoanFilter
+	}
+	return f, f.Validate()
+}
+
+func parseDate(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func writeLoans(w http.ResponseWriter, data []*entity.Loan, err error, errorMessage string) {
+	w.Header().Set("Content-Type", "application/json")
+	switch err {
+	case nil:
+	case entity.ErrInvalidLoanFilter:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+		return
+	}
+	toJ := []*LoanHTTP{}
+	for _, d := range data {
+		toJ = append(toJ, newLoanHTTP(d))
+	}
+	if err := json.NewEncoder(w).Encode(toJ); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+	}
+}
+
+// ListUserLoansHTTP handler
+func ListUserLoansHTTP(userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing loans"
+		f, err := loanFilter(r)
+		if err != nil {
+			writeLoans(w, nil, err, errorMessage)
+			return
+		}
+		u, err := userUseCase.GetUser(chi.URLParam(r, "userID"))
+		if err != nil && err != userEntity.ErrUserNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if u == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := borrowUseCase.ListUserLoans(u, f)
+		writeLoans(w, data, err, errorMessage)
+	})
+}
+
+// ListBookLoansHTTP handler
+func ListBookLoansHTTP(bookUseCase bookUseCase.BookUseCase, borrowUseCase usecase.BorrowUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error listing loans"
+		f, err := loanFilter(r)
+		if err != nil {
+			writeLoans(w, nil, err, errorMessage)
+			return
+		}
+		b, err := bookUseCase.GetBook(chi.URLParam(r, "bookID"))
+		if err != nil && err != bookEntity.ErrBookNotFound {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		if b == nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := borrowUseCase.ListBookLoans(b, f)
+		writeLoans(w, data, err, errorMessage)
+	})
+}
+
+// loanOwner lets through the user who borrowed the loan named by the loanID URL parameter
+func loanOwner(borrowUseCase usecase.BorrowUseCase) router.Policy {
+	return func(p router.Principal, r *http.Request) bool {
+		l, err := borrowUseCase.GetLoan(chi.URLParam(r, "loanID"))
+		return err == nil && l.UserID.String() == p.ID
+	}
+}
+
+// HTTPRoutes make url handlers, members borrow, return and renew for themselves,
+// staff do it on behalf of anyone and run the desk
 func HTTPRoutes(s *server.Server, bookUseCase bookUseCase.BookUseCase, userUseCase userUseCase.UserUseCase, borrowUseCase usecase.BorrowUseCase) {
+	selfOrStaff := router.Authorize(auth.Self, auth.Staff)
+	staff := router.Authorize(auth.Staff)
+	ownerOrStaff := router.Authorize(auth.Staff, loanOwner(borrowUseCase))
 	// RESTy routes for "books" resource
 	s.Router.Chi.Route("/borrow", func(r chi.Router) {
-		r.Post("/{bookID}/{userID}", BorrowBookHTTP(bookUseCase, userUseCase, borrowUseCase))
-		r.Post("/return/{bookID}", ReturnBookHTTP(bookUseCase, borrowUseCase))
+		r.With(selfOrStaff).Post("/{bookID}/{userID}", BorrowBookHTTP(bookUseCase, userUseCase, borrowUseCase))
+		r.With(selfOrStaff).Post("/copy/{barcode}/{userID}", BorrowCopyHTTP(userUseCase, borrowUseCase))
+		r.With(selfOrStaff).Post("/return/{bookID}/{userID}", ReturnBookHTTP(bookUseCase, userUseCase, borrowUseCase))
+		r.With(staff).Post("/return/copy/{barcode}", ReturnCopyHTTP(borrowUseCase))
+		r.With(ownerOrStaff).Post("/{loanID}/renew", RenewLoanHTTP(borrowUseCase))
+		r.With(ownerOrStaff).Post("/{loanID}/return", ReturnLoanHTTP(borrowUseCase))
+		r.With(staff).Get("/overdue", ListOverdueLoansHTTP(borrowUseCase))
 	})
+	s.Router.Chi.Post("/book/{bookID}/hold", PlaceHoldHTTP(bookUseCase, userUseCase, borrowUseCase))
+	s.Router.Chi.Post("/work/{workID}/hold", PlaceWorkHoldHTTP(bookUseCase, userUseCase, borrowUseCase))
+	s.Router.Chi.With(staff).Get("/book/{bookID}/loans", ListBookLoansHTTP(bookUseCase, borrowUseCase))
+	s.Router.Chi.With(selfOrStaff).Get("/user/{userID}/loans", ListUserLoansHTTP(userUseCase, borrowUseCase))
 }
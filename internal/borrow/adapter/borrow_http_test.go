@@ -1,13 +1,17 @@
 package adapter_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/borrow/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
 	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
@@ -26,6 +30,7 @@ func TestBorrowBookHTTP(t *testing.T) {
 	bookMock := bookMock.NewMockBookUseCase(controller)
 	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -58,6 +63,23 @@ func TestBorrowBookHTTP(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, res.StatusCode)
 	})
 
+	t.Run("borrow limit reached", func(t *testing.T) {
+		b := &bookEntity.Book{
+			ID: bookEntity.NewID(),
+		}
+		u := &userEntity.User{
+			ID: userEntity.NewID(),
+		}
+		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().Borrow(u, b).Return(nil, entity.ErrBorrowLimitReached)
+		ts := httptest.NewServer(r.Chi)
+		defer ts.Close()
+		res, err := http.Get(fmt.Sprintf("%s/borrow/%s/%s", ts.URL, b.ID.String(), u.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		b := &bookEntity.Book{
 			ID: bookEntity.NewID(),
@@ -67,12 +89,22 @@ func TestBorrowBookHTTP(t *testing.T) {
 		}
 		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
 		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
-		borrowMock.EXPECT().Borrow(u, b).Return(nil)
+		l := &entity.Loan{
+			ID:     entity.NewID(),
+			UserID: u.ID,
+			BookID: b.ID,
+			Status: entity.LoanActive,
+		}
+		borrowMock.EXPECT().Borrow(u, b).Return(l, nil)
 		ts := httptest.NewServer(r.Chi)
 		defer ts.Close()
 		res, err := http.Get(fmt.Sprintf("%s/borrow/%s/%s", ts.URL, b.ID.String(), u.ID.String()))
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d *adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, l.ID, d.ID)
+		assert.Nil(t, d.ReturnedAt)
 	})
 }
 
@@ -83,33 +115,487 @@ func TestReturnBookHTTP(t *testing.T) {
 	bookMock := bookMock.NewMockBookUseCase(controller)
 	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
 	s := &server.Server{
 		Router: r,
 	}
 	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
-	h := adapter.ReturnBookHTTP(bookMock, borrowMock)
-	r.Chi.Handle("/return/{bookID}", h)
+	h := adapter.ReturnBookHTTP(bookMock, userMock, borrowMock)
+	r.Chi.Handle("/return/{bookID}/{userID}", h)
 
 	t.Run("book not found", func(t *testing.T) {
 		bID := bookEntity.NewID()
 		bookMock.EXPECT().GetBook(bID.String()).Return(nil, bookEntity.ErrBookNotFound)
 		ts := httptest.NewServer(r.Chi)
 		defer ts.Close()
-		res, err := http.Get(fmt.Sprintf("%s/return/%s", ts.URL, bID.String()))
+		res, err := http.Get(fmt.Sprintf("%s/return/%s/%s", ts.URL, bID.String(), userEntity.NewID().String()))
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusNotFound, res.StatusCode)
 	})
 
+	t.Run("book not borrowed", func(t *testing.T) {
+		b := &bookEntity.Book{
+			ID: bookEntity.NewID(),
+		}
+		u := &userEntity.User{
+			ID: userEntity.NewID(),
+		}
+		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().Return(u, b).Return(nil, entity.ErrBookNotBorrowed)
+		ts := httptest.NewServer(r.Chi)
+		defer ts.Close()
+		res, err := http.Get(fmt.Sprintf("%s/return/%s/%s", ts.URL, b.ID.String(), u.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
 	t.Run("success", func(t *testing.T) {
 		b := &bookEntity.Book{
 			ID: bookEntity.NewID(),
 		}
+		u := &userEntity.User{
+			ID: userEntity.NewID(),
+		}
+		l, _ := entity.NewLoan(u.ID, b.ID, time.Hour)
+		_ = l.Return()
 		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
-		borrowMock.EXPECT().Return(b).Return(nil)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().Return(u, b).Return(l, nil)
 		ts := httptest.NewServer(r.Chi)
 		defer ts.Close()
-		res, err := http.Get(fmt.Sprintf("%s/return/%s", ts.URL, b.ID.String()))
+		res, err := http.Get(fmt.Sprintf("%s/return/%s/%s", ts.URL, b.ID.String(), u.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d *adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, l.ID, d.ID)
+		assert.NotNil(t, d.ReturnedAt)
+	})
+}
+
+func TestReturnLoanHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	t.Run("loan not found", func(t *testing.T) {
+		id := entity.NewID()
+		borrowMock.EXPECT().ReturnLoan(id.String()).Return(nil, entity.ErrLoanNotFound)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/%s/return", ts.URL, id.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("already returned", func(t *testing.T) {
+		id := entity.NewID()
+		borrowMock.EXPECT().ReturnLoan(id.String()).Return(nil, entity.ErrLoanAlreadyReturned)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/%s/return", ts.URL, id.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+		_ = l.Return()
+		borrowMock.EXPECT().ReturnLoan(l.ID.String()).Return(l, nil)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/%s/return", ts.URL, l.ID.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d *adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, entity.LoanReturned, d.Status)
+	})
+}
+
+func TestPlaceHoldHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	b := &bookEntity.Book{
+		ID: bookEntity.NewID(),
+	}
+	u := &userEntity.User{
+		ID: userEntity.NewID(),
+	}
+	payload := fmt.Sprintf(`{"user_id": "%s"}`, u.ID.String())
+
+	t.Run("book available", func(t *testing.T) {
+		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().PlaceHold(u, b).Return(nil, entity.ErrBookAvailable)
+		res, err := http.Post(fmt.Sprintf("%s/book/%s/hold", ts.URL, b.ID.String()), "application/json", strings.NewReader(payload))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		h := &entity.Hold{
+			ID:     entity.NewID(),
+			UserID: u.ID,
+			BookID: b.ID,
+			Status: entity.HoldWaiting,
+		}
+		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().PlaceHold(u, b).Return(h, nil)
+		res, err := http.Post(fmt.Sprintf("%s/book/%s/hold", ts.URL, b.ID.String()), "application/json", strings.NewReader(payload))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d *adapter.HoldHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, h.ID, d.ID)
+		assert.Equal(t, entity.HoldWaiting, d.Status)
+	})
+}
+
+func TestPlaceWorkHoldHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	w := &bookEntity.Work{
+		ID: bookEntity.NewID(),
+	}
+	u := &userEntity.User{
+		ID: userEntity.NewID(),
+	}
+	payload := fmt.Sprintf(`{"user_id": "%s"}`, u.ID.String())
+
+	t.Run("work not found", func(t *testing.T) {
+		bookMock.EXPECT().GetWork(w.ID.String()).Return(nil, bookEntity.ErrWorkNotFound)
+		res, err := http.Post(fmt.Sprintf("%s/work/%s/hold", ts.URL, w.ID.String()), "application/json", strings.NewReader(payload))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		h, _ := entity.NewWorkHold(u.ID, w.ID)
+		bookMock.EXPECT().GetWork(w.ID.String()).Return(w, nil)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().PlaceWorkHold(u, w).Return(h, nil)
+		res, err := http.Post(fmt.Sprintf("%s/work/%s/hold", ts.URL, w.ID.String()), "application/json", strings.NewReader(payload))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d map[string]interface{}
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, w.ID.String(), d["work_id"])
+		assert.Nil(t, d["book_id"])
+	})
+}
+
+func TestRenewLoanHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	t.Run("loan not found", func(t *testing.T) {
+		lID := entity.NewID()
+		borrowMock.EXPECT().Renew(lID.String()).Return(nil, entity.ErrLoanNotFound)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/%s/renew", ts.URL, lID.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("refused", func(t *testing.T) {
+		lID := entity.NewID()
+		borrowMock.EXPECT().Renew(lID.String()).Return(nil, entity.ErrBookOnHold)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/%s/renew", ts.URL, lID.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l := &entity.Loan{
+			ID:       entity.NewID(),
+			Status:   entity.LoanActive,
+			Renewals: 1,
+		}
+		borrowMock.EXPECT().Renew(l.ID.String()).Return(l, nil)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/%s/renew", ts.URL, l.ID.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d *adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, 1, d.Renewals)
+	})
+}
+
+func TestListOverdueLoansHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	l := &entity.Loan{
+		ID:     entity.NewID(),
+		UserID: userEntity.NewID(),
+		BookID: bookEntity.NewID(),
+		Status: entity.LoanOverdue,
+	}
+	borrowMock.EXPECT().ListOverdueLoans().Return([]*entity.Loan{l}, nil)
+	ts := httptest.NewServer(adapter.ListOverdueLoansHTTP(borrowMock))
+	defer ts.Close()
+	res, err := http.Get(ts.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var d []*adapter.LoanHTTP
+	json.NewDecoder(res.Body).Decode(&d)
+	assert.Equal(t, 1, len(d))
+	assert.Equal(t, entity.LoanOverdue, d[0].Status)
+}
+
+func TestBorrowCopyHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	u := &userEntity.User{
+		ID: userEntity.NewID(),
+	}
+
+	t.Run("copy not available", func(t *testing.T) {
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().BorrowCopy(u, "LIB-0001").Return(nil, bookEntity.ErrCopyNotAvailable)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/copy/LIB-0001/%s", ts.URL, u.ID.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		l, _ := entity.NewLoan(u.ID, bookEntity.NewID(), time.Hour)
+		l.Barcode = "LIB-0001"
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().BorrowCopy(u, "LIB-0001").Return(l, nil)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/copy/LIB-0001/%s", ts.URL, u.ID.String()), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d *adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, "LIB-0001", d.Barcode)
+	})
+
+	t.Run("return damaged", func(t *testing.T) {
+		l, _ := entity.NewLoan(u.ID, bookEntity.NewID(), time.Hour)
+		_ = l.Return()
+		borrowMock.EXPECT().ReturnCopy("LIB-0001", bookEntity.ConditionDamaged).Return(l, nil)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/return/copy/LIB-0001", ts.URL), "application/json", strings.NewReader(`{"condition": "damaged"}`))
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusCreated, res.StatusCode)
 	})
+
+	t.Run("return not borrowed", func(t *testing.T) {
+		borrowMock.EXPECT().ReturnCopy("LIB-0002", bookEntity.CopyCondition("")).Return(nil, entity.ErrBookNotBorrowed)
+		res, err := http.Post(fmt.Sprintf("%s/borrow/return/copy/LIB-0002", ts.URL), "application/json", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+}
+
+func TestListUserLoansHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	t.Run("invalid filter", func(t *testing.T) {
+		res, err := http.Get(fmt.Sprintf("%s/user/%s/loans?status=lost", ts.URL, userEntity.NewID().String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		res, err = http.Get(fmt.Sprintf("%s/user/%s/loans?from=last-year", ts.URL, userEntity.NewID().String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		uID := userEntity.NewID()
+		userMock.EXPECT().GetUser(uID.String()).Return(nil, userEntity.ErrUserNotFound)
+		res, err := http.Get(fmt.Sprintf("%s/user/%s/loans", ts.URL, uID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		u := &userEntity.User{
+			ID: userEntity.NewID(),
+		}
+		l, _ := entity.NewLoan(u.ID, bookEntity.NewID(), time.Hour)
+		_ = l.Return()
+		from, _ := time.Parse("2006-01-02", "2025-01-01")
+		to, _ := time.Parse("2006-01-02", "2026-01-01")
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		borrowMock.EXPECT().ListUserLoans(u, entity.LoanFilter{Status: entity.LoanReturned, From: from, To: to}).Return([]*entity.Loan{l}, nil)
+		res, err := http.Get(fmt.Sprintf("%s/user/%s/loans?status=returned&from=2025-01-01&to=2026-01-01", ts.URL, u.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d []*adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Len(t, d, 1)
+		assert.Equal(t, l.ID, d[0].ID)
+	})
+}
+
+func TestListBookLoansHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	t.Run("book not found", func(t *testing.T) {
+		bID := bookEntity.NewID()
+		bookMock.EXPECT().GetBook(bID.String()).Return(nil, bookEntity.ErrBookNotFound)
+		res, err := http.Get(fmt.Sprintf("%s/book/%s/loans", ts.URL, bID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		b := &bookEntity.Book{
+			ID: bookEntity.NewID(),
+		}
+		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
+		borrowMock.EXPECT().ListBookLoans(b, entity.LoanFilter{Status: entity.LoanOverdue}).Return(nil, nil)
+		res, err := http.Get(fmt.Sprintf("%s/book/%s/loans?status=overdue", ts.URL, b.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d []*adapter.LoanHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.NotNil(t, d)
+		assert.Empty(t, d)
+	})
+}
+
+func TestBorrowHTTP_Authorization(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	bookMock := bookMock.NewMockBookUseCase(controller)
+	borrowMock := borrowMock.NewMockBorrowUseCase(controller)
+	member := &userEntity.User{
+		ID: userEntity.NewID(),
+	}
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{ID: member.ID.String(), Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, bookMock, userMock, borrowMock)
+	b := &bookEntity.Book{
+		ID: bookEntity.NewID(),
+	}
+
+	t.Run("borrow for themselves", func(t *testing.T) {
+		l, _ := entity.NewLoan(member.ID, b.ID, time.Hour)
+		bookMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
+		userMock.EXPECT().GetUser(member.ID.String()).Return(member, nil)
+		borrowMock.EXPECT().Borrow(member, b).Return(l, nil)
+		req := httptest.NewRequest("POST", fmt.Sprintf("/borrow/%s/%s", b.ID.String(), member.ID.String()), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("borrow for someone else", func(t *testing.T) {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/borrow/%s/%s", b.ID.String(), userEntity.NewID().String()), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		var d router.Denial
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, http.StatusForbidden, d.Status)
+		assert.Equal(t, "Forbidden", d.Error)
+	})
+
+	t.Run("renew someone else loan", func(t *testing.T) {
+		l, _ := entity.NewLoan(userEntity.NewID(), b.ID, time.Hour)
+		borrowMock.EXPECT().GetLoan(l.ID.String()).Return(l, nil)
+		req := httptest.NewRequest("POST", fmt.Sprintf("/borrow/%s/renew", l.ID.String()), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("hold for someone else", func(t *testing.T) {
+		payload := fmt.Sprintf(`{"user_id": "%s"}`, userEntity.NewID().String())
+		req := httptest.NewRequest("POST", fmt.Sprintf("/book/%s/hold", b.ID.String()), strings.NewReader(payload))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+// signedIn plays the authentication middleware, every request is made by p
+func signedIn(p router.Principal) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(router.WithPrincipal(r.Context(), p)))
+		})
+	}
 }
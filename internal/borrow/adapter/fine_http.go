@@ -0,0 +1,182 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	auth "github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/usecase"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	userUseCase "github.com/sgraham785/gocleanarch-example/internal/user/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+// TransactionHTTP JSON data, amounts are in cents
+type TransactionHTTP struct {
+	ID        entity.ID              `json:"id"`
+	Kind      entity.TransactionKind `json:"kind"`
+	Amount    int64                  `json:"amount"`
+	LoanID    *entity.ID             `json:"loan_id,omitempty"`
+	ChargeID  *entity.ID             `json:"charge_id,omitempty"`
+	Reason    string                 `json:"reason,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+func newTransactionHTTP(t *entity.Transaction) *TransactionHTTP {
+	toJ := &TransactionHTTP{
+		ID:        t.ID,
+		Kind:      t.Kind,
+		Amount:    t.Amount,
+		Reason:    t.Reason,
+		CreatedAt: t.CreatedAt,
+	}
+	if !t.LoanID.IsNil() {
+		toJ.LoanID = &t.LoanID
+	}
+	if !t.ChargeID.IsNil() {
+		toJ.ChargeID = &t.ChargeID
+	}
+	return toJ
+}
+
+// LedgerHTTP JSON data
+type LedgerHTTP struct {
+	Balance      int64              `json:"balance"`
+	Transactions []*TransactionHTTP `json:"transactions"`
+}
+
+// fineUser finds the user of the request, writes the response and returns nil when it fails
+func fineUser(w http.ResponseWriter, r *http.Request, userUseCase userUseCase.UserUseCase, errorMessage string) *userEntity.User {
+	u, err := userUseCase.GetUser(chi.URLParam(r, "userID"))
+	if err != nil && err != userEntity.ErrUserNotFound {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(errorMessage))
+		return nil
+	}
+	if u == nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(errorMessage))
+		return nil
+	}
+	return u
+}
+
+// GetLedgerHTTP handler
+func GetLedgerHTTP(userUseCase userUseCase.UserUseCase, fineUseCase usecase.FineUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error reading fines"
+		u := fineUser(w, r, userUseCase, errorMessage)
+		if u == nil {
+			return
+		}
+		ledger, err := fineUseCase.GetLedger(u.ID)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := &LedgerHTTP{
+			Balance:      ledger.Balance(),
+			Transactions: []*TransactionHTTP{},
+		}
+		for _, t := range ledger {
+			toJ.Transactions = append(toJ.Transactions, newTransactionHTTP(t))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// PayFineHTTP handler
+func PayFineHTTP(userUseCase userUseCase.UserUseCase, fineUseCase usecase.FineUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error paying fines"
+		var input struct {
+			Amount int64 `json:"amount"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil || input.Amount <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		u := fineUser(w, r, userUseCase, errorMessage)
+		if u == nil {
+			return
+		}
+		t, err := fineUseCase.Pay(u.ID, input.Amount)
+		switch err {
+		case nil:
+		case entity.ErrNothingOwed:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newTransactionHTTP(t)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// WaiveFineHTTP handler
+func WaiveFineHTTP(userUseCase userUseCase.UserUseCase, fineUseCase usecase.FineUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error waiving fine"
+		var input struct {
+			Reason string `json:"reason"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil || input.Reason == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		u := fineUser(w, r, userUseCase, errorMessage)
+		if u == nil {
+			return
+		}
+		t, err := fineUseCase.Waive(u.ID, chi.URLParam(r, "chargeID"), input.Reason)
+		switch err {
+		case nil:
+		case entity.ErrTransactionNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrChargeAlreadyWaived, entity.ErrNothingOwed:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(newTransactionHTTP(t)); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// FineHTTPRoutes make url handlers
+func FineHTTPRoutes(s *server.Server, userUseCase userUseCase.UserUseCase, fineUseCase usecase.FineUseCase) {
+	s.Router.Chi.With(router.Authorize(auth.Self, auth.Staff)).Get("/user/{userID}/fines", GetLedgerHTTP(userUseCase, fineUseCase))
+	s.Router.Chi.With(router.Authorize(auth.Staff)).Post("/user/{userID}/fines/payments", PayFineHTTP(userUseCase, fineUseCase))
+	s.Router.Chi.With(router.Authorize(auth.Staff)).Post("/user/{userID}/fines/{chargeID}/waive", WaiveFineHTTP(userUseCase, fineUseCase))
+}
@@ -0,0 +1,97 @@
+package adapter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+
+	"github.com/golang/mock/gomock"
+	borrowMock "github.com/sgraham785/gocleanarch-example/internal/borrow/mock"
+	userMock "github.com/sgraham785/gocleanarch-example/internal/user/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFineHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	userMock := userMock.NewMockUserUseCase(controller)
+	fineMock := borrowMock.NewMockFineUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.FineHTTPRoutes(s, userMock, fineMock)
+	ts := httptest.NewServer(r.Chi)
+	defer ts.Close()
+
+	u := &userEntity.User{
+		ID: userEntity.NewID(),
+	}
+	charge, _ := entity.NewCharge(u.ID, entity.NewID(), 300)
+
+	t.Run("user not found", func(t *testing.T) {
+		uID := userEntity.NewID()
+		userMock.EXPECT().GetUser(uID.String()).Return(nil, userEntity.ErrUserNotFound)
+		res, err := http.Get(fmt.Sprintf("%s/user/%s/fines", ts.URL, uID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("ledger", func(t *testing.T) {
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		fineMock.EXPECT().GetLedger(u.ID).Return(entity.Ledger{charge}, nil)
+		res, err := http.Get(fmt.Sprintf("%s/user/%s/fines", ts.URL, u.ID.String()))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var d *adapter.LedgerHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, int64(300), d.Balance)
+		assert.Len(t, d.Transactions, 1)
+		assert.Equal(t, charge.ID, d.Transactions[0].ID)
+	})
+
+	t.Run("pay nothing owed", func(t *testing.T) {
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		fineMock.EXPECT().Pay(u.ID, int64(100)).Return(nil, entity.ErrNothingOwed)
+		res, err := http.Post(fmt.Sprintf("%s/user/%s/fines/payments", ts.URL, u.ID.String()), "application/json", strings.NewReader(`{"amount": 100}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("pay", func(t *testing.T) {
+		p, _ := entity.NewPayment(u.ID, 100)
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		fineMock.EXPECT().Pay(u.ID, int64(100)).Return(p, nil)
+		res, err := http.Post(fmt.Sprintf("%s/user/%s/fines/payments", ts.URL, u.ID.String()), "application/json", strings.NewReader(`{"amount": 100}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+	})
+
+	t.Run("waive without reason", func(t *testing.T) {
+		res, err := http.Post(fmt.Sprintf("%s/user/%s/fines/%s/waive", ts.URL, u.ID.String(), charge.ID.String()), "application/json", strings.NewReader(`{}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("waive", func(t *testing.T) {
+		w, _ := entity.NewWaiver(charge, 300, "first offence")
+		userMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
+		fineMock.EXPECT().Waive(u.ID, charge.ID.String(), "first offence").Return(w, nil)
+		res, err := http.Post(fmt.Sprintf("%s/user/%s/fines/%s/waive", ts.URL, u.ID.String(), charge.ID.String()), "application/json", strings.NewReader(`{"reason": "first offence"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+		var d *adapter.TransactionHTTP
+		json.NewDecoder(res.Body).Decode(&d)
+		assert.Equal(t, charge.ID, *d.ChargeID)
+	})
+}
@@ -10,3 +10,72 @@ var ErrBookAlreadyBorrowed = errors.New("Book already borrowed")
 
 // ErrBookNotBorrowed cannot return
 var ErrBookNotBorrowed = errors.New("Book not borrowed")
+
+// ErrLoanNotFound not found
+var ErrLoanNotFound = errors.New("Loan not found")
+
+// ErrInvalidLoanEntity invalid loan entity
+var ErrInvalidLoanEntity = errors.New("Invalid loan entity")
+
+// ErrLoanAlreadyReturned cannot return twice
+var ErrLoanAlreadyReturned = errors.New("Loan already returned")
+
+// ErrLoanNotOverdue loan is within its due date
+var ErrLoanNotOverdue = errors.New("Loan not overdue")
+
+// ErrRenewalLimitReached cannot renew
+var ErrRenewalLimitReached = errors.New("Renewal limit reached")
+
+// ErrLoanTooOverdue cannot renew
+var ErrLoanTooOverdue = errors.New("Loan overdue beyond grace period")
+
+// ErrBookOnHold cannot renew
+var ErrBookOnHold = errors.New("Book on hold for another user")
+
+// ErrHoldNotFound not found
+var ErrHoldNotFound = errors.New("Hold not found")
+
+// ErrInvalidHoldEntity invalid hold entity
+var ErrInvalidHoldEntity = errors.New("Invalid hold entity")
+
+// ErrHoldNotWaiting cannot be made ready
+var ErrHoldNotWaiting = errors.New("Hold not waiting")
+
+// ErrHoldClosed cannot be fulfilled
+var ErrHoldClosed = errors.New("Hold closed")
+
+// ErrHoldNotExpired cannot expire
+var ErrHoldNotExpired = errors.New("Hold not expired")
+
+// ErrHoldAlreadyPlaced cannot hold twice
+var ErrHoldAlreadyPlaced = errors.New("Hold already placed")
+
+// ErrBookAvailable cannot hold, borrow instead
+var ErrBookAvailable = errors.New("Book available")
+
+// ErrTransactionNotFound not found
+var ErrTransactionNotFound = errors.New("Transaction not found")
+
+// ErrInvalidTransactionEntity invalid transaction entity
+var ErrInvalidTransactionEntity = errors.New("Invalid transaction entity")
+
+// ErrChargeAlreadyWaived cannot waive twice
+var ErrChargeAlreadyWaived = errors.New("Charge already waived")
+
+// ErrNothingOwed cannot pay or waive
+var ErrNothingOwed = errors.New("Nothing owed")
+
+// ErrOutstandingFines cannot borrow
+var ErrOutstandingFines = errors.New("Outstanding fines")
+
+// ErrBorrowLimitReached cannot borrow
+var ErrBorrowLimitReached = errors.New("Borrow limit reached")
+
+// ErrInvalidLoanFilter invalid borrow history filter
+var ErrInvalidLoanFilter = errors.New("Invalid loan filter")
+
+// ErrUserNotVerified cannot borrow before verifying the email
+var ErrUserNotVerified = errors.New("User email not verified")
+
+// ErrWorkWithoutEditions cannot hold a work with no edition to lend
+var ErrWorkWithoutEditions = errors.New("Work has no editions")
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+// TransactionKind is the kind of a fines ledger entry
+type TransactionKind string
+
+const (
+	// TransactionCharge the user owes a fine
+	TransactionCharge TransactionKind = "charge"
+	// TransactionPayment the user paid
+	TransactionPayment TransactionKind = "payment"
+	// TransactionWaiver a librarian cancelled a charge
+	TransactionWaiver TransactionKind = "waiver"
+)
+
+// Transaction is an entry of an user fines ledger, amounts are in cents
+type Transaction struct {
+	ID        ID
+	UserID    userEntity.ID
+	LoanID    ID
+	ChargeID  ID
+	Kind      TransactionKind
+	Amount    int64
+	Reason    string
+	CreatedAt time.Time
+}
+
+// NewCharge fines an user for a loan
+func NewCharge(userID userEntity.ID, loanID ID, amount int64) (*Transaction, error) {
+	return newTransaction(&Transaction{
+		UserID: userID,
+		LoanID: loanID,
+		Kind:   TransactionCharge,
+		Amount: amount,
+	})
+}
+
+// NewPayment records money received from an user
+func NewPayment(userID userEntity.ID, amount int64) (*Transaction, error) {
+	return newTransaction(&Transaction{
+		UserID: userID,
+		Kind:   TransactionPayment,
+		Amount: amount,
+	})
+}
+
+// NewWaiver cancels amount of a charge
+func NewWaiver(charge *Transaction, amount int64, reason string) (*Transaction, error) {
+	if charge.Kind != TransactionCharge {
+		return nil, ErrInvalidTransactionEntity
+	}
+	return newTransaction(&Transaction{
+		UserID:   charge.UserID,
+		LoanID:   charge.LoanID,
+		ChargeID: charge.ID,
+		Kind:     TransactionWaiver,
+		Amount:   amount,
+		Reason:   reason,
+	})
+}
+
+func newTransaction(t *Transaction) (*Transaction, error) {
+	t.ID = xid.New()
+	t.CreatedAt = time.Now()
+	err := t.Validate()
+	if err != nil {
+		return nil, ErrInvalidTransactionEntity
+	}
+	return t, nil
+}
+
+// Validate validate transaction
+func (t *Transaction) Validate() error {
+	if t.UserID.IsNil() || t.Amount <= 0 {
+		return ErrInvalidTransactionEntity
+	}
+	if t.Kind == TransactionWaiver && (t.ChargeID.IsNil() || t.Reason == "") {
+		return ErrInvalidTransactionEntity
+	}
+	if t.Kind == TransactionCharge && t.LoanID.IsNil() {
+		return ErrInvalidTransactionEntity
+	}
+	return nil
+}
+
+// Ledger is the fines history of an user
+type Ledger []*Transaction
+
+// Balance is what the user still owes, in cents
+func (l Ledger) Balance() int64 {
+	var balance int64
+	for _, t := range l {
+		switch t.Kind {
+		case TransactionCharge:
+			balance += t.Amount
+		case TransactionPayment, TransactionWaiver:
+			balance -= t.Amount
+		}
+	}
+	return balance
+}
+
+// Charge finds a charge in the ledger
+func (l Ledger) Charge(id ID) (*Transaction, error) {
+	for _, t := range l {
+		if t.ID == id && t.Kind == TransactionCharge {
+			return t, nil
+		}
+	}
+	return nil, ErrTransactionNotFound
+}
+
+// Waived tells if a charge was cancelled
+func (l Ledger) Waived(chargeID ID) bool {
+	for _, t := range l {
+		if t.Kind == TransactionWaiver && t.ChargeID == chargeID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoan_LateFee(t *testing.T) {
+	type test struct {
+		late time.Duration
+		want int64
+	}
+
+	tests := []test{
+		{late: -time.Hour, want: 0},
+		{late: time.Minute, want: 25},
+		{late: 24 * time.Hour, want: 25},
+		{late: 25 * time.Hour, want: 50},
+		{late: 100 * 24 * time.Hour, want: 1000},
+	}
+	for _, tc := range tests {
+		l, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+		assert.Equal(t, int64(0), l.LateFee(25, 1000))
+		_ = l.Return()
+		l.ReturnedAt = l.DueAt.Add(tc.late)
+		assert.Equal(t, tc.want, l.LateFee(25, 1000))
+	}
+}
+
+func TestLedger(t *testing.T) {
+	uID := userEntity.NewID()
+	charge, err := entity.NewCharge(uID, entity.NewID(), 300)
+	assert.Nil(t, err)
+	payment, err := entity.NewPayment(uID, 100)
+	assert.Nil(t, err)
+	waiver, err := entity.NewWaiver(charge, 50, "first offence")
+	assert.Nil(t, err)
+	ledger := entity.Ledger{charge, payment, waiver}
+	assert.Equal(t, int64(150), ledger.Balance())
+	found, err := ledger.Charge(charge.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, charge, found)
+	_, err = ledger.Charge(payment.ID)
+	assert.Equal(t, entity.ErrTransactionNotFound, err)
+	assert.True(t, ledger.Waived(charge.ID))
+	_, err = entity.NewWaiver(payment, 50, "not a charge")
+	assert.Equal(t, entity.ErrInvalidTransactionEntity, err)
+}
+
+func TestTransaction_Validate(t *testing.T) {
+	uID := userEntity.NewID()
+	charge, _ := entity.NewCharge(uID, entity.NewID(), 100)
+
+	_, err := entity.NewCharge(uID, entity.NewID(), 0)
+	assert.Equal(t, entity.ErrInvalidTransactionEntity, err)
+	_, err = entity.NewCharge(uID, entity.ID{}, 100)
+	assert.Equal(t, entity.ErrInvalidTransactionEntity, err)
+	_, err = entity.NewPayment(userEntity.ID{}, 100)
+	assert.Equal(t, entity.ErrInvalidTransactionEntity, err)
+	_, err = entity.NewWaiver(charge, 100, "")
+	assert.Equal(t, entity.ErrInvalidTransactionEntity, err)
+}
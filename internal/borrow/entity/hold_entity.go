@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+// HoldStatus is the state of a hold
+type HoldStatus string
+
+const (
+	// HoldWaiting the user is queued for the book
+	HoldWaiting HoldStatus = "waiting"
+	// HoldReady a copy is set aside for the user to pick up
+	HoldReady HoldStatus = "ready"
+	// HoldFulfilled the user borrowed the book
+	HoldFulfilled HoldStatus = "fulfilled"
+	// HoldExpired the user did not pick up the book in time
+	HoldExpired HoldStatus = "expired"
+)
+
+// Hold entity. A hold on a work waits for any edition of it: its BookID stays
+// nil until a copy of one edition is set aside.
+type Hold struct {
+	ID        ID
+	UserID    userEntity.ID
+	BookID    bookEntity.ID
+	WorkID    bookEntity.ID
+	Status    HoldStatus
+	PlacedAt  time.Time
+	ReadyAt   time.Time
+	ExpiresAt time.Time
+}
+
+// NewHold queues an user for a book
+func NewHold(userID userEntity.ID, bookID bookEntity.ID) (*Hold, error) {
+	h := &Hold{
+		ID:       xid.New(),
+		UserID:   userID,
+		BookID:   bookID,
+		Status:   HoldWaiting,
+		PlacedAt: time.Now(),
+	}
+	err := h.Validate()
+	if err != nil {
+		return nil, ErrInvalidHoldEntity
+	}
+	return h, nil
+}
+
+// NewWorkHold queues an user for any edition of a work
+func NewWorkHold(userID userEntity.ID, workID bookEntity.ID) (*Hold, error) {
+	h := &Hold{
+		ID:       xid.New(),
+		UserID:   userID,
+		WorkID:   workID,
+		Status:   HoldWaiting,
+		PlacedAt: time.Now(),
+	}
+	err := h.Validate()
+	if err != nil {
+		return nil, ErrInvalidHoldEntity
+	}
+	return h, nil
+}
+
+// IsOpen tells if the hold still waits for a copy or for its pick up
+func (h *Hold) IsOpen() bool {
+	return h.Status == HoldWaiting || h.Status == HoldReady
+}
+
+// IsReady tells if a copy is set aside for the user at t
+func (h *Hold) IsReady(t time.Time) bool {
+	return h.Status == HoldReady && t.Before(h.ExpiresAt)
+}
+
+// MarkReady sets a copy aside for the user to pick up within window
+func (h *Hold) MarkReady(window time.Duration) error {
+	if h.Status != HoldWaiting {
+		return ErrHoldNotWaiting
+	}
+	h.Status = HoldReady
+	h.ReadyAt = time.Now()
+	h.ExpiresAt = h.ReadyAt.Add(window)
+	return nil
+}
+
+// Fulfill closes the hold once the user borrowed the book
+func (h *Hold) Fulfill() error {
+	if !h.IsOpen() {
+		return ErrHoldClosed
+	}
+	h.Status = HoldFulfilled
+	return nil
+}
+
+// Expire closes a ready hold not picked up by t
+func (h *Hold) Expire(t time.Time) error {
+	if h.Status != HoldReady || t.Before(h.ExpiresAt) {
+		return ErrHoldNotExpired
+	}
+	h.Status = HoldExpired
+	return nil
+}
+
+// Validate validate hold, it is on a book or on a work
+func (h *Hold) Validate() error {
+	if h.UserID.IsNil() || h.BookID.IsNil() && h.WorkID.IsNil() {
+		return ErrInvalidHoldEntity
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHold(t *testing.T) {
+	h, err := entity.NewHold(userEntity.NewID(), bookEntity.NewID())
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldWaiting, h.Status)
+	assert.True(t, h.IsOpen())
+	assert.False(t, h.IsReady(time.Now()))
+
+	_, err = entity.NewHold(userEntity.NewID(), bookEntity.ID{})
+	assert.Equal(t, entity.ErrInvalidHoldEntity, err)
+}
+
+func TestNewWorkHold(t *testing.T) {
+	h, err := entity.NewWorkHold(userEntity.NewID(), bookEntity.NewID())
+	assert.Nil(t, err)
+	assert.True(t, h.BookID.IsNil())
+	assert.True(t, h.IsOpen())
+
+	_, err = entity.NewWorkHold(userEntity.NewID(), bookEntity.ID{})
+	assert.Equal(t, entity.ErrInvalidHoldEntity, err)
+}
+
+func TestHold_MarkReady(t *testing.T) {
+	h, _ := entity.NewHold(userEntity.NewID(), bookEntity.NewID())
+	err := h.MarkReady(time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldReady, h.Status)
+	assert.True(t, h.IsReady(time.Now()))
+	assert.False(t, h.IsReady(h.ExpiresAt))
+	err = h.MarkReady(time.Hour)
+	assert.Equal(t, entity.ErrHoldNotWaiting, err)
+}
+
+func TestHold_Expire(t *testing.T) {
+	h, _ := entity.NewHold(userEntity.NewID(), bookEntity.NewID())
+	err := h.Expire(time.Now())
+	assert.Equal(t, entity.ErrHoldNotExpired, err)
+	_ = h.MarkReady(time.Hour)
+	err = h.Expire(time.Now())
+	assert.Equal(t, entity.ErrHoldNotExpired, err)
+	err = h.Expire(h.ExpiresAt)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldExpired, h.Status)
+	assert.False(t, h.IsOpen())
+}
+
+func TestHold_Fulfill(t *testing.T) {
+	h, _ := entity.NewHold(userEntity.NewID(), bookEntity.NewID())
+	err := h.Fulfill()
+	assert.Nil(t, err)
+	assert.Equal(t, entity.HoldFulfilled, h.Status)
+	err = h.Fulfill()
+	assert.Equal(t, entity.ErrHoldClosed, err)
+}
@@ -0,0 +1,141 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+// ID is id for loan
+type ID = xid.ID
+
+// NewID create a new loan entity ID
+func NewID() ID {
+	return xid.New()
+}
+
+func IDFromString(id string) (xid.ID, error) {
+	i, err := xid.FromString(id)
+	return i, err
+}
+
+// LoanStatus is the state of a loan
+type LoanStatus string
+
+const (
+	// LoanActive the book is out with the user
+	LoanActive LoanStatus = "active"
+	// LoanOverdue the book is still out past its due date
+	LoanOverdue LoanStatus = "overdue"
+	// LoanReturned the book was brought back
+	LoanReturned LoanStatus = "returned"
+)
+
+// Loan entity, Barcode is the copy the user took home
+type Loan struct {
+	ID         ID
+	UserID     userEntity.ID
+	BookID     bookEntity.ID
+	Barcode    string
+	Status     LoanStatus
+	BorrowedAt time.Time
+	DueAt      time.Time
+	ReturnedAt time.Time
+	Renewals   int
+}
+
+// NewLoan creates a new loan of a book to an user, due after period
+func NewLoan(userID userEntity.ID, bookID bookEntity.ID, period time.Duration) (*Loan, error) {
+	now := time.Now()
+	l := &Loan{
+		ID:         xid.New(),
+		UserID:     userID,
+		BookID:     bookID,
+		Status:     LoanActive,
+		BorrowedAt: now,
+		DueAt:      now.Add(period),
+	}
+	err := l.Validate()
+	if err != nil {
+		return nil, ErrInvalidLoanEntity
+	}
+	return l, nil
+}
+
+// IsActive tells if the book is still out
+func (l *Loan) IsActive() bool {
+	return l.ReturnedAt.IsZero()
+}
+
+// IsOverdue tells if the book should have been back by t
+func (l *Loan) IsOverdue(t time.Time) bool {
+	return l.IsActive() && t.After(l.DueAt)
+}
+
+// MarkOverdue flags a loan that is past its due date at t
+func (l *Loan) MarkOverdue(t time.Time) error {
+	if !l.IsOverdue(t) {
+		return ErrLoanNotOverdue
+	}
+	l.Status = LoanOverdue
+	return nil
+}
+
+// Renew extends the due date by period, unless the loan was renewed
+// maxRenewals times already or is overdue for longer than grace
+func (l *Loan) Renew(period time.Duration, maxRenewals int, grace time.Duration) error {
+	if !l.IsActive() {
+		return ErrLoanAlreadyReturned
+	}
+	if l.Renewals >= maxRenewals {
+		return ErrRenewalLimitReached
+	}
+	now := time.Now()
+	if l.IsOverdue(now.Add(-grace)) {
+		return ErrLoanTooOverdue
+	}
+	l.Renewals++
+	l.DueAt = l.DueAt.Add(period)
+	if !l.IsOverdue(now) {
+		l.Status = LoanActive
+	}
+	return nil
+}
+
+// Return closes the loan
+func (l *Loan) Return() error {
+	if !l.IsActive() {
+		return ErrLoanAlreadyReturned
+	}
+	l.ReturnedAt = time.Now()
+	l.Status = LoanReturned
+	return nil
+}
+
+// LateFee is the fine for returning the book after its due date, in cents.
+// Every started day counts, up to max.
+func (l *Loan) LateFee(dailyRate, max int64) int64 {
+	if l.IsActive() || !l.ReturnedAt.After(l.DueAt) {
+		return 0
+	}
+	late := l.ReturnedAt.Sub(l.DueAt)
+	days := int64(late / (24 * time.Hour))
+	if late%(24*time.Hour) > 0 {
+		days++
+	}
+	fee := days * dailyRate
+	if fee > max {
+		return max
+	}
+	return fee
+}
+
+// Validate validate loan
+func (l *Loan) Validate() error {
+	if l.UserID.IsNil() || l.BookID.IsNil() || !l.DueAt.After(l.BorrowedAt) {
+		return ErrInvalidLoanEntity
+	}
+	return nil
+}
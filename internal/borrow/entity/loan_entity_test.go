@@ -0,0 +1,115 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoan(t *testing.T) {
+	uID := userEntity.NewID()
+	bID := bookEntity.NewID()
+	l, err := entity.NewLoan(uID, bID, 24*time.Hour)
+	assert.Nil(t, err)
+	assert.NotNil(t, l.ID)
+	assert.Equal(t, uID, l.UserID)
+	assert.Equal(t, bID, l.BookID)
+	assert.Equal(t, entity.LoanActive, l.Status)
+	assert.Equal(t, 24*time.Hour, l.DueAt.Sub(l.BorrowedAt))
+	assert.True(t, l.IsActive())
+}
+
+func TestLoan_Return(t *testing.T) {
+	l, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	err := l.Return()
+	assert.Nil(t, err)
+	assert.False(t, l.IsActive())
+	assert.Equal(t, entity.LoanReturned, l.Status)
+	assert.False(t, l.ReturnedAt.IsZero())
+	err = l.Return()
+	assert.Equal(t, entity.ErrLoanAlreadyReturned, err)
+}
+
+func TestLoan_MarkOverdue(t *testing.T) {
+	l, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	assert.False(t, l.IsOverdue(time.Now()))
+	err := l.MarkOverdue(time.Now())
+	assert.Equal(t, entity.ErrLoanNotOverdue, err)
+
+	later := l.DueAt.Add(time.Minute)
+	assert.True(t, l.IsOverdue(later))
+	err = l.MarkOverdue(later)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.LoanOverdue, l.Status)
+
+	_ = l.Return()
+	assert.False(t, l.IsOverdue(later))
+}
+
+func TestLoan_Renew(t *testing.T) {
+	l, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	due := l.DueAt
+	err := l.Renew(time.Hour, 1, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, l.Renewals)
+	assert.Equal(t, due.Add(time.Hour), l.DueAt)
+	err = l.Renew(time.Hour, 1, 0)
+	assert.Equal(t, entity.ErrRenewalLimitReached, err)
+
+	late, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	late.DueAt = time.Now().Add(-2 * time.Hour)
+	late.Status = entity.LoanOverdue
+	err = late.Renew(24*time.Hour, 2, time.Hour)
+	assert.Equal(t, entity.ErrLoanTooOverdue, err)
+	err = late.Renew(24*time.Hour, 2, 3*time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.LoanActive, late.Status)
+
+	_ = l.Return()
+	err = l.Renew(time.Hour, 5, 0)
+	assert.Equal(t, entity.ErrLoanAlreadyReturned, err)
+}
+
+func TestLoan_Validate(t *testing.T) {
+	type test struct {
+		userID userEntity.ID
+		bookID bookEntity.ID
+		period time.Duration
+		want   error
+	}
+
+	tests := []test{
+		{
+			userID: userEntity.NewID(),
+			bookID: bookEntity.NewID(),
+			period: time.Hour,
+			want:   nil,
+		},
+		{
+			bookID: bookEntity.NewID(),
+			period: time.Hour,
+			want:   entity.ErrInvalidLoanEntity,
+		},
+		{
+			userID: userEntity.NewID(),
+			period: time.Hour,
+			want:   entity.ErrInvalidLoanEntity,
+		},
+		{
+			userID: userEntity.NewID(),
+			bookID: bookEntity.NewID(),
+			period: 0,
+			want:   entity.ErrInvalidLoanEntity,
+		},
+	}
+	for _, tc := range tests {
+
+		_, err := entity.NewLoan(tc.userID, tc.bookID, tc.period)
+		assert.Equal(t, err, tc.want)
+	}
+
+}
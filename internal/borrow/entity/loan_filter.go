@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// LoanFilter narrows a borrow history. Status is active (still out),
+// overdue or returned; From and To bound the day the book was borrowed.
+// Zero fields match every loan.
+type LoanFilter struct {
+	Status LoanStatus
+	From   time.Time
+	To     time.Time
+}
+
+// Match tells if the loan passes the filter at t
+func (f LoanFilter) Match(l *Loan, t time.Time) bool {
+	switch f.Status {
+	case LoanActive:
+		if !l.IsActive() {
+			return false
+		}
+	case LoanOverdue:
+		if !l.IsOverdue(t) {
+			return false
+		}
+	case LoanReturned:
+		if l.IsActive() {
+			return false
+		}
+	}
+	if !f.From.IsZero() && l.BorrowedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !l.BorrowedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// Validate validate filter
+func (f LoanFilter) Validate() error {
+	switch f.Status {
+	case "", LoanActive, LoanOverdue, LoanReturned:
+	default:
+		return ErrInvalidLoanFilter
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.From.Before(f.To) {
+		return ErrInvalidLoanFilter
+	}
+	return nil
+}
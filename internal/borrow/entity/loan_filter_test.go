@@ -0,0 +1,71 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoanFilter_Match(t *testing.T) {
+	now := time.Now()
+	open, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	late, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	late.BorrowedAt = now.Add(-48 * time.Hour)
+	late.DueAt = now.Add(-24 * time.Hour)
+	returned, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	returned.BorrowedAt = now.Add(-400 * 24 * time.Hour)
+	_ = returned.Return()
+
+	type test struct {
+		filter entity.LoanFilter
+		want   []*entity.Loan
+	}
+
+	tests := []test{
+		{
+			filter: entity.LoanFilter{},
+			want:   []*entity.Loan{open, late, returned},
+		},
+		{
+			filter: entity.LoanFilter{Status: entity.LoanActive},
+			want:   []*entity.Loan{open, late},
+		},
+		{
+			filter: entity.LoanFilter{Status: entity.LoanOverdue},
+			want:   []*entity.Loan{late},
+		},
+		{
+			filter: entity.LoanFilter{Status: entity.LoanReturned},
+			want:   []*entity.Loan{returned},
+		},
+		{
+			filter: entity.LoanFilter{From: now.Add(-72 * time.Hour)},
+			want:   []*entity.Loan{open, late},
+		},
+		{
+			filter: entity.LoanFilter{To: now.Add(-time.Hour)},
+			want:   []*entity.Loan{late, returned},
+		},
+	}
+	for _, tc := range tests {
+		var got []*entity.Loan
+		for _, l := range []*entity.Loan{open, late, returned} {
+			if tc.filter.Match(l, now) {
+				got = append(got, l)
+			}
+		}
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestLoanFilter_Validate(t *testing.T) {
+	now := time.Now()
+	assert.Nil(t, entity.LoanFilter{}.Validate())
+	assert.Nil(t, entity.LoanFilter{Status: entity.LoanOverdue, From: now.Add(-time.Hour), To: now}.Validate())
+	assert.Equal(t, entity.ErrInvalidLoanFilter, entity.LoanFilter{Status: "lost"}.Validate())
+	assert.Equal(t, entity.ErrInvalidLoanFilter, entity.LoanFilter{From: now, To: now.Add(-time.Hour)}.Validate())
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+// Policy is what an user of a membership tier may borrow
+type Policy struct {
+	MaxLoans    int
+	LoanPeriod  time.Duration
+	MaxRenewals int
+}
+
+// Policies are the borrowing policies by membership tier
+type Policies map[userEntity.Tier]Policy
+
+// For finds the policy of a tier, users without a known tier get the standard one
+func (p Policies) For(t userEntity.Tier) Policy {
+	if policy, ok := p[t]; ok {
+		return policy
+	}
+	return p[userEntity.TierStandard]
+}
+
+// CanBorrow tells if an user with loans open may borrow one more book
+func (p Policy) CanBorrow(loans int) error {
+	if loans >= p.MaxLoans {
+		return ErrBorrowLimitReached
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicies_For(t *testing.T) {
+	policies := entity.Policies{
+		userEntity.TierStandard: {MaxLoans: 2, LoanPeriod: time.Hour, MaxRenewals: 1},
+		userEntity.TierStaff:    {MaxLoans: 10, LoanPeriod: 2 * time.Hour, MaxRenewals: 3},
+	}
+	assert.Equal(t, 10, policies.For(userEntity.TierStaff).MaxLoans)
+	assert.Equal(t, 2, policies.For(userEntity.TierStandard).MaxLoans)
+	assert.Equal(t, 2, policies.For("").MaxLoans)
+}
+
+func TestPolicy_CanBorrow(t *testing.T) {
+	type test struct {
+		loans int
+		want  error
+	}
+
+	p := entity.Policy{MaxLoans: 2}
+	tests := []test{
+		{
+			loans: 0,
+			want:  nil,
+		},
+		{
+			loans: 1,
+			want:  nil,
+		},
+		{
+			loans: 2,
+			want:  entity.ErrBorrowLimitReached,
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, p.CanBorrow(tc.loans))
+	}
+}
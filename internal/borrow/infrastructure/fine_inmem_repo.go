@@ -0,0 +1,41 @@
+package infrastructure
+
+import (
+	"sync"
+
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+type fineInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[userEntity.ID]entity.Ledger
+}
+
+// NewFineInMemRepo create fines in memory repository
+func NewFineInMemRepo() FineRepo {
+	var m = map[userEntity.ID]entity.Ledger{}
+	return &fineInMemRepo{
+		m: m,
+	}
+}
+
+// Create a transaction
+func (r *fineInMemRepo) Create(e *entity.Transaction) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.UserID] = append(r.m[e.UserID], e)
+	return e.ID, nil
+}
+
+// ListByUser lists the ledger of an user, oldest first
+func (r *fineInMemRepo) ListByUser(userID userEntity.ID) (entity.Ledger, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return append(entity.Ledger(nil), r.m[userID]...), nil
+}
+
+// LockByUser lists the ledger of an user, the in memory unit of work already runs alone
+func (r *fineInMemRepo) LockByUser(userID userEntity.ID) (entity.Ledger, error) {
+	return r.ListByUser(userID)
+}
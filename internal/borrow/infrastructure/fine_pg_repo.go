@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+const fineColumns = `id, user_id, loan_id, charge_id, kind, amount, reason, created_at`
+
+// finePgRepo pg database repo
+type finePgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewFinePgRepo create new fines postgres repo
+func NewFinePgRepo(s *server.Server) FineRepo {
+	return &finePgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// NewFineTxPgRepo create a fines postgres repo reading and writing through tx
+func NewFineTxPgRepo(tx *sqlx.Tx, l *logger.Logger) FineRepo {
+	return &finePgRepo{
+		db:  tx,
+		log: l,
+	}
+}
+
+// Create a transaction
+func (r *finePgRepo) Create(e *entity.Transaction) (entity.ID, error) {
+	query := `insert into fine_transaction (` + fineColumns + `) values($1,$2,$3,$4,$5,$6,$7,$8)`
+	_, err := r.db.Exec(query,
+		e.ID,
+		e.UserID,
+		e.LoanID,
+		e.ChargeID,
+		e.Kind,
+		e.Amount,
+		e.Reason,
+		e.CreatedAt,
+	)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// ListByUser lists the ledger of an user, oldest first
+func (r *finePgRepo) ListByUser(userID userEntity.ID) (entity.Ledger, error) {
+	return r.ledger(`select `+fineColumns+` from fine_transaction where user_id = $1 order by created_at`, userID)
+}
+
+// LockByUser lists the ledger of an user and locks its rows until the transaction ends.
+// The user row is locked first so an empty ledger is held too.
+func (r *finePgRepo) LockByUser(userID userEntity.ID) (entity.Ledger, error) {
+	_, err := r.db.Exec(`select id from "user" where id = $1 for update`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.ledger(`select `+fineColumns+` from fine_transaction where user_id = $1 order by created_at for update`, userID)
+}
+
+// ledger reads the transactions query selects
+func (r *finePgRepo) ledger(query string, args ...interface{}) (entity.Ledger, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ledger entity.Ledger
+	for rows.Next() {
+		var t entity.Transaction
+		err = rows.Scan(&t.ID, &t.UserID, &t.LoanID, &t.ChargeID, &t.Kind, &t.Amount, &t.Reason, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		ledger = append(ledger, &t)
+	}
+	return ledger, rows.Err()
+}
@@ -0,0 +1,26 @@
+package infrastructure
+
+import (
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+//go:generate mockgen -destination=../mock/fine_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure FineReader,FineWriter,FineRepo
+
+// FineReader interface
+type FineReader interface {
+	ListByUser(userID userEntity.ID) (entity.Ledger, error)
+	// LockByUser is ListByUser keeping the ledger from other units of work until this one ends
+	LockByUser(userID userEntity.ID) (entity.Ledger, error)
+}
+
+// FineWriter fines ledger writer, entries are never changed once written
+type FineWriter interface {
+	Create(e *entity.Transaction) (entity.ID, error)
+}
+
+// FineRepo interface
+type FineRepo interface {
+	FineReader
+	FineWriter
+}
@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+type holdInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[entity.ID]*entity.Hold
+}
+
+// NewHoldInMemRepo create hold in memory repository
+func NewHoldInMemRepo() HoldRepo {
+	var m = map[entity.ID]*entity.Hold{}
+	return &holdInMemRepo{
+		m: m,
+	}
+}
+
+// Create a hold
+func (r *holdInMemRepo) Create(e *entity.Hold) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Get a hold
+func (r *holdInMemRepo) Get(id entity.ID) (*entity.Hold, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrHoldNotFound
+	}
+	return r.m[id], nil
+}
+
+// Update a hold
+func (r *holdInMemRepo) Update(e *entity.Hold) error {
+	_, err := r.Get(e.ID)
+	if err != nil {
+		return err
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.ID] = e
+	return nil
+}
+
+// ListOpenByBook lists the waiting and ready holds of a book, first placed first
+func (r *holdInMemRepo) ListOpenByBook(bookID bookEntity.ID) ([]*entity.Hold, error) {
+	return r.filter(func(h *entity.Hold) bool {
+		return h.BookID == bookID && h.IsOpen()
+	}), nil
+}
+
+// ListOpenByWork lists the waiting and ready holds of a work, first placed first
+func (r *holdInMemRepo) ListOpenByWork(workID bookEntity.ID) ([]*entity.Hold, error) {
+	return r.filter(func(h *entity.Hold) bool {
+		return h.WorkID == workID && h.IsOpen()
+	}), nil
+}
+
+// CountOpenByWork counts the waiting and ready holds of a work
+func (r *holdInMemRepo) CountOpenByWork(workID bookEntity.ID) (int, error) {
+	holds, err := r.ListOpenByWork(workID)
+	return len(holds), err
+}
+
+// CountOpenByBook counts the waiting and ready holds of a book
+func (r *holdInMemRepo) CountOpenByBook(bookID bookEntity.ID) (int, error) {
+	holds, err := r.ListOpenByBook(bookID)
+	return len(holds), err
+}
+
+// ListByUser lists the holds of an user
+func (r *holdInMemRepo) ListByUser(userID userEntity.ID) ([]*entity.Hold, error) {
+	return r.filter(func(h *entity.Hold) bool {
+		return h.UserID == userID
+	}), nil
+}
+
+// ListExpired lists the ready holds not picked up by at
+func (r *holdInMemRepo) ListExpired(at time.Time) ([]*entity.Hold, error) {
+	return r.filter(func(h *entity.Hold) bool {
+		return h.Status == entity.HoldReady && !at.Before(h.ExpiresAt)
+	}), nil
+}
+
+// filter returns the matching holds, first placed first
+func (r *holdInMemRepo) filter(match func(h *entity.Hold) bool) []*entity.Hold {
+	var d []*entity.Hold
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	for _, j := range r.m {
+		if match(j) {
+			d = append(d, j)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool {
+		return d[i].PlacedAt.Before(d[j].PlacedAt)
+	})
+	return d
+}
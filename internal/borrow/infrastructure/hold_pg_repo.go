@@ -0,0 +1,146 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+const holdColumns = `id, user_id, book_id, work_id, status, placed_at, ready_at, expires_at`
+
+// holdPgRepo pg database repo
+type holdPgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewHoldPgRepo create new hold postgres repo
+func NewHoldPgRepo(s *server.Server) HoldRepo {
+	return &holdPgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// NewHoldTxPgRepo create a hold postgres repo writing through tx
+func NewHoldTxPgRepo(tx *sqlx.Tx, l *logger.Logger) HoldRepo {
+	return &holdPgRepo{
+		db:  tx,
+		log: l,
+	}
+}
+
+// Create a hold
+func (r *holdPgRepo) Create(e *entity.Hold) (entity.ID, error) {
+	query := `insert into hold (` + holdColumns + `) values($1,$2,$3,$4,$5,$6,$7,$8)`
+	_, err := r.db.Exec(query,
+		e.ID,
+		e.UserID,
+		e.BookID,
+		e.WorkID,
+		e.Status,
+		e.PlacedAt,
+		nullTime(e.ReadyAt),
+		nullTime(e.ExpiresAt),
+	)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get a hold
+func (r *holdPgRepo) Get(id entity.ID) (*entity.Hold, error) {
+	holds, err := r.query(`select `+holdColumns+` from hold where id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, entity.ErrHoldNotFound
+	}
+	return holds[0], nil
+}
+
+// Update a hold, a hold on a work gets the book set aside for it
+func (r *holdPgRepo) Update(e *entity.Hold) error {
+	query := `update hold set book_id = $1, status = $2, ready_at = $3, expires_at = $4, updated_at = $5 where id = $6`
+	res, err := r.db.Exec(query, e.BookID, e.Status, nullTime(e.ReadyAt), nullTime(e.ExpiresAt), time.Now(), e.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrHoldNotFound
+	}
+	return nil
+}
+
+// ListOpenByBook lists the waiting and ready holds of a book, first placed first
+func (r *holdPgRepo) ListOpenByBook(bookID bookEntity.ID) ([]*entity.Hold, error) {
+	return r.query(`select `+holdColumns+` from hold where book_id = $1 and status in ($2, $3) order by placed_at`,
+		bookID, entity.HoldWaiting, entity.HoldReady)
+}
+
+// ListOpenByWork lists the waiting and ready holds of a work, first placed first
+func (r *holdPgRepo) ListOpenByWork(workID bookEntity.ID) ([]*entity.Hold, error) {
+	return r.query(`select `+holdColumns+` from hold where work_id = $1 and status in ($2, $3) order by placed_at`,
+		workID, entity.HoldWaiting, entity.HoldReady)
+}
+
+// CountOpenByWork counts the waiting and ready holds of a work
+func (r *holdPgRepo) CountOpenByWork(workID bookEntity.ID) (int, error) {
+	var n int
+	err := r.db.QueryRowx(`select count(*) from hold where work_id = $1 and status in ($2, $3)`,
+		workID, entity.HoldWaiting, entity.HoldReady).Scan(&n)
+	return n, err
+}
+
+// CountOpenByBook counts the waiting and ready holds of a book
+func (r *holdPgRepo) CountOpenByBook(bookID bookEntity.ID) (int, error) {
+	var n int
+	err := r.db.QueryRowx(`select count(*) from hold where book_id = $1 and status in ($2, $3)`,
+		bookID, entity.HoldWaiting, entity.HoldReady).Scan(&n)
+	return n, err
+}
+
+// ListByUser lists the holds of an user
+func (r *holdPgRepo) ListByUser(userID userEntity.ID) ([]*entity.Hold, error) {
+	return r.query(`select `+holdColumns+` from hold where user_id = $1 order by placed_at`, userID)
+}
+
+// ListExpired lists the ready holds not picked up by at
+func (r *holdPgRepo) ListExpired(at time.Time) ([]*entity.Hold, error) {
+	return r.query(`select `+holdColumns+` from hold where status = $1 and expires_at <= $2 order by placed_at`,
+		entity.HoldReady, at)
+}
+
+func (r *holdPgRepo) query(query string, args ...interface{}) ([]*entity.Hold, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var holds []*entity.Hold
+	for rows.Next() {
+		var h entity.Hold
+		var readyAt, expiresAt sql.NullTime
+		err = rows.Scan(&h.ID, &h.UserID, &h.BookID, &h.WorkID, &h.Status, &h.PlacedAt, &readyAt, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		h.ReadyAt = readyAt.Time
+		h.ExpiresAt = expiresAt.Time
+		holds = append(holds, &h)
+	}
+	return holds, rows.Err()
+}
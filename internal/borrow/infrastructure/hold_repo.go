@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+//go:generate mockgen -destination=../mock/hold_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure HoldReader,HoldWriter,HoldRepo
+
+// HoldReader interface, the holds of a work are those placed on it, not on its editions
+type HoldReader interface {
+	Get(id entity.ID) (*entity.Hold, error)
+	ListOpenByBook(bookID bookEntity.ID) ([]*entity.Hold, error)
+	ListOpenByWork(workID bookEntity.ID) ([]*entity.Hold, error)
+	CountOpenByWork(workID bookEntity.ID) (int, error)
+	CountOpenByBook(bookID bookEntity.ID) (int, error)
+	ListByUser(userID userEntity.ID) ([]*entity.Hold, error)
+	ListExpired(at time.Time) ([]*entity.Hold, error)
+}
+
+// HoldWriter hold writer
+type HoldWriter interface {
+	Create(e *entity.Hold) (entity.ID, error)
+	Update(e *entity.Hold) error
+}
+
+// HoldRepo interface
+type HoldRepo interface {
+	HoldReader
+	HoldWriter
+}
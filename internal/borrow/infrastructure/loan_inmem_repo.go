@@ -0,0 +1,122 @@
+package infrastructure
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+type loanInMemRepo struct {
+	mtx sync.RWMutex
+	m   map[entity.ID]*entity.Loan
+}
+
+// NewInMemRepo create loan in memory repository
+func NewInMemRepo() LoanRepo {
+	var m = map[entity.ID]*entity.Loan{}
+	return &loanInMemRepo{
+		m: m,
+	}
+}
+
+// Create a loan
+func (r *loanInMemRepo) Create(e *entity.Loan) (entity.ID, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.ID] = e
+	return e.ID, nil
+}
+
+// Get a loan
+func (r *loanInMemRepo) Get(id entity.ID) (*entity.Loan, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if r.m[id] == nil {
+		return nil, entity.ErrLoanNotFound
+	}
+	return r.m[id], nil
+}
+
+// GetActive finds the open loan of a book by an user
+func (r *loanInMemRepo) GetActive(userID userEntity.ID, bookID bookEntity.ID) (*entity.Loan, error) {
+	loans := r.filter(func(l *entity.Loan) bool {
+		return l.UserID == userID && l.BookID == bookID && l.IsActive()
+	})
+	if len(loans) == 0 {
+		return nil, entity.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+// GetActiveByBarcode finds the open loan of a copy
+func (r *loanInMemRepo) GetActiveByBarcode(barcode string) (*entity.Loan, error) {
+	loans := r.filter(func(l *entity.Loan) bool {
+		return l.Barcode == barcode && l.IsActive()
+	})
+	if len(loans) == 0 {
+		return nil, entity.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+// Update a loan
+func (r *loanInMemRepo) Update(e *entity.Loan) error {
+	_, err := r.Get(e.ID)
+	if err != nil {
+		return err
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.m[e.ID] = e
+	return nil
+}
+
+// ListByUser lists the loans of an user passing f
+func (r *loanInMemRepo) ListByUser(userID userEntity.ID, f entity.LoanFilter) ([]*entity.Loan, error) {
+	now := time.Now()
+	return r.filter(func(l *entity.Loan) bool {
+		return l.UserID == userID && f.Match(l, now)
+	}), nil
+}
+
+// ListByBook lists the loans of a book passing f
+func (r *loanInMemRepo) ListByBook(bookID bookEntity.ID, f entity.LoanFilter) ([]*entity.Loan, error) {
+	now := time.Now()
+	return r.filter(func(l *entity.Loan) bool {
+		return l.BookID == bookID && f.Match(l, now)
+	}), nil
+}
+
+// ListOverdue lists the open loans due before at
+func (r *loanInMemRepo) ListOverdue(at time.Time) ([]*entity.Loan, error) {
+	return r.filter(func(l *entity.Loan) bool {
+		return l.IsOverdue(at)
+	}), nil
+}
+
+// List loans
+func (r *loanInMemRepo) List() ([]*entity.Loan, error) {
+	return r.filter(func(l *entity.Loan) bool {
+		return true
+	}), nil
+}
+
+// filter returns the matching loans, oldest first
+func (r *loanInMemRepo) filter(match func(l *entity.Loan) bool) []*entity.Loan {
+	var d []*entity.Loan
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	for _, j := range r.m {
+		if match(j) {
+			d = append(d, j)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool {
+		return d[i].BorrowedAt.Before(d[j].BorrowedAt)
+	})
+	return d
+}
@@ -0,0 +1,184 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+const loanColumns = `id, user_id, book_id, barcode, status, borrowed_at, due_at, returned_at, renewals`
+
+// loanPgRepo pg database repo
+type loanPgRepo struct {
+	db  repository.Querier
+	log *logger.Logger
+}
+
+// NewPgRepo create new loan postgres repo
+func NewPgRepo(s *server.Server) LoanRepo {
+	return &loanPgRepo{
+		db:  s.DB.Pg,
+		log: s.Log,
+	}
+}
+
+// NewTxPgRepo create a loan postgres repo writing through tx
+func NewTxPgRepo(tx *sqlx.Tx, l *logger.Logger) LoanRepo {
+	return &loanPgRepo{
+		db:  tx,
+		log: l,
+	}
+}
+
+// Create a loan
+func (r *loanPgRepo) Create(e *entity.Loan) (entity.ID, error) {
+	query := `insert into loan (` + loanColumns + `) values($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	_, err := r.db.Exec(query,
+		e.ID,
+		e.UserID,
+		e.BookID,
+		e.Barcode,
+		e.Status,
+		e.BorrowedAt,
+		e.DueAt,
+		nullTime(e.ReturnedAt),
+		e.Renewals,
+	)
+	if err != nil {
+		return e.ID, err
+	}
+	return e.ID, nil
+}
+
+// Get a loan
+func (r *loanPgRepo) Get(id entity.ID) (*entity.Loan, error) {
+	loans, err := r.query(`select `+loanColumns+` from loan where id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(loans) == 0 {
+		return nil, entity.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+// GetActive finds the open loan of a book by an user
+func (r *loanPgRepo) GetActive(userID userEntity.ID, bookID bookEntity.ID) (*entity.Loan, error) {
+	loans, err := r.query(`select `+loanColumns+` from loan where user_id = $1 and book_id = $2 and returned_at is null`, userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(loans) == 0 {
+		return nil, entity.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+// GetActiveByBarcode finds the open loan of a copy
+func (r *loanPgRepo) GetActiveByBarcode(barcode string) (*entity.Loan, error) {
+	loans, err := r.query(`select `+loanColumns+` from loan where barcode = $1 and returned_at is null`, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if len(loans) == 0 {
+		return nil, entity.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+// Update a loan
+func (r *loanPgRepo) Update(e *entity.Loan) error {
+	query := `update loan set status = $1, due_at = $2, returned_at = $3, renewals = $4, updated_at = $5 where id = $6`
+	res, err := r.db.Exec(query, e.Status, e.DueAt, nullTime(e.ReturnedAt), e.Renewals, time.Now(), e.ID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return entity.ErrLoanNotFound
+	}
+	return nil
+}
+
+// ListByUser lists the loans of an user passing f
+func (r *loanPgRepo) ListByUser(userID userEntity.ID, f entity.LoanFilter) ([]*entity.Loan, error) {
+	where, args := filterClauses(f, []string{`user_id = $1`}, []interface{}{userID})
+	return r.query(`select `+loanColumns+` from loan where `+where+` order by borrowed_at`, args...)
+}
+
+// ListByBook lists the loans of a book passing f
+func (r *loanPgRepo) ListByBook(bookID bookEntity.ID, f entity.LoanFilter) ([]*entity.Loan, error) {
+	where, args := filterClauses(f, []string{`book_id = $1`}, []interface{}{bookID})
+	return r.query(`select `+loanColumns+` from loan where `+where+` order by borrowed_at`, args...)
+}
+
+// filterClauses appends the conditions of f to where, numbering the placeholders after args
+func filterClauses(f entity.LoanFilter, where []string, args []interface{}) (string, []interface{}) {
+	switch f.Status {
+	case entity.LoanActive:
+		where = append(where, `returned_at is null`)
+	case entity.LoanOverdue:
+		args = append(args, time.Now())
+		where = append(where, fmt.Sprintf(`returned_at is null and due_at < $%d`, len(args)))
+	case entity.LoanReturned:
+		where = append(where, `returned_at is not null`)
+	}
+	if !f.From.IsZero() {
+		args = append(args, f.From)
+		where = append(where, fmt.Sprintf(`borrowed_at >= $%d`, len(args)))
+	}
+	if !f.To.IsZero() {
+		args = append(args, f.To)
+		where = append(where, fmt.Sprintf(`borrowed_at < $%d`, len(args)))
+	}
+	return strings.Join(where, ` and `), args
+}
+
+// ListOverdue lists the open loans due before at
+func (r *loanPgRepo) ListOverdue(at time.Time) ([]*entity.Loan, error) {
+	return r.query(`select `+loanColumns+` from loan where returned_at is null and due_at < $1 order by due_at`, at)
+}
+
+// List loans
+func (r *loanPgRepo) List() ([]*entity.Loan, error) {
+	return r.query(`select ` + loanColumns + ` from loan order by borrowed_at`)
+}
+
+func (r *loanPgRepo) query(query string, args ...interface{}) ([]*entity.Loan, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var loans []*entity.Loan
+	for rows.Next() {
+		var l entity.Loan
+		var returnedAt sql.NullTime
+		var barcode sql.NullString
+		err = rows.Scan(&l.ID, &l.UserID, &l.BookID, &barcode, &l.Status, &l.BorrowedAt, &l.DueAt, &returnedAt, &l.Renewals)
+		if err != nil {
+			return nil, err
+		}
+		l.Barcode = barcode.String
+		l.ReturnedAt = returnedAt.Time
+		loans = append(loans, &l)
+	}
+	return loans, rows.Err()
+}
+
+// nullTime stores the zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
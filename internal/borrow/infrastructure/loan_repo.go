@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"time"
+
+	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+)
+
+//go:generate mockgen -destination=../mock/loan_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure Reader,Writer,LoanRepo
+
+// Reader interface
+type Reader interface {
+	Get(id entity.ID) (*entity.Loan, error)
+	GetActive(userID userEntity.ID, bookID bookEntity.ID) (*entity.Loan, error)
+	GetActiveByBarcode(barcode string) (*entity.Loan, error)
+	ListByUser(userID userEntity.ID, f entity.LoanFilter) ([]*entity.Loan, error)
+	ListByBook(bookID bookEntity.ID, f entity.LoanFilter) ([]*entity.Loan, error)
+	ListOverdue(at time.Time) ([]*entity.Loan, error)
+	List() ([]*entity.Loan, error)
+}
+
+// Writer loan writer
+type Writer interface {
+	Create(e *entity.Loan) (entity.ID, error)
+	Update(e *entity.Loan) error
+}
+
+// LoanRepo interface
+type LoanRepo interface {
+	Reader
+	Writer
+}
@@ -0,0 +1,24 @@
+package infrastructure
+
+import (
+	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
+	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
+)
+
+//go:generate mockgen -destination=../mock/unit_of_work_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure UnitOfWork
+
+// Repos are the repositories a unit of work reads and writes through
+type Repos struct {
+	Books  bookInfra.BookRepo
+	Copies bookInfra.CopyRepo
+	Users  userInfra.UserRepo
+	Loans  LoanRepo
+	Holds  HoldRepo
+	Fines  FineRepo
+}
+
+// UnitOfWork runs fn so that its writes are applied all together or not at all,
+// and no other unit sees them half done
+type UnitOfWork interface {
+	Do(fn func(r *Repos) error) error
+}
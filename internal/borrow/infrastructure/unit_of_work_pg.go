@@ -0,0 +1,37 @@
+package infrastructure
+
+import (
+	"github.com/jmoiron/sqlx"
+	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
+	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+type pgUnitOfWork struct {
+	db  *repository.Repository
+	log *logger.Logger
+}
+
+// NewPgUnitOfWork create new postgres unit of work
+func NewPgUnitOfWork(s *server.Server) UnitOfWork {
+	return &pgUnitOfWork{
+		db:  s.DB,
+		log: s.Log,
+	}
+}
+
+// Do run fn in a transaction, rolled back if fn fails
+func (u *pgUnitOfWork) Do(fn func(r *Repos) error) error {
+	return u.db.Transact(func(tx *sqlx.Tx) error {
+		return fn(&Repos{
+			Books:  bookInfra.NewTxPgRepo(tx, u.log),
+			Copies: bookInfra.NewCopyTxPgRepo(tx, u.log),
+			Users:  userInfra.NewTxPgRepo(tx, u.log),
+			Loans:  NewTxPgRepo(tx, u.log),
+			Holds:  NewHoldTxPgRepo(tx, u.log),
+			Fines:  NewFineTxPgRepo(tx, u.log),
+		})
+	})
+}
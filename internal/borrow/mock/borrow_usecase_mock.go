@@ -9,7 +9,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	entity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
-	entity0 "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	entity0 "github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	entity1 "github.com/sgraham785/gocleanarch-example/internal/user/entity"
 )
 
 // MockBorrowUseCase is a mock of BorrowUseCase interface.
@@ -36,11 +37,12 @@ func (m *MockBorrowUseCase) EXPECT() *MockBorrowUseCaseMockRecorder {
 }
 
 // Borrow mocks base method.
-func (m *MockBorrowUseCase) Borrow(arg0 *entity0.User, arg1 *entity.Book) error {
+func (m *MockBorrowUseCase) Borrow(arg0 *entity1.User, arg1 *entity.Book) (*entity0.Loan, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Borrow", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Borrow indicates an expected call of Borrow.
@@ -49,16 +51,197 @@ func (mr *MockBorrowUseCaseMockRecorder) Borrow(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Borrow", reflect.TypeOf((*MockBorrowUseCase)(nil).Borrow), arg0, arg1)
 }
 
+// BorrowCopy mocks base method.
+func (m *MockBorrowUseCase) BorrowCopy(arg0 *entity1.User, arg1 string) (*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BorrowCopy", arg0, arg1)
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BorrowCopy indicates an expected call of BorrowCopy.
+func (mr *MockBorrowUseCaseMockRecorder) BorrowCopy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorrowCopy", reflect.TypeOf((*MockBorrowUseCase)(nil).BorrowCopy), arg0, arg1)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockBorrowUseCase) ExpireHolds() ([]*entity0.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds")
+	ret0, _ := ret[0].([]*entity0.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockBorrowUseCaseMockRecorder) ExpireHolds() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockBorrowUseCase)(nil).ExpireHolds))
+}
+
+// GetLoan mocks base method.
+func (m *MockBorrowUseCase) GetLoan(arg0 string) (*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoan", arg0)
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoan indicates an expected call of GetLoan.
+func (mr *MockBorrowUseCaseMockRecorder) GetLoan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoan", reflect.TypeOf((*MockBorrowUseCase)(nil).GetLoan), arg0)
+}
+
+// ListBookLoans mocks base method.
+func (m *MockBorrowUseCase) ListBookLoans(arg0 *entity.Book, arg1 entity0.LoanFilter) ([]*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookLoans", arg0, arg1)
+	ret0, _ := ret[0].([]*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookLoans indicates an expected call of ListBookLoans.
+func (mr *MockBorrowUseCaseMockRecorder) ListBookLoans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookLoans", reflect.TypeOf((*MockBorrowUseCase)(nil).ListBookLoans), arg0, arg1)
+}
+
+// ListOverdueLoans mocks base method.
+func (m *MockBorrowUseCase) ListOverdueLoans() ([]*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueLoans")
+	ret0, _ := ret[0].([]*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueLoans indicates an expected call of ListOverdueLoans.
+func (mr *MockBorrowUseCaseMockRecorder) ListOverdueLoans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueLoans", reflect.TypeOf((*MockBorrowUseCase)(nil).ListOverdueLoans))
+}
+
+// ListUserLoans mocks base method.
+func (m *MockBorrowUseCase) ListUserLoans(arg0 *entity1.User, arg1 entity0.LoanFilter) ([]*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLoans", arg0, arg1)
+	ret0, _ := ret[0].([]*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLoans indicates an expected call of ListUserLoans.
+func (mr *MockBorrowUseCaseMockRecorder) ListUserLoans(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLoans", reflect.TypeOf((*MockBorrowUseCase)(nil).ListUserLoans), arg0, arg1)
+}
+
+// MarkOverdueLoans mocks base method.
+func (m *MockBorrowUseCase) MarkOverdueLoans() ([]*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdueLoans")
+	ret0, _ := ret[0].([]*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdueLoans indicates an expected call of MarkOverdueLoans.
+func (mr *MockBorrowUseCaseMockRecorder) MarkOverdueLoans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdueLoans", reflect.TypeOf((*MockBorrowUseCase)(nil).MarkOverdueLoans))
+}
+
+// PlaceHold mocks base method.
+func (m *MockBorrowUseCase) PlaceHold(arg0 *entity1.User, arg1 *entity.Book) (*entity0.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", arg0, arg1)
+	ret0, _ := ret[0].(*entity0.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockBorrowUseCaseMockRecorder) PlaceHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockBorrowUseCase)(nil).PlaceHold), arg0, arg1)
+}
+
+// PlaceWorkHold mocks base method.
+func (m *MockBorrowUseCase) PlaceWorkHold(arg0 *entity1.User, arg1 *entity.Work) (*entity0.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceWorkHold", arg0, arg1)
+	ret0, _ := ret[0].(*entity0.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceWorkHold indicates an expected call of PlaceWorkHold.
+func (mr *MockBorrowUseCaseMockRecorder) PlaceWorkHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceWorkHold", reflect.TypeOf((*MockBorrowUseCase)(nil).PlaceWorkHold), arg0, arg1)
+}
+
+// Renew mocks base method.
+func (m *MockBorrowUseCase) Renew(arg0 string) (*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", arg0)
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockBorrowUseCaseMockRecorder) Renew(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockBorrowUseCase)(nil).Renew), arg0)
+}
+
 // Return mocks base method.
-func (m *MockBorrowUseCase) Return(arg0 *entity.Book) error {
+func (m *MockBorrowUseCase) Return(arg0 *entity1.User, arg1 *entity.Book) (*entity0.Loan, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Return", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Return", arg0, arg1)
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Return indicates an expected call of Return.
-func (mr *MockBorrowUseCaseMockRecorder) Return(arg0 interface{}) *gomock.Call {
+func (mr *MockBorrowUseCaseMockRecorder) Return(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockBorrowUseCase)(nil).Return), arg0, arg1)
+}
+
+// ReturnCopy mocks base method.
+func (m *MockBorrowUseCase) ReturnCopy(arg0 string, arg1 entity.CopyCondition) (*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnCopy", arg0, arg1)
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnCopy indicates an expected call of ReturnCopy.
+func (mr *MockBorrowUseCaseMockRecorder) ReturnCopy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnCopy", reflect.TypeOf((*MockBorrowUseCase)(nil).ReturnCopy), arg0, arg1)
+}
+
+// ReturnLoan mocks base method.
+func (m *MockBorrowUseCase) ReturnLoan(arg0 string) (*entity0.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnLoan", arg0)
+	ret0, _ := ret[0].(*entity0.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnLoan indicates an expected call of ReturnLoan.
+func (mr *MockBorrowUseCaseMockRecorder) ReturnLoan(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockBorrowUseCase)(nil).Return), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnLoan", reflect.TypeOf((*MockBorrowUseCase)(nil).ReturnLoan), arg0)
 }
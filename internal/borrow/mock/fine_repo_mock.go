@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure (interfaces: FineReader,FineWriter,FineRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+)
+
+// MockFineReader is a mock of FineReader interface.
+type MockFineReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineReaderMockRecorder
+}
+
+// MockFineReaderMockRecorder is the mock recorder for MockFineReader.
+type MockFineReaderMockRecorder struct {
+	mock *MockFineReader
+}
+
+// NewMockFineReader creates a new mock instance.
+func NewMockFineReader(ctrl *gomock.Controller) *MockFineReader {
+	mock := &MockFineReader{ctrl: ctrl}
+	mock.recorder = &MockFineReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineReader) EXPECT() *MockFineReaderMockRecorder {
+	return m.recorder
+}
+
+// ListByUser mocks base method.
+func (m *MockFineReader) ListByUser(arg0 xid.ID) (entity.Ledger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", arg0)
+	ret0, _ := ret[0].(entity.Ledger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockFineReaderMockRecorder) ListByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockFineReader)(nil).ListByUser), arg0)
+}
+
+// LockByUser mocks base method.
+func (m *MockFineReader) LockByUser(arg0 xid.ID) (entity.Ledger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByUser", arg0)
+	ret0, _ := ret[0].(entity.Ledger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByUser indicates an expected call of LockByUser.
+func (mr *MockFineReaderMockRecorder) LockByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByUser", reflect.TypeOf((*MockFineReader)(nil).LockByUser), arg0)
+}
+
+// MockFineWriter is a mock of FineWriter interface.
+type MockFineWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineWriterMockRecorder
+}
+
+// MockFineWriterMockRecorder is the mock recorder for MockFineWriter.
+type MockFineWriterMockRecorder struct {
+	mock *MockFineWriter
+}
+
+// NewMockFineWriter creates a new mock instance.
+func NewMockFineWriter(ctrl *gomock.Controller) *MockFineWriter {
+	mock := &MockFineWriter{ctrl: ctrl}
+	mock.recorder = &MockFineWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineWriter) EXPECT() *MockFineWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFineWriter) Create(arg0 *entity.Transaction) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFineWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFineWriter)(nil).Create), arg0)
+}
+
+// MockFineRepo is a mock of FineRepo interface.
+type MockFineRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineRepoMockRecorder
+}
+
+// MockFineRepoMockRecorder is the mock recorder for MockFineRepo.
+type MockFineRepoMockRecorder struct {
+	mock *MockFineRepo
+}
+
+// NewMockFineRepo creates a new mock instance.
+func NewMockFineRepo(ctrl *gomock.Controller) *MockFineRepo {
+	mock := &MockFineRepo{ctrl: ctrl}
+	mock.recorder = &MockFineRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineRepo) EXPECT() *MockFineRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFineRepo) Create(arg0 *entity.Transaction) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFineRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFineRepo)(nil).Create), arg0)
+}
+
+// ListByUser mocks base method.
+func (m *MockFineRepo) ListByUser(arg0 xid.ID) (entity.Ledger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", arg0)
+	ret0, _ := ret[0].(entity.Ledger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockFineRepoMockRecorder) ListByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockFineRepo)(nil).ListByUser), arg0)
+}
+
+// LockByUser mocks base method.
+func (m *MockFineRepo) LockByUser(arg0 xid.ID) (entity.Ledger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByUser", arg0)
+	ret0, _ := ret[0].(entity.Ledger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByUser indicates an expected call of LockByUser.
+func (mr *MockFineRepoMockRecorder) LockByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByUser", reflect.TypeOf((*MockFineRepo)(nil).LockByUser), arg0)
+}
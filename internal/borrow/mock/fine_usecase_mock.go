@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/borrow/usecase (interfaces: FineUseCase)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	infrastructure "github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
+)
+
+// MockFineUseCase is a mock of FineUseCase interface.
+type MockFineUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineUseCaseMockRecorder
+}
+
+// MockFineUseCaseMockRecorder is the mock recorder for MockFineUseCase.
+type MockFineUseCaseMockRecorder struct {
+	mock *MockFineUseCase
+}
+
+// NewMockFineUseCase creates a new mock instance.
+func NewMockFineUseCase(ctrl *gomock.Controller) *MockFineUseCase {
+	mock := &MockFineUseCase{ctrl: ctrl}
+	mock.recorder = &MockFineUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineUseCase) EXPECT() *MockFineUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Blocked mocks base method.
+func (m *MockFineUseCase) Blocked(arg0 xid.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Blocked", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Blocked indicates an expected call of Blocked.
+func (mr *MockFineUseCaseMockRecorder) Blocked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Blocked", reflect.TypeOf((*MockFineUseCase)(nil).Blocked), arg0)
+}
+
+// BlockedBy mocks base method.
+func (m *MockFineUseCase) BlockedBy(arg0 infrastructure.FineReader, arg1 xid.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockedBy", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockedBy indicates an expected call of BlockedBy.
+func (mr *MockFineUseCaseMockRecorder) BlockedBy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockedBy", reflect.TypeOf((*MockFineUseCase)(nil).BlockedBy), arg0, arg1)
+}
+
+// ChargeLateReturn mocks base method.
+func (m *MockFineUseCase) ChargeLateReturn(arg0 *entity.Loan) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeLateReturn", arg0)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeLateReturn indicates an expected call of ChargeLateReturn.
+func (mr *MockFineUseCaseMockRecorder) ChargeLateReturn(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeLateReturn", reflect.TypeOf((*MockFineUseCase)(nil).ChargeLateReturn), arg0)
+}
+
+// ChargeLateReturnBy mocks base method.
+func (m *MockFineUseCase) ChargeLateReturnBy(arg0 infrastructure.FineWriter, arg1 *entity.Loan) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeLateReturnBy", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeLateReturnBy indicates an expected call of ChargeLateReturnBy.
+func (mr *MockFineUseCaseMockRecorder) ChargeLateReturnBy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeLateReturnBy", reflect.TypeOf((*MockFineUseCase)(nil).ChargeLateReturnBy), arg0, arg1)
+}
+
+// GetLedger mocks base method.
+func (m *MockFineUseCase) GetLedger(arg0 xid.ID) (entity.Ledger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedger", arg0)
+	ret0, _ := ret[0].(entity.Ledger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedger indicates an expected call of GetLedger.
+func (mr *MockFineUseCaseMockRecorder) GetLedger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedger", reflect.TypeOf((*MockFineUseCase)(nil).GetLedger), arg0)
+}
+
+// Pay mocks base method.
+func (m *MockFineUseCase) Pay(arg0 xid.ID, arg1 int64) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockFineUseCaseMockRecorder) Pay(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockFineUseCase)(nil).Pay), arg0, arg1)
+}
+
+// Waive mocks base method.
+func (m *MockFineUseCase) Waive(arg0 xid.ID, arg1, arg2 string) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Waive", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Waive indicates an expected call of Waive.
+func (mr *MockFineUseCaseMockRecorder) Waive(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Waive", reflect.TypeOf((*MockFineUseCase)(nil).Waive), arg0, arg1, arg2)
+}
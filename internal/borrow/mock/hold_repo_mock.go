@@ -0,0 +1,351 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure (interfaces: HoldReader,HoldWriter,HoldRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	xid "github.com/rs/xid"
+	entity "github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+)
+
+// MockHoldReader is a mock of HoldReader interface.
+type MockHoldReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldReaderMockRecorder
+}
+
+// MockHoldReaderMockRecorder is the mock recorder for MockHoldReader.
+type MockHoldReaderMockRecorder struct {
+	mock *MockHoldReader
+}
+
+// NewMockHoldReader creates a new mock instance.
+func NewMockHoldReader(ctrl *gomock.Controller) *MockHoldReader {
+	mock := &MockHoldReader{ctrl: ctrl}
+	mock.recorder = &MockHoldReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldReader) EXPECT() *MockHoldReaderMockRecorder {
+	return m.recorder
+}
+
+// CountOpenByBook mocks base method.
+func (m *MockHoldReader) CountOpenByBook(arg0 xid.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByBook", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByBook indicates an expected call of CountOpenByBook.
+func (mr *MockHoldReaderMockRecorder) CountOpenByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByBook", reflect.TypeOf((*MockHoldReader)(nil).CountOpenByBook), arg0)
+}
+
+// CountOpenByWork mocks base method.
+func (m *MockHoldReader) CountOpenByWork(arg0 xid.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByWork", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByWork indicates an expected call of CountOpenByWork.
+func (mr *MockHoldReaderMockRecorder) CountOpenByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByWork", reflect.TypeOf((*MockHoldReader)(nil).CountOpenByWork), arg0)
+}
+
+// Get mocks base method.
+func (m *MockHoldReader) Get(arg0 xid.ID) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHoldReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHoldReader)(nil).Get), arg0)
+}
+
+// ListByUser mocks base method.
+func (m *MockHoldReader) ListByUser(arg0 xid.ID) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockHoldReaderMockRecorder) ListByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockHoldReader)(nil).ListByUser), arg0)
+}
+
+// ListExpired mocks base method.
+func (m *MockHoldReader) ListExpired(arg0 time.Time) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockHoldReaderMockRecorder) ListExpired(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockHoldReader)(nil).ListExpired), arg0)
+}
+
+// ListOpenByBook mocks base method.
+func (m *MockHoldReader) ListOpenByBook(arg0 xid.ID) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenByBook indicates an expected call of ListOpenByBook.
+func (mr *MockHoldReaderMockRecorder) ListOpenByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByBook", reflect.TypeOf((*MockHoldReader)(nil).ListOpenByBook), arg0)
+}
+
+// ListOpenByWork mocks base method.
+func (m *MockHoldReader) ListOpenByWork(arg0 xid.ID) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenByWork", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenByWork indicates an expected call of ListOpenByWork.
+func (mr *MockHoldReaderMockRecorder) ListOpenByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByWork", reflect.TypeOf((*MockHoldReader)(nil).ListOpenByWork), arg0)
+}
+
+// MockHoldWriter is a mock of HoldWriter interface.
+type MockHoldWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldWriterMockRecorder
+}
+
+// MockHoldWriterMockRecorder is the mock recorder for MockHoldWriter.
+type MockHoldWriterMockRecorder struct {
+	mock *MockHoldWriter
+}
+
+// NewMockHoldWriter creates a new mock instance.
+func NewMockHoldWriter(ctrl *gomock.Controller) *MockHoldWriter {
+	mock := &MockHoldWriter{ctrl: ctrl}
+	mock.recorder = &MockHoldWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldWriter) EXPECT() *MockHoldWriterMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHoldWriter) Create(arg0 *entity.Hold) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldWriterMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHoldWriter)(nil).Create), arg0)
+}
+
+// Update mocks base method.
+func (m *MockHoldWriter) Update(arg0 *entity.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHoldWriterMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHoldWriter)(nil).Update), arg0)
+}
+
+// MockHoldRepo is a mock of HoldRepo interface.
+type MockHoldRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepoMockRecorder
+}
+
+// MockHoldRepoMockRecorder is the mock recorder for MockHoldRepo.
+type MockHoldRepoMockRecorder struct {
+	mock *MockHoldRepo
+}
+
+// NewMockHoldRepo creates a new mock instance.
+func NewMockHoldRepo(ctrl *gomock.Controller) *MockHoldRepo {
+	mock := &MockHoldRepo{ctrl: ctrl}
+	mock.recorder = &MockHoldRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepo) EXPECT() *MockHoldRepoMockRecorder {
+	return m.recorder
+}
+
+// CountOpenByBook mocks base method.
+func (m *MockHoldRepo) CountOpenByBook(arg0 xid.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByBook", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByBook indicates an expected call of CountOpenByBook.
+func (mr *MockHoldRepoMockRecorder) CountOpenByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByBook", reflect.TypeOf((*MockHoldRepo)(nil).CountOpenByBook), arg0)
+}
+
+// CountOpenByWork mocks base method.
+func (m *MockHoldRepo) CountOpenByWork(arg0 xid.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByWork", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByWork indicates an expected call of CountOpenByWork.
+func (mr *MockHoldRepoMockRecorder) CountOpenByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByWork", reflect.TypeOf((*MockHoldRepo)(nil).CountOpenByWork), arg0)
+}
+
+// Create mocks base method.
+func (m *MockHoldRepo) Create(arg0 *entity.Hold) (xid.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(xid.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldRepoMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHoldRepo)(nil).Create), arg0)
+}
+
+// Get mocks base method.
+func (m *MockHoldRepo) Get(arg0 xid.ID) (*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHoldRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHoldRepo)(nil).Get), arg0)
+}
+
+// ListByUser mocks base method.
+func (m *MockHoldRepo) ListByUser(arg0 xid.ID) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockHoldRepoMockRecorder) ListByUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockHoldRepo)(nil).ListByUser), arg0)
+}
+
+// ListExpired mocks base method.
+func (m *MockHoldRepo) ListExpired(arg0 time.Time) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockHoldRepoMockRecorder) ListExpired(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockHoldRepo)(nil).ListExpired), arg0)
+}
+
+// ListOpenByBook mocks base method.
+func (m *MockHoldRepo) ListOpenByBook(arg0 xid.ID) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenByBook", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenByBook indicates an expected call of ListOpenByBook.
+func (mr *MockHoldRepoMockRecorder) ListOpenByBook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByBook", reflect.TypeOf((*MockHoldRepo)(nil).ListOpenByBook), arg0)
+}
+
+// ListOpenByWork mocks base method.
+func (m *MockHoldRepo) ListOpenByWork(arg0 xid.ID) ([]*entity.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenByWork", arg0)
+	ret0, _ := ret[0].([]*entity.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenByWork indicates an expected call of ListOpenByWork.
+func (mr *MockHoldRepoMockRecorder) ListOpenByWork(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByWork", reflect.TypeOf((*MockHoldRepo)(nil).ListOpenByWork), arg0)
+}
+
+// Update mocks base method.
+func (m *MockHoldRepo) Update(arg0 *entity.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHoldRepoMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHoldRepo)(nil).Update), arg0)
+}
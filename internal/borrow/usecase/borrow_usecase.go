@@ -1,110 +1,686 @@
 package usecase
 
 import (
+	"sort"
+	"time"
+
 	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
-	bookUseCase "github.com/sgraham785/gocleanarch-example/internal/book/usecase"
 	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
 	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
-	userUseCase "github.com/sgraham785/gocleanarch-example/internal/user/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"go.uber.org/zap"
 )
 
 //go:generate mockgen -destination=../mock/borrow_usecase_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/borrow/usecase BorrowUseCase
 
 // BorrowUseCase is the interface that provides the methods.
 type BorrowUseCase interface {
-	Borrow(u *userEntity.User, b *bookEntity.Book) error
-	Return(b *bookEntity.Book) error
+	Borrow(u *userEntity.User, b *bookEntity.Book) (*entity.Loan, error)
+	BorrowCopy(u *userEntity.User, barcode string) (*entity.Loan, error)
+	Return(u *userEntity.User, b *bookEntity.Book) (*entity.Loan, error)
+	ReturnLoan(loanID string) (*entity.Loan, error)
+	ReturnCopy(barcode string, condition bookEntity.CopyCondition) (*entity.Loan, error)
+	GetLoan(loanID string) (*entity.Loan, error)
+	Renew(loanID string) (*entity.Loan, error)
+	ListOverdueLoans() ([]*entity.Loan, error)
+	ListUserLoans(u *userEntity.User, f entity.LoanFilter) ([]*entity.Loan, error)
+	ListBookLoans(b *bookEntity.Book, f entity.LoanFilter) ([]*entity.Loan, error)
+	MarkOverdueLoans() ([]*entity.Loan, error)
+	PlaceHold(u *userEntity.User, b *bookEntity.Book) (*entity.Hold, error)
+	PlaceWorkHold(u *userEntity.User, w *bookEntity.Work) (*entity.Hold, error)
+	ExpireHolds() ([]*entity.Hold, error)
 }
 
 type borrowUseCase struct {
-	userUseCase userUseCase.UserUseCase
-	bookUseCase bookUseCase.BookUseCase
+	uow         infrastructure.UnitOfWork
+	fineUseCase FineUseCase
+	policies    entity.Policies
+	renewGrace  time.Duration
+	holdWindow  time.Duration
 	log         *logger.Logger
 }
 
-// New create new borrow use case
-func New(s *server.Server, u userUseCase.UserUseCase, b bookUseCase.BookUseCase) BorrowUseCase {
+// New create new borrow use case, every operation runs in its own unit of work
+func New(s *server.Server, w infrastructure.UnitOfWork, f FineUseCase) BorrowUseCase {
 	return &borrowUseCase{
-		userUseCase: u,
-		bookUseCase: b,
+		uow:         w,
+		fineUseCase: f,
+		policies:    newPolicies(s.Cfg.TierConf),
+		renewGrace:  s.Cfg.RenewalGracePeriod,
+		holdWindow:  s.Cfg.HoldPickupWindow,
 		log:         s.Log,
 	}
 }
 
-// Borrow borrow a book to an user
-func (s *borrowUseCase) Borrow(u *userEntity.User, b *bookEntity.Book) error {
-	u, err := s.userUseCase.GetUser(u.ID.String())
+// newPolicies reads the borrowing policy of each membership tier
+func newPolicies(c config.TierConf) entity.Policies {
+	return entity.Policies{
+		userEntity.TierStandard: {
+			MaxLoans:    c.StandardMaxLoans,
+			LoanPeriod:  c.StandardLoanPeriod,
+			MaxRenewals: c.StandardMaxRenewals,
+		},
+		userEntity.TierPremium: {
+			MaxLoans:    c.PremiumMaxLoans,
+			LoanPeriod:  c.PremiumLoanPeriod,
+			MaxRenewals: c.PremiumMaxRenewals,
+		},
+		userEntity.TierStaff: {
+			MaxLoans:    c.StaffMaxLoans,
+			LoanPeriod:  c.StaffLoanPeriod,
+			MaxRenewals: c.StaffMaxRenewals,
+		},
+	}
+}
+
+// Borrow borrow any available copy of a book to an user
+func (s *borrowUseCase) Borrow(u *userEntity.User, b *bookEntity.Book) (*entity.Loan, error) {
+	var l *entity.Loan
+	err := s.uow.Do(func(r *infrastructure.Repos) (err error) {
+		l, err = s.borrow(r, u.ID, b.ID, "")
+		return err
+	})
 	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// BorrowCopy borrow the copy scanned at the desk to an user
+func (s *borrowUseCase) BorrowCopy(u *userEntity.User, barcode string) (*entity.Loan, error) {
+	var l *entity.Loan
+	err := s.uow.Do(func(r *infrastructure.Repos) error {
+		c, err := r.Copies.Get(barcode)
+		if err != nil {
+			return err
+		}
+		l, err = s.borrow(r, u.ID, c.BookID, c.Barcode)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	b, err = s.bookUseCase.GetBook(b.ID.String())
+	return l, nil
+}
+
+// borrow lends the copy with barcode, or the first one on the shelf when barcode is empty
+func (s *borrowUseCase) borrow(r *infrastructure.Repos, userID userEntity.ID, bookID bookEntity.ID, barcode string) (*entity.Loan, error) {
+	// the unit of work locks the user row here, so the open loans counted
+	// against the tier limit cannot change until this borrow ends
+	u, err := r.Users.Get(userID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !u.IsVerified() {
+		return nil, entity.ErrUserNotVerified
+	}
+	blocked, err := s.fineUseCase.BlockedBy(r.Fines, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, entity.ErrOutstandingFines
+	}
+	policy := s.policies.For(u.Tier)
+	err = policy.CanBorrow(len(u.Books))
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.Books.Get(bookID)
+	if err != nil {
+		return nil, err
 	}
-	if b.Quantity <= 0 {
-		return entity.ErrNotEnoughBooks
+	available, err := s.availableCopies(r, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(available) == 0 {
+		return nil, entity.ErrNotEnoughBooks
+	}
+	own, reserved, err := s.holdsFor(r, u.ID, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(available)-reserved <= 0 {
+		return nil, entity.ErrBookOnHold
+	}
+	c := available[0]
+	if barcode != "" {
+		c = nil
+		for _, a := range available {
+			if a.Barcode == barcode {
+				c = a
+			}
+		}
+		if c == nil {
+			return nil, bookEntity.ErrCopyNotAvailable
+		}
 	}
 
 	err = u.AddBook(b.ID)
 	if err != nil {
+		return nil, err
+	}
+	l, err := entity.NewLoan(u.ID, b.ID, policy.LoanPeriod)
+	if err != nil {
+		return nil, err
+	}
+	l.Barcode = c.Barcode
+	err = r.Users.Update(u)
+	if err != nil {
+		return nil, err
+	}
+	err = c.CheckOut()
+	if err != nil {
+		return nil, err
+	}
+	// the copy read above may be stale, the write only succeeds if
+	// nobody checked it out in between
+	err = r.Copies.UpdateStatus(c, bookEntity.CopyAvailable)
+	if err == bookEntity.ErrCopyStatusChanged {
+		return nil, bookEntity.ErrCopyNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.Loans.Create(l)
+	if err != nil {
+		return nil, err
+	}
+	if own != nil {
+		// a hold on a work may have a copy of another edition set aside,
+		// which goes to the next in line for that edition
+		setAside := own.BookID
+		if own.Status != entity.HoldReady || setAside == b.ID {
+			setAside = bookEntity.ID{}
+		}
+		err = own.Fulfill()
+		if err != nil {
+			return nil, err
+		}
+		own.BookID = b.ID
+		err = r.Holds.Update(own)
+		if err != nil {
+			return nil, err
+		}
+		if !setAside.IsNil() {
+			_, err = s.readyNextHold(r, setAside)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return l, nil
+}
+
+// Return return the book an user borrowed
+func (s *borrowUseCase) Return(u *userEntity.User, b *bookEntity.Book) (*entity.Loan, error) {
+	return s.closeLoan(func(r *infrastructure.Repos) (*entity.Loan, error) {
+		_, err := r.Books.Get(b.ID)
+		if err != nil {
+			return nil, err
+		}
+		l, err := r.Loans.GetActive(u.ID, b.ID)
+		if err == entity.ErrLoanNotFound {
+			return nil, entity.ErrBookNotBorrowed
+		}
+		return l, err
+	}, "")
+}
+
+// ReturnLoan return the book of a loan
+func (s *borrowUseCase) ReturnLoan(loanID string) (*entity.Loan, error) {
+	lID, err := entity.IDFromString(loanID)
+	if err != nil {
+		return nil, entity.ErrLoanNotFound
+	}
+	return s.closeLoan(func(r *infrastructure.Repos) (*entity.Loan, error) {
+		return r.Loans.Get(lID)
+	}, "")
+}
+
+// ReturnCopy return the copy scanned at the desk, recording the condition it came back in
+func (s *borrowUseCase) ReturnCopy(barcode string, condition bookEntity.CopyCondition) (*entity.Loan, error) {
+	return s.closeLoan(func(r *infrastructure.Repos) (*entity.Loan, error) {
+		_, err := r.Copies.Get(barcode)
+		if err != nil {
+			return nil, err
+		}
+		l, err := r.Loans.GetActiveByBarcode(barcode)
+		if err == entity.ErrLoanNotFound {
+			return nil, entity.ErrBookNotBorrowed
+		}
+		return l, err
+	}, condition)
+}
+
+// closeLoan checks in the loan found by find and fines the user if it was late, all in one unit
+func (s *borrowUseCase) closeLoan(find func(r *infrastructure.Repos) (*entity.Loan, error), condition bookEntity.CopyCondition) (*entity.Loan, error) {
+	var l *entity.Loan
+	err := s.uow.Do(func(r *infrastructure.Repos) (err error) {
+		l, err = find(r)
+		if err != nil {
+			return err
+		}
+		err = s.checkIn(r, l, condition)
+		if err != nil {
+			return err
+		}
+		_, err = s.fineUseCase.ChargeLateReturnBy(r.Fines, l)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	err = s.userUseCase.UpdateUser(u)
+	return l, nil
+}
+
+// checkIn closes the loan and shelves its copy, handing it to the next hold if it can be lent
+func (s *borrowUseCase) checkIn(r *infrastructure.Repos, l *entity.Loan, condition bookEntity.CopyCondition) error {
+	err := l.Return()
 	if err != nil {
 		return err
 	}
-	b.Quantity--
-	err = s.bookUseCase.UpdateBook(b)
+	u, err := r.Users.Get(l.UserID)
 	if err != nil {
 		return err
 	}
-	return nil
+	c, err := r.Copies.Get(l.Barcode)
+	if err != nil {
+		return err
+	}
+	err = u.RemoveBook(l.BookID)
+	if err != nil {
+		return err
+	}
+	err = r.Users.Update(u)
+	if err != nil {
+		return err
+	}
+	err = c.CheckIn(condition)
+	if err != nil {
+		return err
+	}
+	err = r.Copies.UpdateStatus(c, bookEntity.CopyOnLoan)
+	if err != nil {
+		return err
+	}
+	err = r.Loans.Update(l)
+	if err != nil {
+		return err
+	}
+	if !c.Available() {
+		return nil
+	}
+	_, err = s.readyNextHold(r, l.BookID)
+	return err
 }
 
-//Return return a book
-func (s *borrowUseCase) Return(b *bookEntity.Book) error {
-	b, err := s.bookUseCase.GetBook(b.ID.String())
+// availableCopies lists the copies of the book on the shelf
+func (s *borrowUseCase) availableCopies(r *infrastructure.Repos, bookID bookEntity.ID) ([]*bookEntity.Copy, error) {
+	copies, err := r.Copies.ListByBook(bookID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var available []*bookEntity.Copy
+	for _, c := range copies {
+		if c.Available() {
+			available = append(available, c)
+		}
+	}
+	return available, nil
+}
 
-	all, err := s.userUseCase.ListUsers()
+// GetLoan gets a loan
+func (s *borrowUseCase) GetLoan(loanID string) (*entity.Loan, error) {
+	lID, err := entity.IDFromString(loanID)
 	if err != nil {
+		return nil, entity.ErrLoanNotFound
+	}
+	var l *entity.Loan
+	err = s.uow.Do(func(r *infrastructure.Repos) (err error) {
+		l, err = r.Loans.Get(lID)
 		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Renew extends the due date of a loan
+func (s *borrowUseCase) Renew(loanID string) (*entity.Loan, error) {
+	lID, err := entity.IDFromString(loanID)
+	if err != nil {
+		return nil, entity.ErrLoanNotFound
 	}
-	borrowed := false
-	var borrowedBy userEntity.ID
-	for _, u := range all {
-		_, err := u.GetBook(b.ID)
+	var l *entity.Loan
+	err = s.uow.Do(func(r *infrastructure.Repos) error {
+		l, err = r.Loans.Get(lID)
 		if err != nil {
-			continue
+			return err
+		}
+		waiting, err := s.hasWaitingHolds(r, l.BookID)
+		if err != nil {
+			return err
+		}
+		if waiting {
+			return entity.ErrBookOnHold
 		}
-		borrowed = true
-		borrowedBy = u.ID
-		break
+		u, err := r.Users.Get(l.UserID)
+		if err != nil {
+			return err
+		}
+		policy := s.policies.For(u.Tier)
+		err = l.Renew(policy.LoanPeriod, policy.MaxRenewals, s.renewGrace)
+		if err != nil {
+			return err
+		}
+		return r.Loans.Update(l)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if !borrowed {
-		return entity.ErrBookNotBorrowed
+	return l, nil
+}
+
+// hasWaitingHolds tells if a patron is queued for the book, or for its work
+// without a copy of another edition set aside
+func (s *borrowUseCase) hasWaitingHolds(r *infrastructure.Repos, bookID bookEntity.ID) (bool, error) {
+	holds, err := s.openHolds(r, bookID)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range holds {
+		if h.BookID.IsNil() || h.BookID == bookID {
+			return true, nil
+		}
 	}
-	u, err := s.userUseCase.GetUser(borrowedBy.String())
+	return false, nil
+}
+
+// PlaceHold queues an user for a book with no copy left to borrow
+func (s *borrowUseCase) PlaceHold(u *userEntity.User, b *bookEntity.Book) (*entity.Hold, error) {
+	var h *entity.Hold
+	err := s.uow.Do(func(r *infrastructure.Repos) error {
+		u, err := r.Users.Get(u.ID)
+		if err != nil {
+			return err
+		}
+		b, err := r.Books.Get(b.ID)
+		if err != nil {
+			return err
+		}
+		_, err = u.GetBook(b.ID)
+		if err == nil {
+			return entity.ErrBookAlreadyBorrowed
+		}
+		own, reserved, err := s.holdsFor(r, u.ID, b.ID)
+		if err != nil {
+			return err
+		}
+		if own != nil {
+			return entity.ErrHoldAlreadyPlaced
+		}
+		available, err := s.availableCopies(r, b.ID)
+		if err != nil {
+			return err
+		}
+		if len(available)-reserved > 0 {
+			return entity.ErrBookAvailable
+		}
+		h, err = entity.NewHold(u.ID, b.ID)
+		if err != nil {
+			return err
+		}
+		_, err = r.Holds.Create(h)
+		return err
+	})
 	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// PlaceWorkHold queues an user for any edition of a work, when no copy of any is left to borrow
+func (s *borrowUseCase) PlaceWorkHold(u *userEntity.User, w *bookEntity.Work) (*entity.Hold, error) {
+	var h *entity.Hold
+	err := s.uow.Do(func(r *infrastructure.Repos) error {
+		u, err := r.Users.Get(u.ID)
+		if err != nil {
+			return err
+		}
+		holds, err := r.Holds.ListOpenByWork(w.ID)
+		if err != nil {
+			return err
+		}
+		for _, h := range holds {
+			if h.UserID == u.ID {
+				return entity.ErrHoldAlreadyPlaced
+			}
+		}
+		editions, err := r.Books.ListByWork(w.ID)
+		if err != nil {
+			return err
+		}
+		if len(editions) == 0 {
+			return entity.ErrWorkWithoutEditions
+		}
+		for _, b := range editions {
+			_, err = u.GetBook(b.ID)
+			if err == nil {
+				return entity.ErrBookAlreadyBorrowed
+			}
+			own, reserved, err := s.holdsFor(r, u.ID, b.ID)
+			if err != nil {
+				return err
+			}
+			if own != nil {
+				return entity.ErrHoldAlreadyPlaced
+			}
+			available, err := s.availableCopies(r, b.ID)
+			if err != nil {
+				return err
+			}
+			if len(available)-reserved > 0 {
+				return entity.ErrBookAvailable
+			}
+		}
+		h, err = entity.NewWorkHold(u.ID, w.ID)
+		if err != nil {
+			return err
+		}
+		_, err = r.Holds.Create(h)
 		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// ExpireHolds closes the holds not picked up in time and hands their copy to the next in line
+func (s *borrowUseCase) ExpireHolds() ([]*entity.Hold, error) {
+	now := time.Now()
+	var expired []*entity.Hold
+	err := s.uow.Do(func(r *infrastructure.Repos) error {
+		holds, err := r.Holds.ListExpired(now)
+		if err != nil {
+			return err
+		}
+		for _, h := range holds {
+			err = h.Expire(now)
+			if err != nil {
+				return err
+			}
+			err = r.Holds.Update(h)
+			if err != nil {
+				return err
+			}
+			_, err = s.readyNextHold(r, h.BookID)
+			if err != nil {
+				return err
+			}
+			expired = append(expired, h)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	err = u.RemoveBook(b.ID)
+	return expired, nil
+}
+
+// holdsFor returns the open hold of the user on a book or on its work, if any,
+// and how many copies of the book are set aside for other users
+func (s *borrowUseCase) holdsFor(r *infrastructure.Repos, userID userEntity.ID, bookID bookEntity.ID) (*entity.Hold, int, error) {
+	holds, err := s.openHolds(r, bookID)
 	if err != nil {
-		return err
+		return nil, 0, err
+	}
+	now := time.Now()
+	var own *entity.Hold
+	reserved := 0
+	for _, h := range holds {
+		switch {
+		case h.UserID == userID:
+			own = h
+		case h.IsReady(now) && h.BookID == bookID:
+			reserved++
+		}
 	}
-	err = s.userUseCase.UpdateUser(u)
+	return own, reserved, nil
+}
+
+// openHolds lists the open holds of a book and those of its work, first placed first
+func (s *borrowUseCase) openHolds(r *infrastructure.Repos, bookID bookEntity.ID) ([]*entity.Hold, error) {
+	holds, err := r.Holds.ListOpenByBook(bookID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	b, err := r.Books.Get(bookID)
+	if err == bookEntity.ErrBookNotFound {
+		return holds, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if b.WorkID.IsNil() {
+		return holds, nil
+	}
+	work, err := r.Holds.ListOpenByWork(b.WorkID)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range work {
+		if h.BookID != bookID {
+			holds = append(holds, h)
+		}
 	}
-	b.Quantity++
-	err = s.bookUseCase.UpdateBook(b)
+	sort.SliceStable(holds, func(i, j int) bool {
+		return holds[i].PlacedAt.Before(holds[j].PlacedAt)
+	})
+	return holds, nil
+}
+
+// readyNextHold sets a copy aside for the first user waiting for the book or for its work
+func (s *borrowUseCase) readyNextHold(r *infrastructure.Repos, bookID bookEntity.ID) (*entity.Hold, error) {
+	holds, err := s.openHolds(r, bookID)
 	if err != nil {
+		return nil, err
+	}
+	for _, h := range holds {
+		if h.Status != entity.HoldWaiting {
+			continue
+		}
+		// a hold on a work takes the edition whose copy came back
+		h.BookID = bookID
+		err = h.MarkReady(s.holdWindow)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Holds.Update(h)
+		if err != nil {
+			return nil, err
+		}
+		s.log.Zap.Info("hold ready for pick up",
+			zap.String("hold_id", h.ID.String()),
+			zap.String("user_id", h.UserID.String()),
+			zap.Time("expires_at", h.ExpiresAt),
+		)
+		return h, nil
+	}
+	return nil, nil
+}
+
+// ListOverdueLoans lists the loans past their due date
+func (s *borrowUseCase) ListOverdueLoans() ([]*entity.Loan, error) {
+	var loans []*entity.Loan
+	err := s.uow.Do(func(r *infrastructure.Repos) (err error) {
+		loans, err = r.Loans.ListOverdue(time.Now())
+		return err
+	})
+	return loans, err
+}
+
+// ListUserLoans lists the borrow history of an user
+func (s *borrowUseCase) ListUserLoans(u *userEntity.User, f entity.LoanFilter) ([]*entity.Loan, error) {
+	err := f.Validate()
+	if err != nil {
+		return nil, err
+	}
+	var loans []*entity.Loan
+	err = s.uow.Do(func(r *infrastructure.Repos) (err error) {
+		loans, err = r.Loans.ListByUser(u.ID, f)
 		return err
+	})
+	return loans, err
+}
+
+// ListBookLoans lists who borrowed a book
+func (s *borrowUseCase) ListBookLoans(b *bookEntity.Book, f entity.LoanFilter) ([]*entity.Loan, error) {
+	err := f.Validate()
+	if err != nil {
+		return nil, err
 	}
+	var loans []*entity.Loan
+	err = s.uow.Do(func(r *infrastructure.Repos) (err error) {
+		loans, err = r.Loans.ListByBook(b.ID, f)
+		return err
+	})
+	return loans, err
+}
 
-	return nil
+// MarkOverdueLoans flags the loans that went past their due date and returns them
+func (s *borrowUseCase) MarkOverdueLoans() ([]*entity.Loan, error) {
+	now := time.Now()
+	var marked []*entity.Loan
+	err := s.uow.Do(func(r *infrastructure.Repos) error {
+		loans, err := r.Loans.ListOverdue(now)
+		if err != nil {
+			return err
+		}
+		for _, l := range loans {
+			if l.Status == entity.LoanOverdue {
+				continue
+			}
+			err = l.MarkOverdue(now)
+			if err != nil {
+				return err
+			}
+			err = r.Loans.Update(l)
+			if err != nil {
+				return err
+			}
+			marked = append(marked, l)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return marked, nil
 }
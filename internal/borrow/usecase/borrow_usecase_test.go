@@ -1,144 +1,546 @@
 package usecase_test
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/internal/borrow/usecase"
 	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 
-	"github.com/golang/mock/gomock"
-	bookMock "github.com/sgraham785/gocleanarch-example/internal/book/mock"
-	userMock "github.com/sgraham785/gocleanarch-example/internal/user/mock"
+	bookInfra "github.com/sgraham785/gocleanarch-example/internal/book/infrastructure"
+	userInfra "github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
+
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_borrowUseCase_Borrow(t *testing.T) {
-	controller := gomock.NewController(t)
-	defer controller.Finish()
+func newFixtureConfig() *config.Specification {
+	return &config.Specification{
+		LoanConf: config.LoanConf{
+			RenewalGracePeriod: 24 * time.Hour,
+			HoldPickupWindow:   72 * time.Hour,
+		},
+		TierConf: config.TierConf{
+			StandardMaxLoans:    3,
+			StandardLoanPeriod:  14 * 24 * time.Hour,
+			StandardMaxRenewals: 1,
+			PremiumMaxLoans:     5,
+			PremiumLoanPeriod:   21 * 24 * time.Hour,
+			PremiumMaxRenewals:  2,
+			StaffMaxLoans:       10,
+			StaffLoanPeriod:     28 * 24 * time.Hour,
+			StaffMaxRenewals:    4,
+		},
+		FineConf: config.FineConf{
+			FineDailyRate:      25,
+			FineMax:            1000,
+			FineBlockThreshold: 500,
+		},
+	}
+}
+
+type fixture struct {
+	repos *infrastructure.Repos
+	fines infrastructure.FineRepo
+	uc    usecase.BorrowUseCase
+}
+
+func newFixture() *fixture {
 	logger := logger.New()
-	defer logger.Zap.Sync()
 	s := &server.Server{
 		Log: logger,
+		Cfg: newFixtureConfig(),
+	}
+	copies := bookInfra.NewCopyInMemRepo()
+	fines := infrastructure.NewFineInMemRepo()
+	f := &fixture{
+		repos: &infrastructure.Repos{
+			Books:  bookInfra.NewInMemRepo(copies),
+			Copies: copies,
+			Users:  userInfra.NewInMemRepo(),
+			Loans:  infrastructure.NewInMemRepo(),
+			Holds:  infrastructure.NewHoldInMemRepo(),
+			Fines:  fines,
+		},
+		fines: fines,
+	}
+	uow := infrastructure.NewInMemUnitOfWork(f.repos)
+	f.uc = usecase.New(s, uow, usecase.NewFineUseCase(s, f.fines, uow))
+	return f
+}
+
+func (f *fixture) user(name string) *userEntity.User {
+	u, _ := userEntity.New(name+"."+userEntity.NewID().String()+"@metalgods.net", "123456", name, "Metal")
+	_ = u.Verify()
+	_, _ = f.repos.Users.Create(u)
+	return u
+}
+
+func (f *fixture) book(quantity int) *bookEntity.Book {
+	b := &bookEntity.Book{
+		ID:     bookEntity.NewID(),
+		Title:  "I Am Ozzy",
+		Author: "Ozzy Osbourne",
+		Pages:  294,
+	}
+	_, _ = f.repos.Books.Create(b)
+	for i := 0; i < quantity; i++ {
+		c, _ := bookEntity.NewCopy(b.ID, "", bookEntity.ConditionGood, "")
+		_, _ = f.repos.Copies.Create(c)
+	}
+	return b
+}
+
+// available counts the copies of b on the shelf
+func (f *fixture) available(b *bookEntity.Book) int {
+	copies, _ := f.repos.Copies.ListByBook(b.ID)
+	n := 0
+	for _, c := range copies {
+		if c.Available() {
+			n++
+		}
 	}
-	uMock := userMock.NewMockUserUseCase(controller)
-	bMock := bookMock.NewMockBookUseCase(controller)
-	uc := usecase.New(s, uMock, bMock)
+	return n
+}
+
+func Test_borrowUseCase_Borrow(t *testing.T) {
+	f := newFixture()
 	t.Run("user not found", func(t *testing.T) {
 		u := &userEntity.User{
 			ID: userEntity.NewID(),
 		}
-		b := &bookEntity.Book{
-			ID: bookEntity.NewID(),
-		}
-		uMock.EXPECT().GetUser(u.ID.String()).Return(nil, userEntity.ErrUserNotFound)
-		err := uc.Borrow(u, b)
+		_, err := f.uc.Borrow(u, f.book(1))
 		assert.Equal(t, userEntity.ErrUserNotFound, err)
 	})
 	t.Run("book not found", func(t *testing.T) {
-		u := &userEntity.User{
-			ID: userEntity.NewID(),
-		}
 		b := &bookEntity.Book{
 			ID: bookEntity.NewID(),
 		}
-		uMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
-		bMock.EXPECT().GetBook(b.ID.String()).Return(nil, bookEntity.ErrBookNotFound)
-		err := uc.Borrow(u, b)
+		_, err := f.uc.Borrow(f.user("Ozzy"), b)
 		assert.Equal(t, bookEntity.ErrBookNotFound, err)
 	})
 	t.Run("not enough books to borrow", func(t *testing.T) {
-		u := &userEntity.User{
-			ID: userEntity.NewID(),
-		}
-		b := &bookEntity.Book{
-			ID: bookEntity.NewID(),
-		}
-		b.Quantity = 0
-		uMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
-		bMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
-		err := uc.Borrow(u, b)
+		_, err := f.uc.Borrow(f.user("Ozzy"), f.book(0))
 		assert.Equal(t, entity.ErrNotEnoughBooks, err)
 	})
 	t.Run("book already borrowed", func(t *testing.T) {
-		u := &userEntity.User{
-			ID: userEntity.NewID(),
-		}
-		b := &bookEntity.Book{
-			ID: bookEntity.NewID(),
-		}
-		u.AddBook(b.ID)
-		b.Quantity = 1
-		uMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
-		bMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
-		err := uc.Borrow(u, b)
+		u := f.user("Ozzy")
+		b := f.book(2)
+		_, err := f.uc.Borrow(u, b)
+		assert.Nil(t, err)
+		_, err = f.uc.Borrow(u, b)
 		assert.Equal(t, entity.ErrBookAlreadyBorrowed, err)
 	})
+	t.Run("email not verified", func(t *testing.T) {
+		u, _ := userEntity.New("dio@metalgods.net", "123456", "Dio", "Metal")
+		_, _ = f.repos.Users.Create(u)
+		b := f.book(1)
+		_, err := f.uc.Borrow(u, b)
+		assert.Equal(t, entity.ErrUserNotVerified, err)
+		assert.Equal(t, 1, f.available(b))
+	})
+	t.Run("outstanding fines", func(t *testing.T) {
+		u := f.user("Ozzy")
+		charge, _ := entity.NewCharge(u.ID, entity.NewID(), 501)
+		_, _ = f.fines.Create(charge)
+		_, err := f.uc.Borrow(u, f.book(1))
+		assert.Equal(t, entity.ErrOutstandingFines, err)
+	})
 	t.Run("sucess", func(t *testing.T) {
-		u := &userEntity.User{
-			ID: userEntity.NewID(),
+		u := f.user("Ozzy")
+		b := f.book(10)
+		l, err := f.uc.Borrow(u, b)
+		assert.Nil(t, err)
+		assert.Equal(t, 9, f.available(b))
+		assert.Equal(t, u.ID, l.UserID)
+		assert.Equal(t, b.ID, l.BookID)
+		assert.Equal(t, entity.LoanActive, l.Status)
+		assert.Equal(t, 14*24*time.Hour, l.DueAt.Sub(l.BorrowedAt))
+		loan, err := f.repos.Loans.Get(l.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, l, loan)
+		c, _ := f.repos.Copies.Get(l.Barcode)
+		assert.Equal(t, bookEntity.CopyOnLoan, c.Status)
+	})
+	t.Run("specific copy", func(t *testing.T) {
+		b := f.book(2)
+		copies, _ := f.repos.Copies.ListByBook(b.ID)
+		l, err := f.uc.BorrowCopy(f.user("Ozzy"), copies[1].Barcode)
+		assert.Nil(t, err)
+		assert.Equal(t, copies[1].Barcode, l.Barcode)
+		assert.Equal(t, b.ID, l.BookID)
+		_, err = f.uc.BorrowCopy(f.user("Lemmy"), copies[1].Barcode)
+		assert.Equal(t, bookEntity.ErrCopyNotAvailable, err)
+		_, err = f.uc.BorrowCopy(f.user("Lemmy"), "NO-SUCH-COPY")
+		assert.Equal(t, bookEntity.ErrCopyNotFound, err)
+	})
+	t.Run("concurrent borrows of the last copy", func(t *testing.T) {
+		b := f.book(1)
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			u := f.user("Ronnie")
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := f.uc.Borrow(u, b)
+				errs <- err
+			}()
 		}
-		b := &bookEntity.Book{
-			ID:       bookEntity.NewID(),
-			Quantity: 10,
+		wg.Wait()
+		close(errs)
+		ok := 0
+		for err := range errs {
+			if err == nil {
+				ok++
+				continue
+			}
+			assert.Equal(t, entity.ErrNotEnoughBooks, err)
 		}
-		uMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
-		bMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
-		uMock.EXPECT().UpdateUser(u).Return(nil)
-		bMock.EXPECT().UpdateBook(b).Return(nil)
-		err := uc.Borrow(u, b)
+		assert.Equal(t, 1, ok)
+		assert.Equal(t, 0, f.available(b))
+	})
+}
+
+func Test_borrowUseCase_Tiers(t *testing.T) {
+	f := newFixture()
+	t.Run("standard", func(t *testing.T) {
+		u := f.user("Ozzy")
+		for i := 0; i < 3; i++ {
+			l, err := f.uc.Borrow(u, f.book(1))
+			assert.Nil(t, err)
+			assert.Equal(t, 14*24*time.Hour, l.DueAt.Sub(l.BorrowedAt))
+		}
+		b := f.book(1)
+		_, err := f.uc.Borrow(u, b)
+		assert.Equal(t, entity.ErrBorrowLimitReached, err)
+		assert.Equal(t, 1, f.available(b))
+	})
+	t.Run("staff", func(t *testing.T) {
+		u := f.user("Lemmy")
+		_ = u.SetTier(userEntity.TierStaff)
+		for i := 0; i < 10; i++ {
+			l, err := f.uc.Borrow(u, f.book(1))
+			assert.Nil(t, err)
+			assert.Equal(t, 28*24*time.Hour, l.DueAt.Sub(l.BorrowedAt))
+		}
+		_, err := f.uc.Borrow(u, f.book(1))
+		assert.Equal(t, entity.ErrBorrowLimitReached, err)
+	})
+	t.Run("returning frees a slot", func(t *testing.T) {
+		u := f.user("Ronnie")
+		var books []*bookEntity.Book
+		for i := 0; i < 3; i++ {
+			b := f.book(1)
+			_, _ = f.uc.Borrow(u, b)
+			books = append(books, b)
+		}
+		_, err := f.uc.Return(u, books[0])
+		assert.Nil(t, err)
+		_, err = f.uc.Borrow(u, f.book(1))
 		assert.Nil(t, err)
 	})
 }
 
 func Test_borrowUseCase_Return(t *testing.T) {
-	controller := gomock.NewController(t)
-	defer controller.Finish()
-	uMock := userMock.NewMockUserUseCase(controller)
-	bMock := bookMock.NewMockBookUseCase(controller)
-	logger := logger.New()
-	defer logger.Zap.Sync()
-	s := &server.Server{
-		Log: logger,
-	}
-	uc := usecase.New(s, uMock, bMock)
+	f := newFixture()
 	t.Run("book not found", func(t *testing.T) {
 		b := &bookEntity.Book{
 			ID: bookEntity.NewID(),
 		}
-		bMock.EXPECT().GetBook(b.ID.String()).Return(nil, bookEntity.ErrBookNotFound)
-		err := uc.Return(b)
+		_, err := f.uc.Return(f.user("Ozzy"), b)
 		assert.Equal(t, bookEntity.ErrBookNotFound, err)
 	})
 	t.Run("book not borrowed", func(t *testing.T) {
-		u := &userEntity.User{
-			ID: userEntity.NewID(),
-		}
-		b := &bookEntity.Book{
-			ID: bookEntity.NewID(),
-		}
-		bMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
-		uMock.EXPECT().ListUsers().Return([]*userEntity.User{u}, nil)
-		err := uc.Return(b)
+		_, err := f.uc.Return(f.user("Ozzy"), f.book(1))
 		assert.Equal(t, entity.ErrBookNotBorrowed, err)
 	})
 	t.Run("success", func(t *testing.T) {
-		u := &userEntity.User{
-			ID: userEntity.NewID(),
-		}
-		b := &bookEntity.Book{
-			ID: bookEntity.NewID(),
-		}
-		u.AddBook(b.ID)
-		bMock.EXPECT().GetBook(b.ID.String()).Return(b, nil)
-		uMock.EXPECT().GetUser(u.ID.String()).Return(u, nil)
-		uMock.EXPECT().ListUsers().Return([]*userEntity.User{u}, nil)
-		uMock.EXPECT().UpdateUser(u).Return(nil)
-		bMock.EXPECT().UpdateBook(b).Return(nil)
-		err := uc.Return(b)
+		u := f.user("Ozzy")
+		b := f.book(1)
+		l, err := f.uc.Borrow(u, b)
+		assert.Nil(t, err)
+		returned, err := f.uc.Return(u, b)
+		assert.Nil(t, err)
+		assert.Equal(t, l.ID, returned.ID)
+		assert.Equal(t, 1, f.available(b))
+		loan, _ := f.repos.Loans.Get(l.ID)
+		assert.Equal(t, entity.LoanReturned, loan.Status)
+		assert.False(t, loan.ReturnedAt.IsZero())
+		ledger, _ := f.fines.ListByUser(u.ID)
+		assert.Empty(t, ledger)
+	})
+	t.Run("copy came back damaged", func(t *testing.T) {
+		b := f.book(1)
+		l, _ := f.uc.Borrow(f.user("Ronnie"), b)
+		_, err := f.uc.ReturnCopy("NO-SUCH-COPY", "")
+		assert.Equal(t, bookEntity.ErrCopyNotFound, err)
+		returned, err := f.uc.ReturnCopy(l.Barcode, bookEntity.ConditionDamaged)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.LoanReturned, returned.Status)
+		c, _ := f.repos.Copies.Get(l.Barcode)
+		assert.Equal(t, bookEntity.CopyInRepair, c.Status)
+		assert.Equal(t, 0, f.available(b))
+		_, err = f.uc.ReturnCopy(l.Barcode, "")
+		assert.Equal(t, entity.ErrBookNotBorrowed, err)
+	})
+	t.Run("only the borrower's loan is returned", func(t *testing.T) {
+		ozzy, lemmy := f.user("Ozzy"), f.user("Lemmy")
+		b := f.book(2)
+		first, _ := f.uc.Borrow(ozzy, b)
+		second, _ := f.uc.Borrow(lemmy, b)
+		returned, err := f.uc.Return(lemmy, b)
+		assert.Nil(t, err)
+		assert.Equal(t, second.ID, returned.ID)
+		loan, _ := f.repos.Loans.Get(first.ID)
+		assert.True(t, loan.IsActive())
+	})
+	t.Run("by loan", func(t *testing.T) {
+		_, err := f.uc.ReturnLoan(entity.NewID().String())
+		assert.Equal(t, entity.ErrLoanNotFound, err)
+		b := f.book(1)
+		l, _ := f.uc.Borrow(f.user("Ozzy"), b)
+		returned, err := f.uc.ReturnLoan(l.ID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, entity.LoanReturned, returned.Status)
+		assert.Equal(t, 1, f.available(b))
+		_, err = f.uc.ReturnLoan(l.ID.String())
+		assert.Equal(t, entity.ErrLoanAlreadyReturned, err)
+	})
+	t.Run("late return is fined", func(t *testing.T) {
+		u := f.user("Lemmy")
+		b := f.book(1)
+		l, _ := f.uc.Borrow(u, b)
+		l.BorrowedAt = time.Now().Add(-72 * time.Hour)
+		l.DueAt = time.Now().Add(-36 * time.Hour)
+		_, err := f.uc.Return(u, b)
+		assert.Nil(t, err)
+		ledger, _ := f.fines.ListByUser(u.ID)
+		assert.Len(t, ledger, 1)
+		assert.Equal(t, entity.TransactionCharge, ledger[0].Kind)
+		assert.Equal(t, l.ID, ledger[0].LoanID)
+		assert.Equal(t, int64(50), ledger.Balance())
+	})
+}
+
+func Test_borrowUseCase_Renew(t *testing.T) {
+	f := newFixture()
+	t.Run("loan not found", func(t *testing.T) {
+		_, err := f.uc.Renew(entity.NewID().String())
+		assert.Equal(t, entity.ErrLoanNotFound, err)
+	})
+	t.Run("renewal limit", func(t *testing.T) {
+		l, _ := entity.NewLoan(f.user("Ozzy").ID, bookEntity.NewID(), time.Hour)
+		_, _ = f.repos.Loans.Create(l)
+		due := l.DueAt
+		renewed, err := f.uc.Renew(l.ID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, due.Add(14*24*time.Hour), renewed.DueAt)
+		_, err = f.uc.Renew(l.ID.String())
+		assert.Equal(t, entity.ErrRenewalLimitReached, err)
+	})
+	t.Run("premium renews longer and more often", func(t *testing.T) {
+		u := f.user("Lemmy")
+		_ = u.SetTier(userEntity.TierPremium)
+		l, _ := entity.NewLoan(u.ID, bookEntity.NewID(), time.Hour)
+		_, _ = f.repos.Loans.Create(l)
+		due := l.DueAt
+		renewed, err := f.uc.Renew(l.ID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, due.Add(21*24*time.Hour), renewed.DueAt)
+		_, err = f.uc.Renew(l.ID.String())
+		assert.Nil(t, err)
+		_, err = f.uc.Renew(l.ID.String())
+		assert.Equal(t, entity.ErrRenewalLimitReached, err)
+	})
+	t.Run("overdue beyond grace", func(t *testing.T) {
+		l, _ := entity.NewLoan(f.user("Ronnie").ID, bookEntity.NewID(), time.Hour)
+		l.DueAt = time.Now().Add(-48 * time.Hour)
+		_, _ = f.repos.Loans.Create(l)
+		_, err := f.uc.Renew(l.ID.String())
+		assert.Equal(t, entity.ErrLoanTooOverdue, err)
+	})
+}
+
+func Test_borrowUseCase_OverdueLoans(t *testing.T) {
+	f := newFixture()
+	onTime, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	late, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	late.BorrowedAt = time.Now().Add(-48 * time.Hour)
+	late.DueAt = time.Now().Add(-24 * time.Hour)
+	returned, _ := entity.NewLoan(userEntity.NewID(), bookEntity.NewID(), time.Hour)
+	returned.DueAt = time.Now().Add(-time.Minute)
+	_ = returned.Return()
+	for _, l := range []*entity.Loan{onTime, late, returned} {
+		_, _ = f.repos.Loans.Create(l)
+	}
+
+	t.Run("list overdue", func(t *testing.T) {
+		loans, err := f.uc.ListOverdueLoans()
+		assert.Nil(t, err)
+		assert.Equal(t, []*entity.Loan{late}, loans)
+	})
+	t.Run("mark overdue", func(t *testing.T) {
+		marked, err := f.uc.MarkOverdueLoans()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(marked))
+		saved, _ := f.repos.Loans.Get(late.ID)
+		assert.Equal(t, entity.LoanOverdue, saved.Status)
+
+		marked, err = f.uc.MarkOverdueLoans()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(marked))
+	})
+}
+
+func Test_borrowUseCase_History(t *testing.T) {
+	f := newFixture()
+	ozzy, lemmy := f.user("Ozzy"), f.user("Lemmy")
+	b := f.book(2)
+	old, _ := f.uc.Borrow(ozzy, b)
+	_, _ = f.uc.Return(ozzy, b)
+	old.BorrowedAt = time.Now().AddDate(-1, 0, 0)
+	current, _ := f.uc.Borrow(ozzy, b)
+	other, _ := f.uc.Borrow(lemmy, b)
+
+	t.Run("user", func(t *testing.T) {
+		loans, err := f.uc.ListUserLoans(ozzy, entity.LoanFilter{})
+		assert.Nil(t, err)
+		assert.Equal(t, []*entity.Loan{old, current}, loans)
+		loans, err = f.uc.ListUserLoans(ozzy, entity.LoanFilter{Status: entity.LoanReturned})
+		assert.Nil(t, err)
+		assert.Equal(t, []*entity.Loan{old}, loans)
+		loans, err = f.uc.ListUserLoans(ozzy, entity.LoanFilter{From: time.Now().AddDate(0, -1, 0)})
+		assert.Nil(t, err)
+		assert.Equal(t, []*entity.Loan{current}, loans)
+	})
+	t.Run("book", func(t *testing.T) {
+		loans, err := f.uc.ListBookLoans(b, entity.LoanFilter{Status: entity.LoanActive})
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []*entity.Loan{current, other}, loans)
+		loans, err = f.uc.ListBookLoans(b, entity.LoanFilter{Status: entity.LoanOverdue})
+		assert.Nil(t, err)
+		assert.Empty(t, loans)
+	})
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := f.uc.ListBookLoans(b, entity.LoanFilter{Status: "lost"})
+		assert.Equal(t, entity.ErrInvalidLoanFilter, err)
+	})
+}
+
+func Test_borrowUseCase_Holds(t *testing.T) {
+	f := newFixture()
+	uc := f.uc
+	holdRepo := f.repos.Holds
+	b := f.book(1)
+	ozzy, lemmy, ronnie := f.user("Ozzy"), f.user("Lemmy"), f.user("Ronnie")
+
+	_, err := uc.PlaceHold(lemmy, b)
+	assert.Equal(t, entity.ErrBookAvailable, err)
+
+	l, err := uc.Borrow(ozzy, b)
+	assert.Nil(t, err)
+
+	_, err = uc.PlaceHold(ozzy, b)
+	assert.Equal(t, entity.ErrBookAlreadyBorrowed, err)
+	first, err := uc.PlaceHold(lemmy, b)
+	assert.Nil(t, err)
+	_, err = uc.PlaceHold(lemmy, b)
+	assert.Equal(t, entity.ErrHoldAlreadyPlaced, err)
+	second, err := uc.PlaceHold(ronnie, b)
+	assert.Nil(t, err)
+
+	_, err = uc.Renew(l.ID.String())
+	assert.Equal(t, entity.ErrBookOnHold, err)
+
+	_, err = uc.Return(ozzy, b)
+	assert.Nil(t, err)
+	saved, _ := holdRepo.Get(first.ID)
+	assert.Equal(t, entity.HoldReady, saved.Status)
+	saved, _ = holdRepo.Get(second.ID)
+	assert.Equal(t, entity.HoldWaiting, saved.Status)
+
+	_, err = uc.Borrow(ronnie, b)
+	assert.Equal(t, entity.ErrBookOnHold, err)
+
+	t.Run("expired hold goes to the next in line", func(t *testing.T) {
+		saved, _ := holdRepo.Get(first.ID)
+		saved.ExpiresAt = time.Now().Add(-time.Minute)
+		expired, err := uc.ExpireHolds()
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(expired))
+		saved, _ = holdRepo.Get(second.ID)
+		assert.Equal(t, entity.HoldReady, saved.Status)
+
+		_, err = uc.Borrow(lemmy, b)
+		assert.Equal(t, entity.ErrBookOnHold, err)
+		_, err = uc.Borrow(ronnie, b)
+		assert.Nil(t, err)
+		saved, _ = holdRepo.Get(second.ID)
+		assert.Equal(t, entity.HoldFulfilled, saved.Status)
+	})
+}
+
+func Test_borrowUseCase_WorkHolds(t *testing.T) {
+	f := newFixture()
+	uc := f.uc
+	holdRepo := f.repos.Holds
+	w, _ := bookEntity.NewWork("Blizzard of Ozz")
+	first, second := f.book(1), f.book(1)
+	for _, b := range []*bookEntity.Book{first, second} {
+		b.WorkID = w.ID
+		_ = f.repos.Books.Update(b)
+	}
+	ozzy, lemmy, ronnie := f.user("Ozzy"), f.user("Lemmy"), f.user("Ronnie")
+
+	empty, _ := bookEntity.NewWork("Ozzmosis")
+	_, err := uc.PlaceWorkHold(ronnie, empty)
+	assert.Equal(t, entity.ErrWorkWithoutEditions, err)
+
+	_, err = uc.PlaceWorkHold(ronnie, w)
+	assert.Equal(t, entity.ErrBookAvailable, err)
+	_, err = uc.Borrow(ozzy, first)
+	assert.Nil(t, err)
+	_, err = uc.PlaceWorkHold(ronnie, w)
+	assert.Equal(t, entity.ErrBookAvailable, err)
+	_, err = uc.Borrow(lemmy, second)
+	assert.Nil(t, err)
+
+	_, err = uc.PlaceWorkHold(ozzy, w)
+	assert.Equal(t, entity.ErrBookAlreadyBorrowed, err)
+	h, err := uc.PlaceWorkHold(ronnie, w)
+	assert.Nil(t, err)
+	assert.Equal(t, w.ID, h.WorkID)
+	assert.True(t, h.BookID.IsNil())
+	_, err = uc.PlaceWorkHold(ronnie, w)
+	assert.Equal(t, entity.ErrHoldAlreadyPlaced, err)
+	_, err = uc.PlaceHold(ronnie, second)
+	assert.Equal(t, entity.ErrHoldAlreadyPlaced, err)
+
+	// any edition coming back satisfies the hold
+	_, err = uc.Return(lemmy, second)
+	assert.Nil(t, err)
+	saved, _ := holdRepo.Get(h.ID)
+	assert.Equal(t, entity.HoldReady, saved.Status)
+	assert.Equal(t, second.ID, saved.BookID)
+	_, err = uc.Borrow(lemmy, second)
+	assert.Equal(t, entity.ErrBookOnHold, err)
+
+	t.Run("another edition frees the copy set aside", func(t *testing.T) {
+		_, err := uc.Return(ozzy, first)
+		assert.Nil(t, err)
+		_, err = uc.Borrow(ronnie, first)
+		assert.Nil(t, err)
+		saved, _ := holdRepo.Get(h.ID)
+		assert.Equal(t, entity.HoldFulfilled, saved.Status)
+		assert.Equal(t, first.ID, saved.BookID)
+		_, err = uc.Borrow(lemmy, second)
 		assert.Nil(t, err)
 	})
 }
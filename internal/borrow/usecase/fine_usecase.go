@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -destination=../mock/fine_usecase_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/borrow/usecase FineUseCase
+
+// FineUseCase keeps the fines ledger of the users
+type FineUseCase interface {
+	GetLedger(userID userEntity.ID) (entity.Ledger, error)
+	ChargeLateReturn(l *entity.Loan) (*entity.Transaction, error)
+	ChargeLateReturnBy(fines infrastructure.FineWriter, l *entity.Loan) (*entity.Transaction, error)
+	Pay(userID userEntity.ID, amount int64) (*entity.Transaction, error)
+	Waive(userID userEntity.ID, chargeID string, reason string) (*entity.Transaction, error)
+	Blocked(userID userEntity.ID) (bool, error)
+	BlockedBy(fines infrastructure.FineReader, userID userEntity.ID) (bool, error)
+}
+
+type fineUseCase struct {
+	repo           infrastructure.FineRepo
+	uow            infrastructure.UnitOfWork
+	dailyRate      int64
+	max            int64
+	blockThreshold int64
+	log            *logger.Logger
+}
+
+// NewFineUseCase create new fines use case, payments and waivers are written in uow
+func NewFineUseCase(s *server.Server, r infrastructure.FineRepo, uow infrastructure.UnitOfWork) FineUseCase {
+	return &fineUseCase{
+		repo:           r,
+		uow:            uow,
+		dailyRate:      s.Cfg.FineDailyRate,
+		max:            s.Cfg.FineMax,
+		blockThreshold: s.Cfg.FineBlockThreshold,
+		log:            s.Log,
+	}
+}
+
+// GetLedger lists the fines transactions of an user
+func (s *fineUseCase) GetLedger(userID userEntity.ID) (entity.Ledger, error) {
+	return s.repo.ListByUser(userID)
+}
+
+// ChargeLateReturn fines the user of a loan returned after its due date, nil if it was on time
+func (s *fineUseCase) ChargeLateReturn(l *entity.Loan) (*entity.Transaction, error) {
+	return s.ChargeLateReturnBy(s.repo, l)
+}
+
+// ChargeLateReturnBy is ChargeLateReturn writing the charge through fines, so it goes with the unit of work
+func (s *fineUseCase) ChargeLateReturnBy(fines infrastructure.FineWriter, l *entity.Loan) (*entity.Transaction, error) {
+	fee := l.LateFee(s.dailyRate, s.max)
+	if fee <= 0 {
+		return nil, nil
+	}
+	t, err := entity.NewCharge(l.UserID, l.ID, fee)
+	if err != nil {
+		return nil, err
+	}
+	_, err = fines.Create(t)
+	if err != nil {
+		return nil, err
+	}
+	s.log.Zap.Info("late return charged",
+		zap.String("user_id", l.UserID.String()),
+		zap.String("loan_id", l.ID.String()),
+		zap.Int64("amount", fee),
+	)
+	return t, nil
+}
+
+// Pay records a payment, anything above the balance is not taken. The ledger is locked
+// while the balance is read so two payments cannot both settle it
+func (s *fineUseCase) Pay(userID userEntity.ID, amount int64) (*entity.Transaction, error) {
+	var t *entity.Transaction
+	err := s.uow.Do(func(r *infrastructure.Repos) error {
+		ledger, err := r.Fines.LockByUser(userID)
+		if err != nil {
+			return err
+		}
+		balance := ledger.Balance()
+		if balance <= 0 {
+			return entity.ErrNothingOwed
+		}
+		if amount > balance {
+			amount = balance
+		}
+		t, err = entity.NewPayment(userID, amount)
+		if err != nil {
+			return err
+		}
+		_, err = r.Fines.Create(t)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Waive cancels what is left of a charge, holding the ledger like Pay does
+func (s *fineUseCase) Waive(userID userEntity.ID, chargeID string, reason string) (*entity.Transaction, error) {
+	cID, err := entity.IDFromString(chargeID)
+	if err != nil {
+		return nil, entity.ErrTransactionNotFound
+	}
+	var t *entity.Transaction
+	err = s.uow.Do(func(r *infrastructure.Repos) error {
+		ledger, err := r.Fines.LockByUser(userID)
+		if err != nil {
+			return err
+		}
+		charge, err := ledger.Charge(cID)
+		if err != nil {
+			return err
+		}
+		if ledger.Waived(charge.ID) {
+			return entity.ErrChargeAlreadyWaived
+		}
+		amount := charge.Amount
+		if balance := ledger.Balance(); balance < amount {
+			amount = balance
+		}
+		if amount <= 0 {
+			return entity.ErrNothingOwed
+		}
+		t, err = entity.NewWaiver(charge, amount, reason)
+		if err != nil {
+			return err
+		}
+		_, err = r.Fines.Create(t)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Blocked tells if an user owes too much to borrow
+func (s *fineUseCase) Blocked(userID userEntity.ID) (bool, error) {
+	return s.BlockedBy(s.repo, userID)
+}
+
+// BlockedBy is Blocked reading the ledger through fines, so a unit of work sees its own transaction
+func (s *fineUseCase) BlockedBy(fines infrastructure.FineReader, userID userEntity.ID) (bool, error) {
+	ledger, err := fines.ListByUser(userID)
+	if err != nil {
+		return false, err
+	}
+	return ledger.Balance() > s.blockThreshold, nil
+}
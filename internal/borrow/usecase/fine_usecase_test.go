@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/infrastructure"
+	"github.com/sgraham785/gocleanarch-example/internal/borrow/usecase"
+	userEntity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fineUseCase(t *testing.T) {
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+		Cfg: newFixtureConfig(),
+	}
+	repo := infrastructure.NewFineInMemRepo()
+	uc := usecase.NewFineUseCase(s, repo, infrastructure.NewInMemUnitOfWork(&infrastructure.Repos{Fines: repo}))
+	uID := userEntity.NewID()
+
+	t.Run("nothing owed", func(t *testing.T) {
+		_, err := uc.Pay(uID, 100)
+		assert.Equal(t, entity.ErrNothingOwed, err)
+		blocked, err := uc.Blocked(uID)
+		assert.Nil(t, err)
+		assert.False(t, blocked)
+	})
+	var first, second *entity.Transaction
+	t.Run("charges block borrowing above the threshold", func(t *testing.T) {
+		first, _ = entity.NewCharge(uID, entity.NewID(), 400)
+		_, _ = repo.Create(first)
+		blocked, _ := uc.Blocked(uID)
+		assert.False(t, blocked)
+		second, _ = entity.NewCharge(uID, entity.NewID(), 300)
+		_, _ = repo.Create(second)
+		blocked, _ = uc.Blocked(uID)
+		assert.True(t, blocked)
+	})
+	t.Run("pay", func(t *testing.T) {
+		p, err := uc.Pay(uID, 250)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.TransactionPayment, p.Kind)
+		blocked, _ := uc.Blocked(uID)
+		assert.False(t, blocked)
+	})
+	t.Run("waive", func(t *testing.T) {
+		_, err := uc.Waive(uID, entity.NewID().String(), "lost in the mail")
+		assert.Equal(t, entity.ErrTransactionNotFound, err)
+		w, err := uc.Waive(uID, second.ID.String(), "lost in the mail")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(300), w.Amount)
+		assert.Equal(t, second.ID, w.ChargeID)
+		_, err = uc.Waive(uID, second.ID.String(), "again")
+		assert.Equal(t, entity.ErrChargeAlreadyWaived, err)
+		ledger, _ := uc.GetLedger(uID)
+		assert.Equal(t, int64(150), ledger.Balance())
+	})
+	t.Run("overpaying only settles the balance", func(t *testing.T) {
+		p, err := uc.Pay(uID, 1000)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(150), p.Amount)
+		_, err = uc.Waive(uID, first.ID.String(), "too late")
+		assert.Equal(t, entity.ErrNothingOwed, err)
+	})
+	t.Run("concurrent payments settle the balance once", func(t *testing.T) {
+		other := userEntity.NewID()
+		c, _ := entity.NewCharge(other, entity.NewID(), 100)
+		_, _ = repo.Create(c)
+		var wg sync.WaitGroup
+		var mtx sync.Mutex
+		paid := 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := uc.Pay(other, 100)
+				if err == nil {
+					mtx.Lock()
+					paid++
+					mtx.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, paid)
+		ledger, _ := uc.GetLedger(other)
+		assert.Equal(t, int64(0), ledger.Balance())
+	})
+}
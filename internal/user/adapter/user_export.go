@@ -0,0 +1,119 @@
+package adapter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/user/usecase"
+)
+
+// Export formats
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// UserWriter writes an export of users one at a time, Close flushes it.
+// It writes the fields of UserHTTP and nothing else.
+type UserWriter interface {
+	Write(u *entity.User) error
+	Close() error
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// Write a row
+func (c *csvWriter) Write(u *entity.User) error {
+	return c.w.Write([]string{
+		u.ID.String(),
+		u.Email,
+		u.FirstName,
+		u.LastName,
+		string(u.Tier),
+		string(u.Role),
+		strconv.FormatBool(u.IsVerified()),
+	})
+}
+
+// Close flushes the rows still buffered
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	e *json.Encoder
+}
+
+// Write the JSON of an user on a line
+func (j *jsonlWriter) Write(u *entity.User) error {
+	return j.e.Encode(&UserHTTP{
+		ID:        u.ID,
+		Email:     u.Email,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Tier:      u.Tier,
+		Role:      u.Role,
+		Verified:  u.IsVerified(),
+	})
+}
+
+// Close has nothing to flush, lines are written whole
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+// NewUserWriter writes an export of users in format, a CSV file starts with its header
+func NewUserWriter(format string, w io.Writer) (UserWriter, error) {
+	switch format {
+	case FormatCSV:
+		c := &csvWriter{w: csv.NewWriter(w)}
+		return c, c.w.Write([]string{"id", "email", "first_name", "last_name", "tier", "role", "verified"})
+	case FormatJSONL:
+		return &jsonlWriter{e: json.NewEncoder(w)}, nil
+	}
+	return nil, entity.ErrUnknownFormat
+}
+
+// exportTypes are the Content-Type and file extension of the export formats
+var exportTypes = map[string][2]string{
+	FormatCSV:   {"text/csv; charset=utf-8", "csv"},
+	FormatJSONL: {"application/x-ndjson", "jsonl"},
+}
+
+// ExportUsersHTTP handler, streams every user as a file in format, csv or jsonl (the default)
+func ExportUsersHTTP(u usecase.UserUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = FormatJSONL
+		}
+		t, ok := exportTypes[format]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(entity.ErrUnknownFormat.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", t[0])
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, t[1]))
+		uw, err := NewUserWriter(format, w)
+		if err == nil {
+			err = u.ExportUsers(uw.Write)
+		}
+		if err == nil {
+			err = uw.Close()
+		}
+		if err != nil {
+			// the status is sent already, the client gets a truncated file
+			log.Println(err.Error())
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package adapter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sgraham785/gocleanarch-example/internal/user/adapter"
+	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/internal/user/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
+	"github.com/sgraham785/gocleanarch-example/pkg/server"
+)
+
+func TestExportUsersHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockUserUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+	u := &entity.User{
+		ID:        entity.NewID(),
+		Email:     "ozzy@metalgods.net",
+		Password:  "$argon2id$v=19$m=65536,t=1,p=2$c2FsdA$aGFzaA",
+		FirstName: "Ozzy",
+		LastName:  "Osbourne",
+		Tier:      entity.TierStandard,
+		Role:      entity.RoleMember,
+	}
+	m.EXPECT().
+		ExportUsers(gomock.Any()).
+		DoAndReturn(func(each func(u *entity.User) error) error {
+			return each(u)
+		}).
+		Times(2)
+
+	req := httptest.NewRequest(http.MethodGet, "/user/export?format=csv", nil)
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "id,email,first_name,last_name,tier,role,verified\n"+
+		u.ID.String()+",ozzy@metalgods.net,Ozzy,Osbourne,standard,member,false\n", res.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/user/export", nil)
+	res = httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "application/x-ndjson", res.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(res.Body.String(), `{"id":"`+u.ID.String()+`"`))
+	assert.NotContains(t, res.Body.String(), "argon2id")
+}
+
+func TestExportUsersHTTP_Forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockUserUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "librarian"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+
+	req := httptest.NewRequest(http.MethodGet, "/user/export", nil)
+	res := httptest.NewRecorder()
+	r.Chi.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusForbidden, res.Code)
+}
@@ -6,38 +6,55 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	auth "github.com/sgraham785/gocleanarch-example/internal/auth/adapter"
 	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/user/usecase"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 )
 
 // UserHTTP JSON data
 type UserHTTP struct {
-	ID        entity.ID `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
+	ID        entity.ID   `json:"id"`
+	Email     string      `json:"email"`
+	FirstName string      `json:"first_name"`
+	LastName  string      `json:"last_name"`
+	Tier      entity.Tier `json:"tier"`
+	Role      entity.Role `json:"role"`
+	Verified  bool        `json:"verified"`
 }
 
-// ListUsersHTTP handler
+// ListUsersHTTP handler, one page at a time
 func ListUsersHTTP(u usecase.UserUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		errorMessage := "Error listing users"
 		var data []*entity.User
+		var page *repository.Page
 		var err error
+		opts := router.ListOptionsFromContext(r.Context())
 		name := r.URL.Query().Get("name")
 		switch {
 		case name == "":
-			data, err = u.ListUsers()
+			data, page, err = u.ListUsers(opts)
 		default:
-			data, err = u.SearchUsers(name)
+			data, page, err = u.SearchUsers(name, opts)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		if err != nil && err != entity.ErrUserNotFound {
+		switch err {
+		case nil, entity.ErrUserNotFound:
+		case repository.ErrInvalidSort, repository.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(errorMessage))
 			return
 		}
+		if page != nil {
+			router.SetPage(w, r, opts, page)
+		}
 
 		if data == nil {
 			w.WriteHeader(http.StatusNotFound)
@@ -51,6 +68,9 @@ func ListUsersHTTP(u usecase.UserUseCase) http.HandlerFunc {
 				Email:     d.Email,
 				FirstName: d.FirstName,
 				LastName:  d.LastName,
+				Tier:      d.Tier,
+				Role:      d.Role,
+				Verified:  d.IsVerified(),
 			})
 		}
 		if err := json.NewEncoder(w).Encode(toJ); err != nil {
@@ -78,6 +98,16 @@ func CreateUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
 			return
 		}
 		id, err := u.CreateUser(input.Email, input.Password, input.FirstName, input.LastName)
+		if err == entity.ErrEmailTaken {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err == entity.ErrInvalidUserEntity {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
 		if err != nil {
 			log.Println(err.Error())
 			w.WriteHeader(http.StatusInternalServerError)
@@ -89,6 +119,8 @@ func CreateUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
 			Email:     input.Email,
 			FirstName: input.FirstName,
 			LastName:  input.LastName,
+			Tier:      entity.TierStandard,
+			Role:      entity.RoleMember,
 		}
 
 		w.WriteHeader(http.StatusCreated)
@@ -123,6 +155,9 @@ func GetUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
 				Email:     data.Email,
 				FirstName: data.FirstName,
 				LastName:  data.LastName,
+				Tier:      data.Tier,
+				Role:      data.Role,
+				Verified:  data.IsVerified(),
 			}
 			if err := json.NewEncoder(w).Encode(toJ); err != nil {
 				w.WriteHeader(http.StatusInternalServerError)
@@ -136,6 +171,172 @@ func GetUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
 	})
 }
 
+// ReplaceUserHTTP handler, PUT takes the email and both names
+func ReplaceUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
+	return updateProfileHTTP(u, true)
+}
+
+// PatchUserHTTP handler, PATCH changes only the fields given
+func PatchUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
+	return updateProfileHTTP(u, false)
+}
+
+// updateProfileHTTP changes a profile, a new email or password takes the current one
+func updateProfileHTTP(u usecase.UserUseCase, full bool) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error updating user"
+		var input struct {
+			Email           *string `json:"email"`
+			FirstName       *string `json:"first_name"`
+			LastName        *string `json:"last_name"`
+			Password        *string `json:"password"`
+			CurrentPassword string  `json:"current_password"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil || (full && (input.Email == nil || input.FirstName == nil || input.LastName == nil)) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.UpdateProfile(chi.URLParam(r, "userID"), &entity.ProfileUpdate{
+			Email:           input.Email,
+			FirstName:       input.FirstName,
+			LastName:        input.LastName,
+			Password:        input.Password,
+			CurrentPassword: input.CurrentPassword,
+		})
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrUserNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidUserEntity:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrWrongPassword:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(err.Error()))
+			return
+		case entity.ErrEmailTaken:
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := &UserHTTP{
+			ID:        data.ID,
+			Email:     data.Email,
+			FirstName: data.FirstName,
+			LastName:  data.LastName,
+			Tier:      data.Tier,
+			Role:      data.Role,
+			Verified:  data.IsVerified(),
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// SetTierHTTP handler
+func SetTierHTTP(u usecase.UserUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error changing membership tier"
+		var input struct {
+			Tier entity.Tier `json:"tier"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.SetTier(chi.URLParam(r, "userID"), input.Tier)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrUserNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidTier:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := &UserHTTP{
+			ID:        data.ID,
+			Email:     data.Email,
+			FirstName: data.FirstName,
+			LastName:  data.LastName,
+			Tier:      data.Tier,
+			Role:      data.Role,
+			Verified:  data.IsVerified(),
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
+// SetRoleHTTP handler
+func SetRoleHTTP(u usecase.UserUseCase) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errorMessage := "Error changing role"
+		var input struct {
+			Role entity.Role `json:"role"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		data, err := u.SetRole(chi.URLParam(r, "userID"), input.Role)
+		w.Header().Set("Content-Type", "application/json")
+		switch err {
+		case nil:
+		case entity.ErrUserNotFound:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(errorMessage))
+			return
+		case entity.ErrInvalidRole:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+			return
+		}
+		toJ := &UserHTTP{
+			ID:        data.ID,
+			Email:     data.Email,
+			FirstName: data.FirstName,
+			LastName:  data.LastName,
+			Tier:      data.Tier,
+			Role:      data.Role,
+			Verified:  data.IsVerified(),
+		}
+		if err := json.NewEncoder(w).Encode(toJ); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(errorMessage))
+		}
+	})
+}
+
 // DeleteUserHTTP handler
 func DeleteUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -151,20 +352,24 @@ func DeleteUserHTTP(u usecase.UserUseCase) http.HandlerFunc {
 	})
 }
 
-// HTTPRoutes defined http routes for user
+// HTTPRoutes defined http routes for user, admins manage users and roles,
+// members only read their own profile
 func HTTPRoutes(s *server.Server, u usecase.UserUseCase) {
 	// RESTy routes for "books" resource
 	s.Router.Chi.Route("/user", func(r chi.Router) {
-		// r.With(paginate).Get("/", ListBooksHTTP(u))
-		r.Get("/", ListUsersHTTP(u))
-		r.Post("/", CreateUserHTTP(u))     // POST /book
-		r.Get("/search", ListUsersHTTP(u)) // GET /book/search?title=something
+		r.With(router.Authorize(auth.Staff), router.Paginate).Get("/", ListUsersHTTP(u))
+		r.With(router.Authorize(auth.Admin)).Post("/", CreateUserHTTP(u))                      // POST /book
+		r.With(router.Authorize(auth.Staff), router.Paginate).Get("/search", ListUsersHTTP(u)) // GET /book/search?title=something
+		r.With(router.Authorize(auth.Admin)).Get("/export", ExportUsersHTTP(u))                // GET /user/export?format=csv
 
 		r.Route("/{userID}", func(r chi.Router) {
 			// r.Use(BookCtx)             // Load the *Article on the request context
-			r.Get("/", GetUserHTTP(u)) // GET /book/123
-			// r.Put("/", UpdateArticle)    // PUT /book/123
-			r.Delete("/", DeleteUserHTTP(u)) // DELETE /book/123
+			r.With(router.Authorize(auth.Self, auth.Staff)).Get("/", GetUserHTTP(u)) // GET /book/123
+			r.With(router.Authorize(auth.Self, auth.Admin)).Put("/", ReplaceUserHTTP(u))
+			r.With(router.Authorize(auth.Self, auth.Admin)).Patch("/", PatchUserHTTP(u))
+			r.With(router.Authorize(auth.Admin)).Delete("/", DeleteUserHTTP(u)) // DELETE /book/123
+			r.With(router.Authorize(auth.Admin)).Put("/tier", SetTierHTTP(u))
+			r.With(router.Authorize(auth.Admin)).Put("/role", SetRoleHTTP(u))
 		})
 
 		// GET /book/whats-up
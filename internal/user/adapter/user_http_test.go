@@ -12,6 +12,7 @@ import (
 	"github.com/sgraham785/gocleanarch-example/internal/user/adapter"
 	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/user/mock"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/router"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,7 @@ func TestListUsersHTTP(t *testing.T) {
 
 	m := mock.NewMockUserUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -31,8 +33,8 @@ func TestListUsersHTTP(t *testing.T) {
 		ID: entity.NewID(),
 	}
 	m.EXPECT().
-		ListUsers().
-		Return([]*entity.User{u}, nil)
+		ListUsers(repository.ListOptions{Limit: router.DefaultLimit, Direction: repository.Asc}).
+		Return([]*entity.User{u}, &repository.Page{Total: 1}, nil)
 
 	adapter.HTTPRoutes(s, m)
 	h := adapter.ListUsersHTTP(m)
@@ -53,8 +55,8 @@ func TestListUsersHTTP_NotFound(t *testing.T) {
 	ts := httptest.NewServer(adapter.ListUsersHTTP(m))
 	defer ts.Close()
 	m.EXPECT().
-		SearchUsers("dio").
-		Return(nil, entity.ErrUserNotFound)
+		SearchUsers("dio", repository.ListOptions{Limit: router.DefaultLimit, Direction: repository.Asc}).
+		Return(nil, &repository.Page{}, entity.ErrUserNotFound)
 	res, err := http.Get(ts.URL + "?name=dio")
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusNotFound, res.StatusCode)
@@ -68,13 +70,14 @@ func TestListUsersHTTP_Search(t *testing.T) {
 		ID: entity.NewID(),
 	}
 	m.EXPECT().
-		SearchUsers("ozzy").
-		Return([]*entity.User{u}, nil)
+		SearchUsers("ozzy", repository.ListOptions{Limit: router.DefaultLimit, Direction: repository.Asc}).
+		Return([]*entity.User{u}, &repository.Page{Total: 1}, nil)
 	ts := httptest.NewServer(adapter.ListUsersHTTP(m))
 	defer ts.Close()
 	res, err := http.Get(ts.URL + "?name=ozzy")
 	assert.Nil(t, err)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get("X-Total-Count"))
 }
 
 func TestCreateUserHTTP(t *testing.T) {
@@ -82,6 +85,7 @@ func TestCreateUserHTTP(t *testing.T) {
 	defer controller.Finish()
 	m := mock.NewMockUserUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -115,6 +119,7 @@ func TestGetUserHTTP(t *testing.T) {
 	defer controller.Finish()
 	m := mock.NewMockUserUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -146,6 +151,7 @@ func TestDeleteUserHTTP(t *testing.T) {
 	defer controller.Finish()
 	m := mock.NewMockUserUseCase(controller)
 	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
 	s := &server.Server{
 		Router: r,
 	}
@@ -169,3 +175,194 @@ func TestDeleteUserHTTP(t *testing.T) {
 	r.Chi.ServeHTTP(rr, req)
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
+
+func TestSetTierHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockUserUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+
+	t.Run("invalid tier", func(t *testing.T) {
+		id := entity.NewID()
+		m.EXPECT().
+			SetTier(id.String(), entity.Tier("gold")).
+			Return(nil, entity.ErrInvalidTier)
+		req, _ := http.NewRequest("PUT", "/user/"+id.String()+"/tier", strings.NewReader(`{"tier":"gold"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		u := &entity.User{
+			ID:   entity.NewID(),
+			Tier: entity.TierPremium,
+		}
+		m.EXPECT().
+			SetTier(u.ID.String(), entity.TierPremium).
+			Return(u, nil)
+		req, _ := http.NewRequest("PUT", "/user/"+u.ID.String()+"/tier", strings.NewReader(`{"tier":"premium"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d *adapter.UserHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, entity.TierPremium, d.Tier)
+	})
+}
+
+func TestSetRoleHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockUserUseCase(controller)
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{Role: "admin"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+
+	t.Run("invalid role", func(t *testing.T) {
+		id := entity.NewID()
+		m.EXPECT().
+			SetRole(id.String(), entity.Role("owner")).
+			Return(nil, entity.ErrInvalidRole)
+		req, _ := http.NewRequest("PUT", "/user/"+id.String()+"/role", strings.NewReader(`{"role":"owner"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		u := &entity.User{
+			ID:   entity.NewID(),
+			Role: entity.RoleLibrarian,
+		}
+		m.EXPECT().
+			SetRole(u.ID.String(), entity.RoleLibrarian).
+			Return(u, nil)
+		req, _ := http.NewRequest("PUT", "/user/"+u.ID.String()+"/role", strings.NewReader(`{"role":"librarian"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d *adapter.UserHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, entity.RoleLibrarian, d.Role)
+	})
+}
+
+func TestUpdateUserHTTP(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockUserUseCase(controller)
+	member := entity.NewID()
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{ID: member.String(), Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+	email := "ozzy@metalgods.net"
+
+	t.Run("put takes every field", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/user/"+member.String(), strings.NewReader(`{"first_name":"John"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("email taken", func(t *testing.T) {
+		m.EXPECT().
+			UpdateProfile(member.String(), &entity.ProfileUpdate{Email: &email}).
+			Return(nil, entity.ErrEmailTaken)
+		req := httptest.NewRequest("PATCH", "/user/"+member.String(), strings.NewReader(`{"email":"ozzy@metalgods.net"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		password := "654321"
+		m.EXPECT().
+			UpdateProfile(member.String(), &entity.ProfileUpdate{Password: &password, CurrentPassword: "wrong"}).
+			Return(nil, entity.ErrWrongPassword)
+		req := httptest.NewRequest("PATCH", "/user/"+member.String(), strings.NewReader(`{"password":"654321","current_password":"wrong"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		first, last := "John", "Osbourne"
+		m.EXPECT().
+			UpdateProfile(member.String(), &entity.ProfileUpdate{Email: &email, FirstName: &first, LastName: &last}).
+			Return(&entity.User{ID: member, Email: email, FirstName: first, LastName: last}, nil)
+		req := httptest.NewRequest("PUT", "/user/"+member.String(), strings.NewReader(`{"email":"ozzy@metalgods.net","first_name":"John","last_name":"Osbourne"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var d *adapter.UserHTTP
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, "John", d.FirstName)
+	})
+
+	t.Run("someone else", func(t *testing.T) {
+		req := httptest.NewRequest("PATCH", "/user/"+entity.NewID().String(), strings.NewReader(`{"first_name":"John"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestUserHTTP_Authorization(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	m := mock.NewMockUserUseCase(controller)
+	member := entity.NewID()
+	r := router.NewChiRouter()
+	r.Chi.Use(signedIn(router.Principal{ID: member.String(), Role: "member"}))
+	s := &server.Server{
+		Router: r,
+	}
+	adapter.HTTPRoutes(s, m)
+
+	t.Run("own profile", func(t *testing.T) {
+		m.EXPECT().GetUser(member.String()).Return(&entity.User{ID: member}, nil)
+		req := httptest.NewRequest("GET", "/user/"+member.String(), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("someone else profile", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/user/"+entity.NewID().String(), nil)
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		var d router.Denial
+		json.NewDecoder(rr.Body).Decode(&d)
+		assert.Equal(t, http.StatusForbidden, d.Status)
+		assert.Equal(t, "Forbidden", d.Error)
+	})
+
+	t.Run("own role", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/user/"+member.String()+"/role", strings.NewReader(`{"role":"admin"}`))
+		rr := httptest.NewRecorder()
+		r.Chi.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+// signedIn plays the authentication middleware, every request is made by p
+func signedIn(p router.Principal) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(router.WithPrincipal(r.Context(), p)))
+		})
+	}
+}
@@ -22,3 +22,21 @@ var ErrBookAlreadyBorrowed = errors.New("Book already borrowed")
 
 // ErrBookNotBorrowed cannot return
 var ErrBookNotBorrowed = errors.New("Book not borrowed")
+
+// ErrInvalidTier unknown membership tier
+var ErrInvalidTier = errors.New("Invalid membership tier")
+
+// ErrInvalidRole unknown role
+var ErrInvalidRole = errors.New("Invalid role")
+
+// ErrUserAlreadyVerified cannot verify twice
+var ErrUserAlreadyVerified = errors.New("User already verified")
+
+// ErrEmailTaken another user has the email
+var ErrEmailTaken = errors.New("Email already taken")
+
+// ErrWrongPassword the current password does not match
+var ErrWrongPassword = errors.New("Wrong current password")
+
+// ErrUnknownFormat the export format is not one of those supported
+var ErrUnknownFormat = errors.New("Unknown format")
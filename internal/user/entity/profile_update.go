@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// ProfileUpdate are the profile fields an user changes, nil fields are left as they are.
+// Changing the email or the password takes the current password.
+type ProfileUpdate struct {
+	Email           *string
+	FirstName       *string
+	LastName        *string
+	Password        *string
+	CurrentPassword string
+}
+
+// Apply copies the names and the email to the user, a new email has to be verified again.
+// The password is hashed by the caller.
+func (p *ProfileUpdate) Apply(u *User) {
+	if p.FirstName != nil {
+		u.FirstName = *p.FirstName
+	}
+	if p.LastName != nil {
+		u.LastName = *p.LastName
+	}
+	if p.Email != nil {
+		if !strings.EqualFold(*p.Email, u.Email) {
+			u.VerifiedAt = time.Time{}
+		}
+		u.Email = *p.Email
+	}
+}
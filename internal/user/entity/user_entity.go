@@ -5,7 +5,6 @@ import (
 
 	"github.com/rs/xid"
 	bookEntity "github.com/sgraham785/gocleanarch-example/internal/book/entity"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // ID is id for user
@@ -21,33 +20,76 @@ func IDFromString(id string) (xid.ID, error) {
 	return i, err
 }
 
+// Tier is the membership level of an user, it sets how much the user may borrow
+type Tier string
+
+const (
+	// TierStandard default membership
+	TierStandard Tier = "standard"
+	// TierPremium paying members
+	TierPremium Tier = "premium"
+	// TierStaff library staff
+	TierStaff Tier = "staff"
+)
+
+// Valid tells if t is a known tier
+func (t Tier) Valid() bool {
+	switch t {
+	case TierStandard, TierPremium, TierStaff:
+		return true
+	}
+	return false
+}
+
+// Role is what an user is allowed to do
+type Role string
+
+const (
+	// RoleMember patrons, they borrow for themselves
+	RoleMember Role = "member"
+	// RoleLibrarian staff running the desk and the catalog
+	RoleLibrarian Role = "librarian"
+	// RoleAdmin manages users and their roles
+	RoleAdmin Role = "admin"
+)
+
+// Valid tells if r is a known role
+func (r Role) Valid() bool {
+	switch r {
+	case RoleMember, RoleLibrarian, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 // User entity
 type User struct {
-	ID        ID
-	Email     string
-	Password  string
-	FirstName string
-	LastName  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Books     []bookEntity.ID
+	ID         ID
+	Email      string
+	Password   string
+	FirstName  string
+	LastName   string
+	Tier       Tier
+	Role       Role
+	VerifiedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Books      []bookEntity.ID
 }
 
-// New creates a new user
+// New creates a new user, password is already hashed
 func New(email, password, firstName, lastName string) (*User, error) {
 	u := &User{
 		ID:        xid.New(),
 		Email:     email,
+		Password:  password,
 		FirstName: firstName,
 		LastName:  lastName,
+		Tier:      TierStandard,
+		Role:      RoleMember,
 		CreatedAt: time.Now(),
 	}
-	pwd, err := generatePassword(password)
-	if err != nil {
-		return nil, err
-	}
-	u.Password = pwd
-	err = u.Validate()
+	err := u.Validate()
 	if err != nil {
 		return nil, ErrInvalidUserEntity
 	}
@@ -85,28 +127,46 @@ func (u *User) GetBook(id ID) (ID, error) {
 	return id, ErrUserBookNotFound
 }
 
-// Validate validate data
-func (u *User) Validate() error {
-	if u.Email == "" || u.FirstName == "" || u.LastName == "" || u.Password == "" {
-		return ErrInvalidUserEntity
+// SetTier changes the membership of the user
+func (u *User) SetTier(t Tier) error {
+	if !t.Valid() {
+		return ErrInvalidTier
 	}
+	u.Tier = t
+	return nil
+}
 
+// SetRole changes what the user is allowed to do
+func (u *User) SetRole(r Role) error {
+	if !r.Valid() {
+		return ErrInvalidRole
+	}
+	u.Role = r
 	return nil
 }
 
-// ValidatePassword validate user password
-func (u *User) ValidatePassword(p string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(p))
-	if err != nil {
-		return err
+// IsVerified tells if the user proved to own its email
+func (u *User) IsVerified() bool {
+	return !u.VerifiedAt.IsZero()
+}
+
+// Verify records the user proved to own its email
+func (u *User) Verify() error {
+	if u.IsVerified() {
+		return ErrUserAlreadyVerified
 	}
+	u.VerifiedAt = time.Now()
 	return nil
 }
 
-func generatePassword(raw string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(raw), 10)
-	if err != nil {
-		return "", err
+// Validate validate data
+func (u *User) Validate() error {
+	if u.Email == "" || u.FirstName == "" || u.LastName == "" || u.Password == "" {
+		return ErrInvalidUserEntity
 	}
-	return string(hash), nil
+	if !u.Tier.Valid() || !u.Role.Valid() {
+		return ErrInvalidUserEntity
+	}
+
+	return nil
 }
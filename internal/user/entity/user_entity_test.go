@@ -13,16 +13,28 @@ func TestNew(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, u.FirstName, "Steve")
 	assert.NotNil(t, u.ID)
-	assert.NotEqual(t, u.Password, "new_password")
+	assert.Equal(t, entity.TierStandard, u.Tier)
+	assert.Equal(t, entity.RoleMember, u.Role)
 }
 
-func TestUser_ValidatePassword(t *testing.T) {
+func TestUser_SetRole(t *testing.T) {
 	u, _ := entity.New("sjobs@apple.com", "new_password", "Steve", "Jobs")
-	err := u.ValidatePassword("new_password")
+	err := u.SetRole(entity.RoleLibrarian)
 	assert.Nil(t, err)
-	err = u.ValidatePassword("wrong_password")
-	assert.NotNil(t, err)
+	assert.Equal(t, entity.RoleLibrarian, u.Role)
+	err = u.SetRole("root")
+	assert.Equal(t, entity.ErrInvalidRole, err)
+	assert.Equal(t, entity.RoleLibrarian, u.Role)
+}
 
+func TestUser_SetTier(t *testing.T) {
+	u, _ := entity.New("sjobs@apple.com", "new_password", "Steve", "Jobs")
+	err := u.SetTier(entity.TierPremium)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.TierPremium, u.Tier)
+	err = u.SetTier("gold")
+	assert.Equal(t, entity.ErrInvalidTier, err)
+	assert.Equal(t, entity.TierPremium, u.Tier)
 }
 
 func TestUser_AddBook(t *testing.T) {
@@ -86,7 +98,7 @@ func TestUser_Validate(t *testing.T) {
 			password:  "",
 			firstName: "Steve",
 			lastName:  "Jobs",
-			want:      nil,
+			want:      entity.ErrInvalidUserEntity,
 		},
 		{
 			email:     "sjobs@apple.com",
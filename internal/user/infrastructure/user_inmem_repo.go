@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 type userInMemRepo struct {
@@ -24,6 +25,9 @@ func NewInMemRepo() UserRepo {
 func (r *userInMemRepo) Create(e *entity.User) (entity.ID, error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	if r.emailTaken(e) {
+		return e.ID, entity.ErrEmailTaken
+	}
 	r.m[e.ID] = e
 	return e.ID, nil
 }
@@ -38,6 +42,18 @@ func (r *userInMemRepo) Get(id entity.ID) (*entity.User, error) {
 	return r.m[id], nil
 }
 
+// GetByEmail finds an user by email, ignoring case
+func (r *userInMemRepo) GetByEmail(email string) (*entity.User, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	for _, u := range r.m {
+		if u != nil && strings.EqualFold(u.Email, email) {
+			return u, nil
+		}
+	}
+	return nil, entity.ErrUserNotFound
+}
+
 // Update an user
 func (r *userInMemRepo) Update(e *entity.User) error {
 	_, err := r.Get(e.ID)
@@ -46,36 +62,67 @@ func (r *userInMemRepo) Update(e *entity.User) error {
 	}
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	if r.emailTaken(e) {
+		return entity.ErrEmailTaken
+	}
 	r.m[e.ID] = e
 	return nil
 }
 
-// Search users
-func (r *userInMemRepo) Search(query string) ([]*entity.User, error) {
-	var d []*entity.User
-	r.mtx.Lock()
-	defer r.mtx.Unlock()
-	for _, j := range r.m {
-		if strings.Contains(strings.ToLower(j.FirstName), query) {
-			d = append(d, j)
+// emailTaken plays the unique index on lower(email)
+func (r *userInMemRepo) emailTaken(e *entity.User) bool {
+	for _, u := range r.m {
+		if u != nil && u.ID != e.ID && strings.EqualFold(u.Email, e.Email) {
+			return true
 		}
 	}
-	if len(d) == 0 {
-		return nil, entity.ErrUserNotFound
-	}
+	return false
+}
 
-	return d, nil
+// Search users
+func (r *userInMemRepo) Search(query string, opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	d, page, err := r.list(func(u *entity.User) bool {
+		return strings.Contains(strings.ToLower(u.FirstName), query)
+	}, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if page.Total == 0 {
+		return nil, page, entity.ErrUserNotFound
+	}
+	return d, page, nil
 }
 
 // List users
-func (r *userInMemRepo) List() ([]*entity.User, error) {
-	var d []*entity.User
-	r.mtx.Lock()
-	defer r.mtx.Unlock()
+func (r *userInMemRepo) List(opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	return r.list(func(u *entity.User) bool { return true }, opts)
+}
+
+// list the page of the users matching filter
+func (r *userInMemRepo) list(filter func(u *entity.User) bool, opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	if _, ok := userSorts[opts.Sort]; !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var users []*entity.User
+	var rows []repository.Row
 	for _, j := range r.m {
-		d = append(d, j)
+		if j == nil || !filter(j) {
+			continue
+		}
+		users = append(users, j)
+		rows = append(rows, repository.Row{ID: j.ID.String(), Key: userSortKey(j, opts.Sort)})
+	}
+	idx, page, err := repository.PageRows(rows, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	var d []*entity.User
+	for _, i := range idx {
+		d = append(d, users[i])
 	}
-	return d, nil
+	return d, page, nil
 }
 
 // Delete an user
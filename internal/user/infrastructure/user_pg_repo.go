@@ -1,9 +1,12 @@
 package infrastructure
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
 	"github.com/sgraham785/gocleanarch-example/pkg/repository"
@@ -11,23 +14,34 @@ import (
 )
 
 type userPgRepo struct {
-	db  *repository.Repository
-	log *logger.Logger
+	db   repository.Querier
+	lock string
+	log  *logger.Logger
 }
 
 // NewPgRepo create new user repository
 func NewPgRepo(s *server.Server) UserRepo {
 	return &userPgRepo{
-		db:  s.DB,
+		db:  s.DB.Pg,
 		log: s.Log,
 	}
 }
 
+// NewTxPgRepo create an user postgres repo writing through tx, whose Get
+// locks the user row until tx ends so two borrows cannot both pass the tier limit
+func NewTxPgRepo(tx *sqlx.Tx, l *logger.Logger) UserRepo {
+	return &userPgRepo{
+		db:   tx,
+		lock: " for update",
+		log:  l,
+	}
+}
+
 // Create an user
 func (r *userPgRepo) Create(e *entity.User) (entity.ID, error) {
-	sql := `insert into "user" (id, email, password, first_name, last_name, created_at) values($1,$2,$3,$4,$5,$6)`
+	query := `insert into "user" (id, email, password, first_name, last_name, tier, role, verified_at, created_at) values($1,$2,$3,$4,$5,$6,$7,$8,$9)`
 	fmt.Printf("Create Repo: user=%v\n", e)
-	stmt, err := r.db.Pg.Prepare(sql)
+	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return e.ID, err
 	}
@@ -37,8 +51,14 @@ func (r *userPgRepo) Create(e *entity.User) (entity.ID, error) {
 		e.Password,
 		e.FirstName,
 		e.LastName,
+		e.Tier,
+		e.Role,
+		sql.NullTime{Time: e.VerifiedAt, Valid: e.IsVerified()},
 		time.Now().Format("2006-01-02"),
 	)
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+		return e.ID, entity.ErrEmailTaken
+	}
 	if err != nil {
 		return e.ID, err
 	}
@@ -51,20 +71,30 @@ func (r *userPgRepo) Create(e *entity.User) (entity.ID, error) {
 
 // Get an user
 func (r *userPgRepo) Get(id entity.ID) (*entity.User, error) {
-	sql := `select id, email, first_name, last_name, created_at from "user" where id = $1`
-	stmt, err := r.db.Pg.Prepare(sql)
+	query := `select id, email, password, first_name, last_name, tier, role, verified_at, created_at from "user" where id = $1` + r.lock
+	stmt, err := r.db.Prepare(query)
 	if err != nil {
 		return nil, err
 	}
 	var u entity.User
+	var verifiedAt sql.NullTime
 	rows, err := stmt.Query(id)
 	if err != nil {
 		return nil, err
 	}
+	found := false
 	for rows.Next() {
-		err = rows.Scan(&u.ID, &u.Email, &u.FirstName, &u.LastName, &u.CreatedAt)
+		found = true
+		err = rows.Scan(&u.ID, &u.Email, &u.Password, &u.FirstName, &u.LastName, &u.Tier, &u.Role, &verifiedAt, &u.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
 	}
-	stmt, err = r.db.Pg.Prepare(`select book_id from book_user where user_id = $1`)
+	if !found {
+		return nil, entity.ErrUserNotFound
+	}
+	u.VerifiedAt = verifiedAt.Time
+	stmt, err = r.db.Prepare(`select book_id from loan where user_id = $1 and returned_at is null`)
 	if err != nil {
 		return nil, err
 	}
@@ -80,90 +110,132 @@ func (r *userPgRepo) Get(id entity.ID) (*entity.User, error) {
 	return &u, nil
 }
 
+// GetByEmail finds an user by email, ignoring case
+func (r *userPgRepo) GetByEmail(email string) (*entity.User, error) {
+	var ids []entity.ID
+	err := r.db.Select(&ids, `select id from "user" where lower(email) = lower($1)`, email)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, entity.ErrUserNotFound
+	}
+	return r.Get(ids[0])
+}
+
 // Update an user
 func (r *userPgRepo) Update(e *entity.User) error {
-	sql := `update "user" set email = $1, password = $2, first_name = $3, last_name = $4, updated_at = $5 where id = $6`
+	query := `update "user" set email = $1, password = $2, first_name = $3, last_name = $4, tier = $5, role = $6, verified_at = $7, updated_at = $8 where id = $9`
 
 	e.UpdatedAt = time.Now()
-	_, err := r.db.Pg.Exec(sql, e.Email, e.Password, e.FirstName, e.LastName, e.UpdatedAt.Format("2006-01-02"), e.ID)
+	verifiedAt := sql.NullTime{Time: e.VerifiedAt, Valid: e.IsVerified()}
+	_, err := r.db.Exec(query, e.Email, e.Password, e.FirstName, e.LastName, e.Tier, e.Role, verifiedAt, e.UpdatedAt.Format("2006-01-02"), e.ID)
+	if err, ok := err.(*pq.Error); ok && err.Code.Name() == "unique_violation" {
+		return entity.ErrEmailTaken
+	}
 	if err != nil {
 		return err
 	}
-	_, err = r.db.Pg.Exec("delete from book_user where user_id = ?", e.ID)
+	// the books an user holds are derived from the open rows in loan
+	return nil
+}
+
+// Search users by first name
+func (r *userPgRepo) Search(query string, opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	users, page, err := r.list(`lower(first_name) like $1`, []interface{}{"%" + query + "%"}, opts)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	for _, b := range e.Books {
-		_, err := r.db.Pg.Exec("insert into book_user values(?,?,?)", e.ID, b, time.Now().Format("2006-01-02"))
-		if err != nil {
-			return err
-		}
+	if page.Total == 0 {
+		return nil, page, entity.ErrUserNotFound
 	}
-	return nil
+	return users, page, nil
 }
 
-// Search users
-func (r *userPgRepo) Search(query string) ([]*entity.User, error) {
-	sql := `select id from "user" where name like $1`
+// List users
+func (r *userPgRepo) List(opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	return r.list(`true`, nil, opts)
+}
 
-	stmt, err := r.db.Pg.Prepare(sql)
+// list reads the page of the users matching filter, with their total count
+func (r *userPgRepo) list(filter string, args []interface{}, opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	column, ok := userSorts[opts.Sort]
+	if !ok {
+		return nil, nil, repository.ErrInvalidSort
+	}
+	page := &repository.Page{}
+	err := r.db.QueryRowx(`select count(*) from "user" where `+filter, args...).Scan(&page.Total)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer stmt.Close()
-	var ids []entity.ID
-	rows, err := stmt.Query()
+	clauses, args, err := repository.Clauses(column, args, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	rows, err := r.db.Query(`select id, email, password, first_name, last_name, tier, role, verified_at, created_at
+		from "user" where `+filter+clauses, args...)
+	if err != nil {
+		return nil, nil, err
 	}
+	defer rows.Close()
+	var users []*entity.User
+	byID := map[entity.ID]*entity.User{}
+	var ids []string
 	for rows.Next() {
-		var i entity.ID
-		err = rows.Scan(&i)
+		var u entity.User
+		var verifiedAt sql.NullTime
+		err = rows.Scan(&u.ID, &u.Email, &u.Password, &u.FirstName, &u.LastName, &u.Tier, &u.Role, &verifiedAt, &u.CreatedAt)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		ids = append(ids, i)
+		u.VerifiedAt = verifiedAt.Time
+		users = append(users, &u)
+		byID[u.ID] = &u
+		ids = append(ids, u.ID.String())
 	}
-	if len(ids) == 0 {
-		return nil, fmt.Errorf("not found")
+	err = rows.Err()
+	if err != nil {
+		return nil, nil, err
 	}
-	var users []*entity.User
-	for _, id := range ids {
-		u, err := r.Get(id)
-		if err != nil {
-			return nil, err
-		}
-		users = append(users, u)
+	err = r.withBooks(byID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if repository.HasNext(len(users), opts) {
+		users = users[:opts.Limit]
+		last := users[len(users)-1]
+		page.Next = repository.Cursor(last.ID.String(), userSortKey(last, opts.Sort))
 	}
-	return users, nil
+	return users, page, nil
 }
 
-// List users
-func (r *userPgRepo) List() ([]*entity.User, error) {
-	sql := `select id from "user"`
-	var ids []entity.ID
-	err := r.db.Pg.Select(&ids, sql)
-	if err != nil {
-		return nil, err
-	}
+// withBooks reads the open loans of a page of users in one query
+func (r *userPgRepo) withBooks(users map[entity.ID]*entity.User, ids []string) error {
 	if len(ids) == 0 {
-		return nil, fmt.Errorf("not found")
+		return nil
 	}
-	var users []*entity.User
-	for _, id := range ids {
-		u, err := r.Get(id)
+	rows, err := r.db.Query(`select user_id, book_id from loan
+		where user_id = any($1::varchar[]) and returned_at is null`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userID, bookID entity.ID
+		err = rows.Scan(&userID, &bookID)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		users = append(users, u)
+		u := users[userID]
+		u.Books = append(u.Books, bookID)
 	}
-	return users, nil
+	return rows.Err()
 }
 
 // Delete an user
 func (r *userPgRepo) Delete(id entity.ID) error {
 	sql := `delete from "user" where id = $1`
-	_, err := r.db.Pg.Exec(sql, id)
+	_, err := r.db.Exec(sql, id)
 	if err != nil {
 		return err
 	}
@@ -1,16 +1,20 @@
 package infrastructure
 
 import (
+	"strings"
+
 	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 //go:generate mockgen -destination=../mock/user_repo_mock.go -package=mock github.com/sgraham785/gocleanarch-example/internal/user/infrastructure Reader,Writer,UserRepo
 
-// Reader interface
+// Reader interface, lists come a page at a time
 type Reader interface {
 	Get(id entity.ID) (*entity.User, error)
-	Search(query string) ([]*entity.User, error)
-	List() ([]*entity.User, error)
+	GetByEmail(email string) (*entity.User, error)
+	Search(query string, opts repository.ListOptions) ([]*entity.User, *repository.Page, error)
+	List(opts repository.ListOptions) ([]*entity.User, *repository.Page, error)
 }
 
 // Writer user writer
@@ -25,3 +29,27 @@ type UserRepo interface {
 	Reader
 	Writer
 }
+
+// userSorts maps the fields users are sorted on to their column
+var userSorts = map[string]string{
+	"":           "id",
+	"email":      "lower(email)",
+	"first_name": "first_name",
+	"last_name":  "last_name",
+	"created_at": "created_at",
+}
+
+// userSortKey is the value of an user for the sort field
+func userSortKey(u *entity.User, field string) interface{} {
+	switch field {
+	case "email":
+		return strings.ToLower(u.Email)
+	case "first_name":
+		return u.FirstName
+	case "last_name":
+		return u.LastName
+	case "created_at":
+		return u.CreatedAt
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	xid "github.com/rs/xid"
 	entity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 // MockReader is a mock of Reader interface.
@@ -50,34 +51,51 @@ func (mr *MockReaderMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReader)(nil).Get), arg0)
 }
 
-// List mocks base method.
-func (m *MockReader) List() ([]*entity.User, error) {
+// GetByEmail mocks base method.
+func (m *MockReader) GetByEmail(arg0 string) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
-	ret0, _ := ret[0].([]*entity.User)
+	ret := m.ctrl.Call(m, "GetByEmail", arg0)
+	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockReaderMockRecorder) GetByEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockReader)(nil).GetByEmail), arg0)
+}
+
+// List mocks base method.
+func (m *MockReader) List(arg0 repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // List indicates an expected call of List.
-func (mr *MockReaderMockRecorder) List() *gomock.Call {
+func (mr *MockReaderMockRecorder) List(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReader)(nil).List), arg0)
 }
 
 // Search mocks base method.
-func (m *MockReader) Search(arg0 string) ([]*entity.User, error) {
+func (m *MockReader) Search(arg0 string, arg1 repository.ListOptions) ([]*entity.User, *repository.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", arg0)
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
 	ret0, _ := ret[0].([]*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockReaderMockRecorder) Search(arg0 interface{}) *gomock.Call {
+func (mr *MockReaderMockRecorder) Search(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockReader)(nil).Search), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockReader)(nil).Search), arg0, arg1)
 }
 
 // MockWriter is a mock of Writer interface.
@@ -213,34 +231,51 @@ func (mr *MockUserRepoMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserRepo)(nil).Get), arg0)
 }
 
-// List mocks base method.
-func (m *MockUserRepo) List() ([]*entity.User, error) {
+// GetByEmail mocks base method.
+func (m *MockUserRepo) GetByEmail(arg0 string) (*entity.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
-	ret0, _ := ret[0].([]*entity.User)
+	ret := m.ctrl.Call(m, "GetByEmail", arg0)
+	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserRepoMockRecorder) GetByEmail(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepo)(nil).GetByEmail), arg0)
+}
+
+// List mocks base method.
+func (m *MockUserRepo) List(arg0 repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // List indicates an expected call of List.
-func (mr *MockUserRepoMockRecorder) List() *gomock.Call {
+func (mr *MockUserRepoMockRecorder) List(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepo)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepo)(nil).List), arg0)
 }
 
 // Search mocks base method.
-func (m *MockUserRepo) Search(arg0 string) ([]*entity.User, error) {
+func (m *MockUserRepo) Search(arg0 string, arg1 repository.ListOptions) ([]*entity.User, *repository.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", arg0)
+	ret := m.ctrl.Call(m, "Search", arg0, arg1)
 	ret0, _ := ret[0].([]*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockUserRepoMockRecorder) Search(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) Search(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepo)(nil).Search), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockUserRepo)(nil).Search), arg0, arg1)
 }
 
 // Update mocks base method.
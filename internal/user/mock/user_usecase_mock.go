@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	xid "github.com/rs/xid"
 	entity "github.com/sgraham785/gocleanarch-example/internal/user/entity"
+	repository "github.com/sgraham785/gocleanarch-example/pkg/repository"
 )
 
 // MockUserUseCase is a mock of UserUseCase interface.
@@ -64,6 +65,20 @@ func (mr *MockUserUseCaseMockRecorder) DeleteUser(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserUseCase)(nil).DeleteUser), arg0)
 }
 
+// ExportUsers mocks base method.
+func (m *MockUserUseCase) ExportUsers(arg0 func(*entity.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockUserUseCaseMockRecorder) ExportUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockUserUseCase)(nil).ExportUsers), arg0)
+}
+
 // GetUser mocks base method.
 func (m *MockUserUseCase) GetUser(arg0 string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -80,33 +95,80 @@ func (mr *MockUserUseCaseMockRecorder) GetUser(arg0 interface{}) *gomock.Call {
 }
 
 // ListUsers mocks base method.
-func (m *MockUserUseCase) ListUsers() ([]*entity.User, error) {
+func (m *MockUserUseCase) ListUsers(arg0 repository.ListOptions) ([]*entity.User, *repository.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers")
+	ret := m.ctrl.Call(m, "ListUsers", arg0)
 	ret0, _ := ret[0].([]*entity.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ListUsers indicates an expected call of ListUsers.
-func (mr *MockUserUseCaseMockRecorder) ListUsers() *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) ListUsers(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserUseCase)(nil).ListUsers))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserUseCase)(nil).ListUsers), arg0)
 }
 
 // SearchUsers mocks base method.
-func (m *MockUserUseCase) SearchUsers(arg0 string) ([]*entity.User, error) {
+func (m *MockUserUseCase) SearchUsers(arg0 string, arg1 repository.ListOptions) ([]*entity.User, *repository.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUsers", arg0)
+	ret := m.ctrl.Call(m, "SearchUsers", arg0, arg1)
 	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(*repository.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockUserUseCaseMockRecorder) SearchUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserUseCase)(nil).SearchUsers), arg0, arg1)
+}
+
+// SetRole mocks base method.
+func (m *MockUserUseCase) SetRole(arg0 string, arg1 entity.Role) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SearchUsers indicates an expected call of SearchUsers.
-func (mr *MockUserUseCaseMockRecorder) SearchUsers(arg0 interface{}) *gomock.Call {
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserUseCaseMockRecorder) SetRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserUseCase)(nil).SetRole), arg0, arg1)
+}
+
+// SetTier mocks base method.
+func (m *MockUserUseCase) SetTier(arg0 string, arg1 entity.Tier) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTier", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTier indicates an expected call of SetTier.
+func (mr *MockUserUseCaseMockRecorder) SetTier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTier", reflect.TypeOf((*MockUserUseCase)(nil).SetTier), arg0, arg1)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserUseCase) UpdateProfile(arg0 string, arg1 *entity.ProfileUpdate) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserUseCaseMockRecorder) UpdateProfile(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockUserUseCase)(nil).SearchUsers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserUseCase)(nil).UpdateProfile), arg0, arg1)
 }
 
 // UpdateUser mocks base method.
@@ -7,6 +7,8 @@ import (
 	"github.com/sgraham785/gocleanarch-example/internal/user/entity"
 	"github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/password"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 )
 
@@ -15,34 +17,51 @@ import (
 // UserUseCase is the interface that provides the methods.
 type UserUseCase interface {
 	GetUser(id string) (*entity.User, error)
-	SearchUsers(query string) ([]*entity.User, error)
-	ListUsers() ([]*entity.User, error)
+	SearchUsers(query string, opts repository.ListOptions) ([]*entity.User, *repository.Page, error)
+	ListUsers(opts repository.ListOptions) ([]*entity.User, *repository.Page, error)
+	ExportUsers(each func(u *entity.User) error) error
 	CreateUser(email, password, firstName, lastName string) (entity.ID, error)
 	UpdateUser(e *entity.User) error
+	UpdateProfile(id string, p *entity.ProfileUpdate) (*entity.User, error)
+	SetTier(id string, t entity.Tier) (*entity.User, error)
+	SetRole(id string, r entity.Role) (*entity.User, error)
 	DeleteUser(id string) error
 }
 
 type userUseCase struct {
-	repo infrastructure.UserRepo
-	log  *logger.Logger
+	repo     infrastructure.UserRepo
+	password password.Service
+	log      *logger.Logger
 }
 
 // New create new user use case
-func New(s *server.Server, r infrastructure.UserRepo) UserUseCase {
+func New(s *server.Server, r infrastructure.UserRepo, p password.Service) UserUseCase {
 	return &userUseCase{
-		repo: r,
-		log:  s.Log,
+		repo:     r,
+		password: p,
+		log:      s.Log,
 	}
 }
 
 // CreateUser create an user
 func (s *userUseCase) CreateUser(email, password, firstName, lastName string) (entity.ID, error) {
 	s.log.Zap.Info("got to create user")
-	e, err := entity.New(email, password, firstName, lastName)
+	if password == "" {
+		return entity.ID{}, entity.ErrInvalidUserEntity
+	}
+	err := s.emailFree(email, entity.ID{})
+	if err != nil {
+		return entity.ID{}, err
+	}
+	hash, err := s.password.Generate(password)
+	if err != nil {
+		return entity.ID{}, err
+	}
+	e, err := entity.New(email, hash, firstName, lastName)
 	if err != nil {
 		s.log.Zap.Error(err.Error())
 
-		return e.ID, err
+		return entity.ID{}, err
 	}
 	return s.repo.Create(e)
 }
@@ -53,14 +72,37 @@ func (s *userUseCase) GetUser(id string) (*entity.User, error) {
 	return s.repo.Get(uID)
 }
 
-// SearchUsers searches users
-func (s *userUseCase) SearchUsers(query string) ([]*entity.User, error) {
-	return s.repo.Search(strings.ToLower(query))
+// SearchUsers searches a page of users by first name
+func (s *userUseCase) SearchUsers(query string, opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	return s.repo.Search(strings.ToLower(query), opts)
 }
 
-// ListUsers lists users
-func (s *userUseCase) ListUsers() ([]*entity.User, error) {
-	return s.repo.List()
+// ListUsers lists a page of users
+func (s *userUseCase) ListUsers(opts repository.ListOptions) ([]*entity.User, *repository.Page, error) {
+	return s.repo.List(opts)
+}
+
+// exportPageSize is how many users an export reads at a time
+const exportPageSize = 500
+
+// ExportUsers hands every user to each, by order of id, reading them a page at
+// a time. Each gets a copy whose password hash is blanked out so it cannot leak.
+func (s *userUseCase) ExportUsers(each func(u *entity.User) error) error {
+	return repository.EachPage(exportPageSize, func(opts repository.ListOptions) (*repository.Page, error) {
+		users, page, err := s.repo.List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			e := *u
+			e.Password = ""
+			err = each(&e)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return page, nil
+	})
 }
 
 // DeleteUser deletes an user
@@ -88,3 +130,89 @@ func (s *userUseCase) UpdateUser(e *entity.User) error {
 	e.UpdatedAt = time.Now()
 	return s.repo.Update(e)
 }
+
+// UpdateProfile changes the names, email or password of an user, emails are unique
+// whatever their case. A new email or password takes the current password.
+func (s *userUseCase) UpdateProfile(id string, p *entity.ProfileUpdate) (*entity.User, error) {
+	current, err := s.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	u := *current
+	p.Apply(&u)
+	if p.Password != nil && *p.Password == "" {
+		return nil, entity.ErrInvalidUserEntity
+	}
+	if p.Password != nil || u.Email != current.Email {
+		err = s.password.Compare(current.Password, p.CurrentPassword)
+		if err != nil {
+			return nil, entity.ErrWrongPassword
+		}
+	}
+	if !strings.EqualFold(u.Email, current.Email) {
+		err = s.emailFree(u.Email, u.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.Password != nil {
+		u.Password, err = s.password.Generate(*p.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.UpdateUser(&u)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// emailFree tells with ErrEmailTaken when an user other than id has the email
+func (s *userUseCase) emailFree(email string, id entity.ID) error {
+	other, err := s.repo.GetByEmail(email)
+	if err == entity.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if other.ID != id {
+		return entity.ErrEmailTaken
+	}
+	return nil
+}
+
+// SetTier changes the membership tier of an user
+func (s *userUseCase) SetTier(id string, t entity.Tier) (*entity.User, error) {
+	u, err := s.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	err = u.SetTier(t)
+	if err != nil {
+		return nil, err
+	}
+	err = s.UpdateUser(u)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// SetRole changes the role of an user
+func (s *userUseCase) SetRole(id string, r entity.Role) (*entity.User, error) {
+	u, err := s.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	err = u.SetRole(r)
+	if err != nil {
+		return nil, err
+	}
+	err = s.UpdateUser(u)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
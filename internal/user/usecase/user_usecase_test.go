@@ -8,6 +8,8 @@ import (
 	"github.com/sgraham785/gocleanarch-example/internal/user/infrastructure"
 	"github.com/sgraham785/gocleanarch-example/internal/user/usecase"
 	"github.com/sgraham785/gocleanarch-example/pkg/logger"
+	"github.com/sgraham785/gocleanarch-example/pkg/password"
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
 	"github.com/sgraham785/gocleanarch-example/pkg/server"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,12 +32,14 @@ func Test_userUseCase_CreateUser(t *testing.T) {
 	s := &server.Server{
 		Log: logger,
 	}
-	uc := usecase.New(s, r)
+	uc := usecase.New(s, r, password.NewFakeService())
 	u := newFixtureUser()
 	_, err := uc.CreateUser(u.Email, u.Password, u.FirstName, u.LastName)
 	assert.Nil(t, err)
 	assert.False(t, u.CreatedAt.IsZero())
 	assert.True(t, u.UpdatedAt.IsZero())
+	_, err = uc.CreateUser("OZZY@MetalGods.net", u.Password, u.FirstName, u.LastName)
+	assert.Equal(t, entity.ErrEmailTaken, err)
 }
 
 func Test_userUseCase_SearchUsers(t *testing.T) {
@@ -45,28 +49,47 @@ func Test_userUseCase_SearchUsers(t *testing.T) {
 	s := &server.Server{
 		Log: logger,
 	}
-	uc := usecase.New(s, r)
+	uc := usecase.New(s, r, password.NewFakeService())
 	u1 := newFixtureUser()
 	u2 := newFixtureUser()
 	u2.FirstName = "Lemmy"
+	u2.Email = "lemmy@metalgods.net"
 
 	uID, _ := uc.CreateUser(u1.Email, u1.Password, u1.FirstName, u1.LastName)
 	_, _ = uc.CreateUser(u2.Email, u2.Password, u2.FirstName, u2.LastName)
 
 	t.Run("search", func(t *testing.T) {
-		c, err := uc.SearchUsers("ozzy")
+		c, page, err := uc.SearchUsers("ozzy", repository.ListOptions{})
 		assert.Nil(t, err)
+		assert.Equal(t, 1, page.Total)
 		assert.Equal(t, 1, len(c))
 		assert.Equal(t, "Osbourne", c[0].LastName)
 
-		c, err = uc.SearchUsers("dio")
+		c, _, err = uc.SearchUsers("dio", repository.ListOptions{})
 		assert.Equal(t, entity.ErrUserNotFound, err)
 		assert.Nil(t, c)
 	})
 	t.Run("list all", func(t *testing.T) {
-		all, err := uc.ListUsers()
+		all, page, err := uc.ListUsers(repository.ListOptions{})
 		assert.Nil(t, err)
 		assert.Equal(t, 2, len(all))
+		assert.Equal(t, 2, page.Total)
+	})
+	t.Run("list a page", func(t *testing.T) {
+		opts := repository.ListOptions{Limit: 1, Sort: "first_name"}
+		first, page, err := uc.ListUsers(opts)
+		assert.Nil(t, err)
+		assert.Equal(t, "Lemmy", first[0].FirstName)
+		assert.Equal(t, 2, page.Total)
+
+		opts.Cursor = page.Next
+		second, page, err := uc.ListUsers(opts)
+		assert.Nil(t, err)
+		assert.Equal(t, "Ozzy", second[0].FirstName)
+		assert.Equal(t, "", page.Next)
+
+		_, _, err = uc.ListUsers(repository.ListOptions{Sort: "password"})
+		assert.Equal(t, repository.ErrInvalidSort, err)
 	})
 
 	t.Run("get", func(t *testing.T) {
@@ -83,7 +106,7 @@ func Test_userUseCase_UpdateUser(t *testing.T) {
 	s := &server.Server{
 		Log: logger,
 	}
-	uc := usecase.New(s, r)
+	uc := usecase.New(s, r, password.NewFakeService())
 	u := newFixtureUser()
 	id, err := uc.CreateUser(u.Email, u.Password, u.FirstName, u.LastName)
 	assert.Nil(t, err)
@@ -105,7 +128,7 @@ func Test_userUseCase_DeleteUser(t *testing.T) {
 	s := &server.Server{
 		Log: logger,
 	}
-	uc := usecase.New(s, r)
+	uc := usecase.New(s, r, password.NewFakeService())
 	u1 := newFixtureUser()
 	u2 := newFixtureUser()
 	u2ID, _ := uc.CreateUser(u2.Email, u2.Password, u2.FirstName, u2.LastName)
@@ -126,3 +149,136 @@ func Test_userUseCase_DeleteUser(t *testing.T) {
 	err = uc.DeleteUser(id.String())
 	assert.Equal(t, entity.ErrUserCannotBeDeleted, err)
 }
+
+func Test_userUseCase_SetTier(t *testing.T) {
+	r := infrastructure.NewInMemRepo()
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	uc := usecase.New(s, r, password.NewFakeService())
+	u := newFixtureUser()
+	id, _ := uc.CreateUser(u.Email, u.Password, u.FirstName, u.LastName)
+
+	_, err := uc.SetTier(entity.NewID().String(), entity.TierStaff)
+	assert.Equal(t, entity.ErrUserNotFound, err)
+	_, err = uc.SetTier(id.String(), "gold")
+	assert.Equal(t, entity.ErrInvalidTier, err)
+	updated, err := uc.SetTier(id.String(), entity.TierStaff)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.TierStaff, updated.Tier)
+	saved, _ := uc.GetUser(id.String())
+	assert.Equal(t, entity.TierStaff, saved.Tier)
+}
+
+func Test_userUseCase_SetRole(t *testing.T) {
+	r := infrastructure.NewInMemRepo()
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	uc := usecase.New(s, r, password.NewFakeService())
+	u := newFixtureUser()
+	id, _ := uc.CreateUser(u.Email, u.Password, u.FirstName, u.LastName)
+
+	_, err := uc.SetRole(entity.NewID().String(), entity.RoleAdmin)
+	assert.Equal(t, entity.ErrUserNotFound, err)
+	_, err = uc.SetRole(id.String(), "owner")
+	assert.Equal(t, entity.ErrInvalidRole, err)
+	updated, err := uc.SetRole(id.String(), entity.RoleLibrarian)
+	assert.Nil(t, err)
+	assert.Equal(t, entity.RoleLibrarian, updated.Role)
+	saved, _ := uc.GetUser(id.String())
+	assert.Equal(t, entity.RoleLibrarian, saved.Role)
+}
+
+func Test_userUseCase_UpdateProfile(t *testing.T) {
+	r := infrastructure.NewInMemRepo()
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	uc := usecase.New(s, r, password.NewFakeService())
+	u := newFixtureUser()
+	id, _ := uc.CreateUser(u.Email, u.Password, u.FirstName, u.LastName)
+	_, _ = uc.CreateUser("lemmy@metalgods.net", "123456", "Lemmy", "Kilmister")
+	str := func(s string) *string { return &s }
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := uc.UpdateProfile(entity.NewID().String(), &entity.ProfileUpdate{FirstName: str("John")})
+		assert.Equal(t, entity.ErrUserNotFound, err)
+	})
+	t.Run("names", func(t *testing.T) {
+		updated, err := uc.UpdateProfile(id.String(), &entity.ProfileUpdate{FirstName: str("John")})
+		assert.Nil(t, err)
+		assert.Equal(t, "John", updated.FirstName)
+		assert.Equal(t, "Osbourne", updated.LastName)
+		_, err = uc.UpdateProfile(id.String(), &entity.ProfileUpdate{LastName: str("")})
+		assert.Equal(t, entity.ErrInvalidUserEntity, err)
+	})
+	t.Run("email taken", func(t *testing.T) {
+		_, err := uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Email: str("LEMMY@metalgods.net"), CurrentPassword: "123456"})
+		assert.Equal(t, entity.ErrEmailTaken, err)
+		saved, _ := uc.GetUser(id.String())
+		assert.Equal(t, u.Email, saved.Email)
+	})
+	t.Run("email", func(t *testing.T) {
+		saved, _ := uc.GetUser(id.String())
+		_ = saved.Verify()
+		_, err := uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Email: str("prince@darkness.net")})
+		assert.Equal(t, entity.ErrWrongPassword, err)
+		_, err = uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Email: str("prince@darkness.net"), CurrentPassword: "wrong"})
+		assert.Equal(t, entity.ErrWrongPassword, err)
+		updated, err := uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Email: str(u.Email)})
+		assert.Nil(t, err)
+		assert.True(t, updated.IsVerified())
+		updated, err = uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Email: str("OZZY@metalgods.net"), CurrentPassword: "123456"})
+		assert.Nil(t, err)
+		assert.True(t, updated.IsVerified())
+		updated, err = uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Email: str("prince@darkness.net"), CurrentPassword: "123456"})
+		assert.Nil(t, err)
+		assert.Equal(t, "prince@darkness.net", updated.Email)
+		assert.False(t, updated.IsVerified())
+	})
+	t.Run("password", func(t *testing.T) {
+		_, err := uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Password: str("654321"), CurrentPassword: "wrong"})
+		assert.Equal(t, entity.ErrWrongPassword, err)
+		_, err = uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Password: str(""), CurrentPassword: "123456"})
+		assert.Equal(t, entity.ErrInvalidUserEntity, err)
+		updated, err := uc.UpdateProfile(id.String(), &entity.ProfileUpdate{Password: str("654321"), CurrentPassword: "123456"})
+		assert.Nil(t, err)
+		assert.Equal(t, "654321", updated.Password)
+	})
+}
+
+func Test_userUseCase_ExportUsers(t *testing.T) {
+	r := infrastructure.NewInMemRepo()
+	logger := logger.New()
+	defer logger.Zap.Sync()
+	s := &server.Server{
+		Log: logger,
+	}
+	uc := usecase.New(s, r, password.NewFakeService())
+	u := newFixtureUser()
+	id, err := uc.CreateUser(u.Email, u.Password, u.FirstName, u.LastName)
+	assert.Nil(t, err)
+	_, err = uc.CreateUser("lemmy@metalgods.net", u.Password, "Lemmy", "Kilmister")
+	assert.Nil(t, err)
+
+	var users []*entity.User
+	err = uc.ExportUsers(func(u *entity.User) error {
+		users = append(users, u)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	for _, e := range users {
+		assert.Equal(t, "", e.Password)
+	}
+	saved, err := uc.GetUser(id.String())
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", saved.Password)
+}
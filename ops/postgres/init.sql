@@ -9,23 +9,299 @@ CREATE TABLE IF NOT EXISTS "user" (
   password varchar(255),
   first_name varchar(100),
   last_name varchar(100),
+  tier varchar(20) NOT NULL DEFAULT 'standard',
+  role varchar(20) NOT NULL DEFAULT 'member',
+  verified_at TIMESTAMP,
   created_at TIMESTAMP NOT NULL DEFAULT NOW(),
   updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
   PRIMARY KEY (id));
 
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS tier varchar(20) NOT NULL DEFAULT 'standard';
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS role varchar(20) NOT NULL DEFAULT 'member';
+
+-- users who had an account before email verification are trusted as verified
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'user' AND column_name = 'verified_at') THEN
+    ALTER TABLE "user" ADD COLUMN verified_at TIMESTAMP;
+    UPDATE "user" SET verified_at = created_at;
+  END IF;
+END $$;
+
 CREATE TABLE IF NOT EXISTS book (
   id varchar(50),
+  isbn varchar(13),
   title varchar(255),
   author varchar(255),
   pages integer,
-  quantity integer,
+  version integer NOT NULL DEFAULT 1,
+  language varchar(3) NOT NULL DEFAULT '',
+  published_at date,
+  tags text[] NOT NULL DEFAULT '{}',
+  search tsvector GENERATED ALWAYS AS (
+      setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+      setweight(to_tsvector('simple', coalesce(author, '')), 'B')) STORED,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+-- bumped by every update, books are edited with a compare and swap on it
+ALTER TABLE book ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+-- lists are paged on (sort column, id)
+CREATE INDEX IF NOT EXISTS book_title_id_idx ON book (title, id);
+CREATE INDEX IF NOT EXISTS book_author_id_idx ON book (author, id);
+CREATE INDEX IF NOT EXISTS book_pages_id_idx ON book (pages, id);
+CREATE INDEX IF NOT EXISTS book_created_at_id_idx ON book (created_at, id);
+
+-- full text search over title and author, title words rank above author words.
+-- The simple configuration does not stem so author names are matched as typed
+ALTER TABLE book ADD COLUMN IF NOT EXISTS search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(author, '')), 'B')) STORED;
+CREATE INDEX IF NOT EXISTS book_search_idx ON book USING GIN (search);
+
+-- catalog filters, tags are lower case
+ALTER TABLE book ADD COLUMN IF NOT EXISTS language varchar(3) NOT NULL DEFAULT '';
+ALTER TABLE book ADD COLUMN IF NOT EXISTS published_at date;
+ALTER TABLE book ADD COLUMN IF NOT EXISTS tags text[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS book_tags_idx ON book USING GIN (tags);
+CREATE INDEX IF NOT EXISTS book_language_idx ON book (language);
+CREATE INDEX IF NOT EXISTS book_published_at_idx ON book (published_at);
+
+-- ISBN-13 of digits only, null for the books without one
+ALTER TABLE book ADD COLUMN IF NOT EXISTS isbn varchar(13);
+CREATE UNIQUE INDEX IF NOT EXISTS book_isbn_key ON book (isbn);
+
+CREATE TABLE IF NOT EXISTS author (
+  id varchar(50),
+  name varchar(255) NOT NULL,
+  dates varchar(50) NOT NULL DEFAULT '',
   created_at TIMESTAMP NOT NULL DEFAULT NOW(),
   updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
   PRIMARY KEY (id));
 
-CREATE TABLE IF NOT EXISTS book_user (
-  user_id varchar(50),
-  book_id varchar(50),
+CREATE INDEX IF NOT EXISTS author_lower_name_id_idx ON author (lower(name), id);
+CREATE INDEX IF NOT EXISTS author_created_at_id_idx ON author (created_at, id);
+
+-- the authors credited on a book, position orders them
+CREATE TABLE IF NOT EXISTS book_author (
+  book_id varchar(50) NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+  author_id varchar(50) NOT NULL REFERENCES author (id) ON DELETE CASCADE,
+  position integer NOT NULL,
+  PRIMARY KEY (book_id, author_id));
+
+CREATE INDEX IF NOT EXISTS book_author_author_id_book_id_idx ON book_author (author_id, book_id);
+
+-- book.author is free text: the books credited to no author yet get an author record
+-- for every name of it, split on ';', '&' and 'and'. Commas are left alone as names are
+-- often written last name first. A name already known, whatever its case, is the same
+-- author, the oldest one when several share it. The ids look like xids, 19 hex digits
+-- and a 0 so they decode and encode back the same.
+DROP TABLE IF EXISTS book_author_name;
+CREATE TEMP TABLE book_author_name AS
+  SELECT b.id AS book_id, trim(n.name) AS name, n.position
+  FROM book b
+  CROSS JOIN LATERAL regexp_split_to_table(b.author, '\s*(;|&|\s+and\s+)\s*') WITH ORDINALITY AS n(name, position)
+  WHERE trim(n.name) <> '' AND NOT EXISTS (SELECT 1 FROM book_author ba WHERE ba.book_id = b.id);
+
+INSERT INTO author (id, name)
+  SELECT DISTINCT ON (lower(name)) substr(md5(lower(name)), 1, 19) || '0', name
+  FROM book_author_name n
+  WHERE NOT EXISTS (SELECT 1 FROM author a WHERE lower(a.name) = lower(n.name))
+  ORDER BY lower(name), name
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO book_author (book_id, author_id, position)
+  SELECT book_id, author_id, min(position) FROM (
+    SELECT n.book_id, n.position,
+      (SELECT a.id FROM author a WHERE lower(a.name) = lower(n.name) ORDER BY a.created_at, a.id LIMIT 1) AS author_id
+    FROM book_author_name n) credited
+  GROUP BY book_id, author_id
+ON CONFLICT DO NOTHING;
+
+DROP TABLE book_author_name;
+
+-- subjects and genres make a tree, a subject with none above it has no parent_id
+CREATE TABLE IF NOT EXISTS subject (
+  id varchar(50),
+  name varchar(255) NOT NULL,
+  parent_id varchar(50) REFERENCES subject (id),
   created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-  PRIMARY KEY (user_id, book_id));
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+CREATE INDEX IF NOT EXISTS subject_parent_id_idx ON subject (parent_id);
+CREATE INDEX IF NOT EXISTS subject_lower_name_id_idx ON subject (lower(name), id);
+
+CREATE TABLE IF NOT EXISTS book_subject (
+  book_id varchar(50) NOT NULL REFERENCES book (id) ON DELETE CASCADE,
+  subject_id varchar(50) NOT NULL REFERENCES subject (id) ON DELETE CASCADE,
+  PRIMARY KEY (book_id, subject_id));
+
+CREATE INDEX IF NOT EXISTS book_subject_subject_id_book_id_idx ON book_subject (subject_id, book_id);
+
+CREATE TABLE IF NOT EXISTS series (
+  id varchar(50),
+  name varchar(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+CREATE INDEX IF NOT EXISTS series_lower_name_id_idx ON series (lower(name), id);
+
+-- a work groups the editions of a book, series_position orders the works of a series
+CREATE TABLE IF NOT EXISTS work (
+  id varchar(50),
+  title varchar(255) NOT NULL,
+  series_id varchar(50) REFERENCES series (id),
+  series_position integer NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+CREATE INDEX IF NOT EXISTS work_series_id_series_position_idx ON work (series_id, series_position);
+CREATE INDEX IF NOT EXISTS work_lower_title_id_idx ON work (lower(title), id);
+
+ALTER TABLE book ADD COLUMN IF NOT EXISTS work_id varchar(50) REFERENCES work (id);
+CREATE INDEX IF NOT EXISTS book_work_id_idx ON book (work_id);
+
+CREATE TABLE IF NOT EXISTS copy (
+  barcode varchar(50),
+  book_id varchar(50) NOT NULL,
+  condition varchar(20) NOT NULL DEFAULT 'new',
+  location varchar(100) NOT NULL DEFAULT '',
+  status varchar(20) NOT NULL DEFAULT 'available',
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (barcode));
+
+CREATE INDEX IF NOT EXISTS copy_book_id_status_idx ON copy (book_id, status);
+
+CREATE TABLE IF NOT EXISTS loan (
+  id varchar(50),
+  user_id varchar(50) NOT NULL,
+  book_id varchar(50) NOT NULL,
+  barcode varchar(50),
+  status varchar(20) NOT NULL,
+  borrowed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  due_at TIMESTAMP NOT NULL,
+  returned_at TIMESTAMP,
+  renewals integer NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+-- borrow history is read per user or per book, by the day the book was borrowed
+DROP INDEX IF EXISTS loan_user_id_idx;
+DROP INDEX IF EXISTS loan_book_id_idx;
+CREATE INDEX IF NOT EXISTS loan_user_id_borrowed_at_idx ON loan (user_id, borrowed_at);
+CREATE INDEX IF NOT EXISTS loan_book_id_borrowed_at_idx ON loan (book_id, borrowed_at);
+CREATE INDEX IF NOT EXISTS loan_open_due_at_idx ON loan (due_at) WHERE returned_at IS NULL;
+CREATE INDEX IF NOT EXISTS loan_open_user_id_book_id_idx ON loan (user_id, book_id) WHERE returned_at IS NULL;
+
+ALTER TABLE loan ADD COLUMN IF NOT EXISTS barcode varchar(50);
+CREATE UNIQUE INDEX IF NOT EXISTS loan_open_barcode_idx ON loan (barcode) WHERE returned_at IS NULL;
+
+-- loan replaces book_user, the books still out move over as active loans due after the
+-- standard loan period (STANDARD_LOAN_PERIOD, 336h by default). Their ids are cut from an
+-- md5 to the 20 characters of an xid, the last one 0 so it reads back the same.
+DO $$
+BEGIN
+  IF to_regclass('book_user') IS NOT NULL THEN
+    INSERT INTO loan (id, user_id, book_id, status, borrowed_at, due_at, created_at, updated_at)
+      SELECT substr(md5(user_id || '-' || book_id), 1, 19) || '0', user_id, book_id, 'active',
+        created_at, created_at + interval '336 hours', created_at, NOW()
+      FROM book_user
+      ON CONFLICT (id) DO NOTHING;
+    DROP TABLE book_user;
+  END IF;
+END $$;
+
+-- copies replace book.quantity: the shelved copies and one per open loan
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'book' AND column_name = 'quantity') THEN
+    INSERT INTO copy (barcode, book_id, condition)
+      SELECT upper(book.id) || '-' || n, book.id, 'good' FROM book, generate_series(1, book.quantity) n;
+    UPDATE loan SET barcode = upper(id) WHERE returned_at IS NULL AND barcode IS NULL;
+    INSERT INTO copy (barcode, book_id, condition, status)
+      SELECT barcode, book_id, 'good', 'on_loan' FROM loan WHERE returned_at IS NULL;
+    ALTER TABLE book DROP COLUMN quantity;
+  END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS hold (
+  id varchar(50),
+  user_id varchar(50) NOT NULL,
+  book_id varchar(50) NOT NULL,
+  status varchar(20) NOT NULL,
+  placed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  ready_at TIMESTAMP,
+  expires_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+CREATE INDEX IF NOT EXISTS hold_book_id_placed_at_idx ON hold (book_id, placed_at);
+CREATE INDEX IF NOT EXISTS hold_user_id_idx ON hold (user_id);
+
+-- a hold on a work waits for any of its editions, book_id is set once a copy is set aside.
+-- A work is only deleted once no hold is open on it, closed holds keep their book
+ALTER TABLE hold ADD COLUMN IF NOT EXISTS work_id varchar(50);
+ALTER TABLE hold DROP CONSTRAINT IF EXISTS hold_work_id_fkey;
+ALTER TABLE hold ADD CONSTRAINT hold_work_id_fkey FOREIGN KEY (work_id) REFERENCES work (id) ON DELETE SET NULL;
+ALTER TABLE hold ALTER COLUMN book_id DROP NOT NULL;
+CREATE INDEX IF NOT EXISTS hold_work_id_placed_at_idx ON hold (work_id, placed_at);
+
+CREATE TABLE IF NOT EXISTS fine_transaction (
+  id varchar(50),
+  user_id varchar(50) NOT NULL,
+  loan_id varchar(50),
+  charge_id varchar(50),
+  kind varchar(20) NOT NULL,
+  amount bigint NOT NULL CHECK (amount > 0),
+  reason text NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (id));
+
+CREATE INDEX IF NOT EXISTS fine_transaction_user_id_idx ON fine_transaction (user_id, created_at);
+
+
+-- emails are unique whatever their case, duplicate accounts have to be merged by hand
+-- before the index can be built, they are listed by
+--   SELECT lower(email), count(*) FROM "user" GROUP BY 1 HAVING count(*) > 1;
+DROP INDEX IF EXISTS user_lower_email_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS user_lower_email_key ON "user" (lower(email));
+CREATE INDEX IF NOT EXISTS user_last_name_id_idx ON "user" (last_name, id);
+CREATE INDEX IF NOT EXISTS user_created_at_id_idx ON "user" (created_at, id);
+
+CREATE TABLE IF NOT EXISTS refresh_token (
+  id varchar(50),
+  user_id varchar(50) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL,
+  revoked_at TIMESTAMP,
+  PRIMARY KEY (id));
+
+CREATE INDEX IF NOT EXISTS refresh_token_user_id_idx ON refresh_token (user_id);
+
+-- access tokens logged out before they expire
+CREATE TABLE IF NOT EXISTS revoked_token (
+  id varchar(50),
+  expires_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (id));
+
+-- single use tokens mailed for password resets and email verification, by hash of their secret
+CREATE TABLE IF NOT EXISTS one_time_token (
+  id varchar(50),
+  user_id varchar(50) NOT NULL,
+  purpose varchar(30) NOT NULL,
+  email varchar(255) NOT NULL,
+  hash varchar(64) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL,
+  used_at TIMESTAMP,
+  PRIMARY KEY (id));
 
+CREATE UNIQUE INDEX IF NOT EXISTS one_time_token_hash_idx ON one_time_token (hash);
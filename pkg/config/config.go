@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -18,6 +20,70 @@ type Specification struct {
 	PostgresConf          `desc:"PostgreSQL config"`
 	PrometheusPushgateway string `required:"true" split_words:"true"`
 	APIPort               int    `default:"9000" split_words:"true"`
+	LoanConf              `desc:"Loan config"`
+	FineConf              `desc:"Fine config"`
+	TierConf              `desc:"Membership tier config"`
+	AuthConf              `desc:"Authentication config"`
+	PasswordConf          `desc:"Password hashing config"`
+	MailConf              `desc:"Mail config"`
+}
+
+// LoanConf is the specification for loan configs, an overdue sweep interval of zero turns the sweeper off
+type LoanConf struct {
+	OverdueSweepInterval time.Duration `default:"1h" split_words:"true"`
+	RenewalGracePeriod   time.Duration `default:"24h" split_words:"true"`
+	HoldPickupWindow     time.Duration `default:"72h" split_words:"true"`
+}
+
+// FineConf is the specification for overdue fines, amounts are in cents
+type FineConf struct {
+	FineDailyRate      int64 `default:"25" split_words:"true"`
+	FineMax            int64 `default:"1000" split_words:"true"`
+	FineBlockThreshold int64 `default:"500" split_words:"true"`
+}
+
+// TierConf is the specification for what each membership tier may borrow
+type TierConf struct {
+	StandardMaxLoans    int           `default:"5" split_words:"true"`
+	StandardLoanPeriod  time.Duration `default:"336h" split_words:"true"`
+	StandardMaxRenewals int           `default:"2" split_words:"true"`
+	PremiumMaxLoans     int           `default:"10" split_words:"true"`
+	PremiumLoanPeriod   time.Duration `default:"504h" split_words:"true"`
+	PremiumMaxRenewals  int           `default:"4" split_words:"true"`
+	StaffMaxLoans       int           `default:"20" split_words:"true"`
+	StaffLoanPeriod     time.Duration `default:"672h" split_words:"true"`
+	StaffMaxRenewals    int           `default:"6" split_words:"true"`
+}
+
+// AuthConf is the specification for login tokens
+type AuthConf struct {
+	AuthSigningKey  string        `required:"true" split_words:"true"`
+	AccessTokenTTL  time.Duration `default:"15m" split_words:"true"`
+	RefreshTokenTTL time.Duration `default:"720h" split_words:"true"`
+	// single use tokens mailed to users
+	PasswordResetTTL     time.Duration `default:"1h" split_words:"true"`
+	EmailVerificationTTL time.Duration `default:"48h" split_words:"true"`
+}
+
+// PasswordConf is the specification for password hashing, hasher is argon2id or bcrypt
+// and argon2 memory is in KiB
+type PasswordConf struct {
+	PasswordHasher string `default:"argon2id" split_words:"true"`
+	BcryptCost     int    `default:"10" split_words:"true"`
+	Argon2Time     uint32 `default:"1" split_words:"true"`
+	Argon2Memory   uint32 `default:"65536" split_words:"true"`
+	Argon2Threads  uint8  `default:"4" split_words:"true"`
+}
+
+// MailConf is the specification for outgoing emails, mailer is smtp, file or memory
+type MailConf struct {
+	Mailer       string `default:"file" split_words:"true"`
+	MailFrom     string `default:"library@localhost" split_words:"true"`
+	MailDir      string `default:"mail" split_words:"true"`
+	SMTPHost     string `split_words:"true"`
+	SMTPPort     int    `default:"587" split_words:"true"`
+	SMTPUser     string `split_words:"true"`
+	SMTPPassword string `split_words:"true"`
 }
 
 // Load is what loads the config.
@@ -0,0 +1,70 @@
+package mailer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// File writes emails as .eml files in a directory, for local development
+type File struct {
+	mtx  sync.Mutex
+	dir  string
+	from string
+}
+
+// NewFile create a mailer writing to dir
+func NewFile(dir, from string) *File {
+	return &File{dir: dir, from: from}
+}
+
+// Send an email
+func (f *File) Send(m Message) error {
+	err := validate(m)
+	if err != nil {
+		return err
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	err = os.MkdirAll(f.dir, 0o755)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), strings.NewReplacer("@", "_at_", "/", "_").Replace(m.To))
+	return ioutil.WriteFile(filepath.Join(f.dir, name), format(f.from, m, now), 0o600)
+}
+
+// InMem keeps the emails it is given, for tests
+type InMem struct {
+	mtx  sync.RWMutex
+	sent []Message
+}
+
+// NewInMem create a mailer keeping emails in memory
+func NewInMem() *InMem {
+	return &InMem{}
+}
+
+// Send an email
+func (i *InMem) Send(m Message) error {
+	err := validate(m)
+	if err != nil {
+		return err
+	}
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	i.sent = append(i.sent, m)
+	return nil
+}
+
+// Sent returns the emails sent so far, oldest first
+func (i *InMem) Sent() []Message {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+	return append([]Message(nil), i.sent...)
+}
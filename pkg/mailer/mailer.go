@@ -0,0 +1,60 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
+)
+
+// ErrUnknownMailer the configured mailer is not supported
+var ErrUnknownMailer = errors.New("Unknown mailer")
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails
+type Mailer interface {
+	Send(m Message) error
+}
+
+// New create the mailer set by the config
+func New(c config.MailConf) (Mailer, error) {
+	switch c.Mailer {
+	case "smtp":
+		return NewSMTP(c.SMTPHost, c.SMTPPort, c.SMTPUser, c.SMTPPassword, c.MailFrom), nil
+	case "file":
+		return NewFile(c.MailDir, c.MailFrom), nil
+	case "memory":
+		return NewInMem(), nil
+	}
+	return nil, ErrUnknownMailer
+}
+
+// format renders m as an RFC 5322 message
+func format(from string, m Message, t time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", t.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// validate rejects headers an attacker could use to inject other headers
+func validate(m Message) error {
+	if m.To == "" || strings.ContainsAny(m.To+m.Subject, "\r\n") {
+		return fmt.Errorf("invalid message to %q", m.To)
+	}
+	return nil
+}
@@ -35,7 +35,7 @@ func NewMetrics(name string, buckets ...float64) func(next http.Handler) http.Ha
 		},
 		[]string{"code", "method", "path"},
 	)
-	prometheus.MustRegister(m.reqs)
+	m.reqs = register(m.reqs).(*prometheus.CounterVec)
 
 	if len(buckets) == 0 {
 		buckets = dflBuckets
@@ -48,10 +48,24 @@ func NewMetrics(name string, buckets ...float64) func(next http.Handler) http.Ha
 	},
 		[]string{"code", "method", "path"},
 	)
-	prometheus.MustRegister(m.latency)
+	m.latency = register(m.latency).(*prometheus.HistogramVec)
 	return m.handler
 }
 
+// register registers c, or returns the collector already registered under its name.
+// Every router built in a process shares the same metrics, where MustRegister would
+// panic on the second NewChiRouter, as the handler tests of a package build several.
+func register(c prometheus.Collector) prometheus.Collector {
+	err := prometheus.Register(c)
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		return are.ExistingCollector
+	}
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
 func (c Metrics) handler(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
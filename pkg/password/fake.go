@@ -1,7 +1,5 @@
 package password
 
-import "errors"
-
 //FakePassword password
 type FakePassword struct{}
 
@@ -20,5 +18,10 @@ func (p *FakePassword) Compare(p1, p2 string) error {
 	if p1 == p2 {
 		return nil
 	}
-	return errors.New("Invalid password")
+	return ErrMismatchedPassword
+}
+
+//NeedsRehash fake passwords never need it
+func (p *FakePassword) NeedsRehash(hash string) bool {
+	return false
 }
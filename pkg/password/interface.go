@@ -4,4 +4,5 @@ package password
 type Service interface {
 	Generate(raw string) (string, error)
 	Compare(p1, p2 string) error
+	NeedsRehash(hash string) bool
 }
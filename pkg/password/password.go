@@ -5,27 +5,36 @@ import (
 )
 
 //Password password
-type Password struct{}
+type Password struct {
+	cost int
+}
 
-//NewService create a new fake password
+//NewService create a new bcrypt password service with the default cost
 func NewService() *Password {
-	return &Password{}
+	return NewBcryptService(bcrypt.DefaultCost)
+}
+
+//NewBcryptService create a new bcrypt password service hashing with cost
+func NewBcryptService(cost int) *Password {
+	return &Password{cost: cost}
 }
 
 //Generate a new password
 func (p *Password) Generate(raw string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(raw), 10)
+	hash, err := bcrypt.GenerateFromPassword([]byte(raw), p.cost)
 	if err != nil {
 		return "", err
 	}
 	return string(hash), nil
 }
 
-//Compare compare two passwords
+//Compare compare a hash, of any supported algorithm, with a raw password
 func (p *Password) Compare(p1, p2 string) error {
-	err := bcrypt.CompareHashAndPassword([]byte(p1), []byte(p2))
-	if err != nil {
-		return err
-	}
-	return nil
+	return compare(p1, p2)
+}
+
+//NeedsRehash tells if hash was made by another algorithm or another cost
+func (p *Password) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != p.cost
 }
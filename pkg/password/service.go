@@ -0,0 +1,51 @@
+package password
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sgraham785/gocleanarch-example/pkg/config"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	//ErrMismatchedPassword the password does not match the hash
+	ErrMismatchedPassword = errors.New("Invalid password")
+	//ErrInvalidHash the hash was not made by a supported algorithm
+	ErrInvalidHash = errors.New("Invalid password hash")
+	//ErrUnknownHasher the configured algorithm is not supported
+	ErrUnknownHasher = errors.New("Unknown password hasher")
+	//ErrInvalidArgon2Params argon2 needs a pass, a thread and 8 KiB of memory per thread
+	ErrInvalidArgon2Params = errors.New("Invalid argon2 parameters")
+)
+
+//New create the password service set by the config
+func New(c config.PasswordConf) (Service, error) {
+	switch c.PasswordHasher {
+	case "argon2id":
+		if c.Argon2Time < 1 || c.Argon2Threads < 1 || c.Argon2Memory < 8*uint32(c.Argon2Threads) {
+			return nil, ErrInvalidArgon2Params
+		}
+		return NewArgon2idService(Argon2idParams{
+			Time:    c.Argon2Time,
+			Memory:  c.Argon2Memory,
+			Threads: c.Argon2Threads,
+		}), nil
+	case "bcrypt":
+		return NewBcryptService(c.BcryptCost), nil
+	}
+	return nil, ErrUnknownHasher
+}
+
+//compare checks raw against a hash of any supported algorithm, so hashes made
+//before a change of algorithm keep working until they are rehashed
+func compare(hash, raw string) error {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return compareArgon2id(hash, raw)
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(raw))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return ErrMismatchedPassword
+	}
+	return err
+}
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSort the list can not be sorted on the field asked
+	ErrInvalidSort = errors.New("Invalid sort")
+	// ErrInvalidCursor the cursor was not handed out with a previous page
+	ErrInvalidCursor = errors.New("Invalid cursor")
+)
+
+// Direction of a sort
+type Direction string
+
+const (
+	// Asc smallest first
+	Asc Direction = "asc"
+	// Desc greatest first
+	Desc Direction = "desc"
+)
+
+// ListOptions pages and sorts a list. A page starts right after Cursor when it is
+// set, Offset rows in otherwise. Limit 0 lists every row, Sort "" sorts on the id.
+type ListOptions struct {
+	Limit     int
+	Offset    int
+	Cursor    string
+	Sort      string
+	Direction Direction
+}
+
+// Descending tells if the greatest rows come first
+func (o ListOptions) Descending() bool {
+	return o.Direction == Desc
+}
+
+// Page tells where a list stands, Total rows match its filter
+// and Next is the cursor of the following page, "" on the last one
+type Page struct {
+	Total int
+	Next  string
+}
+
+// Cursor names the row a page ends on by its id and its value of the sort field
+func Cursor(id string, key interface{}) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(formatKey(key) + "\x00" + id))
+}
+
+// DecodeCursor returns the sort value and the id a cursor names
+func DecodeCursor(c string) (string, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(c)
+	if err != nil {
+		return "", "", ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(b), "\x00", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", ErrInvalidCursor
+	}
+	return parts[0], parts[1], nil
+}
+
+// Clauses renders the clauses a select adds to its filter to list the page of
+// opts sorted on column: the rows after the cursor, their order and the limit.
+// args are the arguments of the filter. One row more than the limit is asked for
+// so the caller knows if a page follows.
+func Clauses(column string, args []interface{}, opts ListOptions) (string, []interface{}, error) {
+	dir, cmp := "asc", ">"
+	if opts.Descending() {
+		dir, cmp = "desc", "<"
+	}
+	var b strings.Builder
+	if opts.Cursor != "" {
+		value, id, err := DecodeCursor(opts.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		if column == "id" {
+			args = append(args, id)
+			fmt.Fprintf(&b, " and id %s $%d", cmp, len(args))
+		} else {
+			args = append(args, value, id)
+			fmt.Fprintf(&b, " and (%s, id) %s ($%d, $%d)", column, cmp, len(args)-1, len(args))
+		}
+	}
+	if column == "id" {
+		fmt.Fprintf(&b, " order by id %s", dir)
+	} else {
+		fmt.Fprintf(&b, " order by %s %s, id %s", column, dir, dir)
+	}
+	if opts.Limit > 0 {
+		fmt.Fprintf(&b, " limit %d", opts.Limit+1)
+	}
+	if opts.Cursor == "" && opts.Offset > 0 {
+		fmt.Fprintf(&b, " offset %d", opts.Offset)
+	}
+	return b.String(), args, nil
+}
+
+// HasNext tells if n rows fetched with Clauses hold more than the page of opts
+func HasNext(n int, opts ListOptions) bool {
+	return opts.Limit > 0 && n > opts.Limit
+}
+
+// EachPage walks a whole list limit rows at a time, following the cursor of every
+// page list returns until the last one. Exports read lists this way so they never
+// hold more than a page.
+func EachPage(limit int, list func(opts ListOptions) (*Page, error)) error {
+	opts := ListOptions{Limit: limit, Direction: Asc}
+	for {
+		page, err := list(opts)
+		if err != nil {
+			return err
+		}
+		if page == nil || page.Next == "" {
+			return nil
+		}
+		opts.Cursor = page.Next
+	}
+}
+
+// Row is an entry of a list kept in memory, Key is its value of the sort field
+type Row struct {
+	ID  string
+	Key interface{}
+}
+
+// PageRows sorts the rows of an in memory list as opts asks
+// and returns the indexes of the rows of its page
+func PageRows(rows []Row, opts ListOptions) ([]int, *Page, error) {
+	idx := make([]int, len(rows))
+	for i := range rows {
+		idx[i] = i
+	}
+	less := func(a, b Row) bool {
+		if c := compareKeys(a.Key, b.Key); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		if opts.Descending() {
+			return less(rows[idx[j]], rows[idx[i]])
+		}
+		return less(rows[idx[i]], rows[idx[j]])
+	})
+	page := &Page{Total: len(rows)}
+	start := 0
+	switch {
+	case opts.Cursor != "":
+		value, id, err := DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		start = len(idx)
+		for n, i := range idx {
+			after := Row{ID: id}
+			if rows[i].Key != nil {
+				after.Key, err = parseKey(rows[i].Key, value)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			if (!opts.Descending() && less(after, rows[i])) || (opts.Descending() && less(rows[i], after)) {
+				start = n
+				break
+			}
+		}
+	case opts.Offset < len(idx):
+		start = opts.Offset
+	default:
+		start = len(idx)
+	}
+	idx = idx[start:]
+	if opts.Limit > 0 && len(idx) > opts.Limit {
+		idx = idx[:opts.Limit]
+		last := rows[idx[len(idx)-1]]
+		page.Next = Cursor(last.ID, last.Key)
+	}
+	return idx, page, nil
+}
+
+func formatKey(key interface{}) string {
+	switch k := key.(type) {
+	case nil:
+		return ""
+	case string:
+		return k
+	case int:
+		return strconv.Itoa(k)
+	case float64:
+		return strconv.FormatFloat(k, 'g', -1, 64)
+	case time.Time:
+		return k.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(k)
+	}
+}
+
+func parseKey(like interface{}, s string) (interface{}, error) {
+	switch like.(type) {
+	case int:
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return i, nil
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return f, nil
+	case time.Time:
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		return t, nil
+	default:
+		return s, nil
+	}
+}
+
+func compareKeys(a, b interface{}) int {
+	switch x := a.(type) {
+	case int:
+		y, _ := b.(int)
+		return x - y
+	case float64:
+		y, _ := b.(float64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case time.Time:
+		y, _ := b.(time.Time)
+		switch {
+		case x.Before(y):
+			return -1
+		case x.After(y):
+			return 1
+		}
+		return 0
+	case string:
+		y, _ := b.(string)
+		return strings.Compare(x, y)
+	}
+	return 0
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier is what the postgres repos run their queries on,
+// either the connection pool or a transaction
+type Querier interface {
+	sqlx.Ext
+	sqlx.Preparer
+	Select(dest interface{}, query string, args ...interface{}) error
+}
+
+// Transact runs fn in a transaction, committed when fn succeeds and rolled back otherwise
+func (r *Repository) Transact(fn func(tx *sqlx.Tx) error) (err error) {
+	tx, err := r.Pg.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	err = fn(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Within runs fn in tx, for the repos writing through a transaction another one opened
+func Within(tx *sqlx.Tx) func(fn func(tx *sqlx.Tx) error) error {
+	return func(fn func(tx *sqlx.Tx) error) error {
+		return fn(tx)
+	}
+}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Principal is who makes a request, once authenticated
+type Principal struct {
+	ID   string
+	Role string
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal of the request, if any
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Policy tells if a principal may make a request
+type Policy func(p Principal, r *http.Request) bool
+
+// AnyRole allows principals holding one of the roles
+func AnyRole(roles ...string) Policy {
+	return func(p Principal, r *http.Request) bool {
+		for _, role := range roles {
+			if p.Role == role {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Self allows the principal named by the URL parameter param
+func Self(param string) Policy {
+	return func(p Principal, r *http.Request) bool {
+		return p.ID != "" && p.ID == chi.URLParam(r, param)
+	}
+}
+
+// Is allows the principal with id, for ids read from a request body
+func Is(id string) Policy {
+	return func(p Principal, r *http.Request) bool {
+		return p.ID != "" && p.ID == id
+	}
+}
+
+// Can tells if the principal of the request passes any of the policies
+func Can(r *http.Request, policies ...Policy) bool {
+	p, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		return false
+	}
+	for _, policy := range policies {
+		if policy(p, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize is a middleware letting through the requests that pass any of the policies,
+// others get a 401 when nobody is authenticated and a 403 otherwise
+func Authorize(policies ...Policy) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := PrincipalFromContext(r.Context()); !ok {
+				Deny(w, http.StatusUnauthorized, "Authentication required")
+				return
+			}
+			if !Can(r, policies...) {
+				Deny(w, http.StatusForbidden, "Not allowed to "+r.Method+" "+r.URL.Path)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Denial JSON data
+type Denial struct {
+	Status  int    `json:"status"`
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Deny writes a structured denial with the status code
+func Deny(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&Denial{
+		Status:  status,
+		Error:   http.StatusText(status),
+		Message: message,
+	})
+}
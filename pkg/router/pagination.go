@@ -0,0 +1,122 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sgraham785/gocleanarch-example/pkg/repository"
+)
+
+const (
+	// DefaultLimit is the page size of a list when the client does not ask for one
+	DefaultLimit = 50
+	// MaxLimit is the largest page a client can ask for
+	MaxLimit = 500
+)
+
+// ErrInvalidListOptions the query string does not name a page
+var ErrInvalidListOptions = errors.New("Invalid list options")
+
+type listOptionsKey struct{}
+
+// Paginate reads the page a list request asks for from its query string:
+// limit, cursor or offset, sort and order (asc or desc)
+func Paginate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseListOptions(r.URL.Query())
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), listOptionsKey{}, opts)))
+	})
+}
+
+// ListOptionsFromContext returns the page Paginate read, the first one otherwise
+func ListOptionsFromContext(ctx context.Context) repository.ListOptions {
+	opts, ok := ctx.Value(listOptionsKey{}).(repository.ListOptions)
+	if !ok {
+		return repository.ListOptions{Limit: DefaultLimit, Direction: repository.Asc}
+	}
+	return opts
+}
+
+func parseListOptions(q url.Values) (repository.ListOptions, error) {
+	opts := repository.ListOptions{
+		Limit:     DefaultLimit,
+		Cursor:    q.Get("cursor"),
+		Sort:      q.Get("sort"),
+		Direction: repository.Direction(strings.ToLower(q.Get("order"))),
+	}
+	var err error
+	if l := q.Get("limit"); l != "" {
+		opts.Limit, err = strconv.Atoi(l)
+		if err != nil || opts.Limit <= 0 || opts.Limit > MaxLimit {
+			return opts, ErrInvalidListOptions
+		}
+	}
+	if o := q.Get("offset"); o != "" {
+		opts.Offset, err = strconv.Atoi(o)
+		if err != nil || opts.Offset < 0 || opts.Cursor != "" {
+			return opts, ErrInvalidListOptions
+		}
+	}
+	switch opts.Direction {
+	case "":
+		opts.Direction = repository.Asc
+	case repository.Asc, repository.Desc:
+	default:
+		return opts, ErrInvalidListOptions
+	}
+	return opts, nil
+}
+
+// SetPage tells the client about the page it got: the X-Total-Count header holds
+// the number of rows of the list and the Link header the URLs of its other pages.
+// Pages asked for by offset link to the previous and last pages too.
+func SetPage(w http.ResponseWriter, r *http.Request, opts repository.ListOptions, page *repository.Page) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+	if opts.Limit <= 0 {
+		return
+	}
+	link := func(rel string, set func(q url.Values)) string {
+		q := r.URL.Query()
+		q.Del("cursor")
+		q.Del("offset")
+		q.Set("limit", strconv.Itoa(opts.Limit))
+		set(q)
+		u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+	links := []string{link("first", func(q url.Values) {})}
+	if r.URL.Query().Get("offset") == "" {
+		if page.Next != "" {
+			links = append(links, link("next", func(q url.Values) { q.Set("cursor", page.Next) }))
+		}
+		w.Header().Set("Link", strings.Join(links, ", "))
+		return
+	}
+	offset := func(n int) func(q url.Values) {
+		return func(q url.Values) { q.Set("offset", strconv.Itoa(n)) }
+	}
+	if opts.Offset > 0 {
+		prev := opts.Offset - opts.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, link("prev", offset(prev)))
+	}
+	if opts.Offset+opts.Limit < page.Total {
+		links = append(links, link("next", offset(opts.Offset+opts.Limit)))
+	}
+	if page.Total > 0 {
+		links = append(links, link("last", offset((page.Total-1)/opts.Limit*opts.Limit)))
+	}
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
@@ -0,0 +1,105 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken the token is malformed or its signature does not match
+var ErrInvalidToken = errors.New("Invalid token")
+
+// ErrExpiredToken the token is past its expiry
+var ErrExpiredToken = errors.New("Expired token")
+
+// Claims carried by a token
+type Claims struct {
+	ID        string `json:"jti"`
+	Subject   string `json:"sub"`
+	Kind      string `json:"kind"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Expiry is when the token stops being valid
+func (c *Claims) Expiry() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+var encoding = base64.RawURLEncoding
+
+// Signer signs and verifies JWTs with HMAC SHA-256
+type Signer struct {
+	key []byte
+}
+
+// NewSigner create a signer using key as the HMAC secret
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// Sign encodes the claims into a signed JWT
+func (s *Signer) Sign(c Claims) (string, error) {
+	h, err := json.Marshal(header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	unsigned := encoding.EncodeToString(h) + "." + encoding.EncodeToString(p)
+	return unsigned + "." + encoding.EncodeToString(s.signature(unsigned)), nil
+}
+
+// Parse verifies the token and returns its claims when it is still valid at t
+func (s *Signer) Parse(token string, t time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	sig, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if !hmac.Equal(sig, s.signature(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidToken
+	}
+	var h header
+	err = decode(parts[0], &h)
+	if err != nil || h.Alg != "HS256" {
+		return nil, ErrInvalidToken
+	}
+	var c Claims
+	err = decode(parts[1], &c)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if !t.Before(c.Expiry()) {
+		return nil, ErrExpiredToken
+	}
+	return &c, nil
+}
+
+func (s *Signer) signature(unsigned string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned))
+	return mac.Sum(nil)
+}
+
+func decode(part string, v interface{}) error {
+	b, err := encoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}